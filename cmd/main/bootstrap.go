@@ -1,12 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
 	"github.com/Alwanly/go-codebase/pkg/database"
 	"github.com/Alwanly/go-codebase/pkg/deps"
+	"github.com/Alwanly/go-codebase/pkg/grpcserver"
+	"github.com/Alwanly/go-codebase/pkg/logger"
 	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/Alwanly/go-codebase/pkg/outbox"
 	"github.com/Alwanly/go-codebase/pkg/redis"
 	"github.com/Alwanly/go-codebase/pkg/validator"
 	"github.com/gofiber/fiber/v2"
@@ -16,8 +23,12 @@ import (
 	"go.uber.org/zap"
 
 	_ "github.com/Alwanly/go-codebase/api"
+	apikey_handler "github.com/Alwanly/go-codebase/internal/apikey/handler"
 	book_handler "github.com/Alwanly/go-codebase/internal/book/handler"
+	graphql_handler "github.com/Alwanly/go-codebase/internal/graphql/handler"
+	progress_handler "github.com/Alwanly/go-codebase/internal/progress/handler"
 	user_handler "github.com/Alwanly/go-codebase/internal/user/handler"
+	webhook_handler "github.com/Alwanly/go-codebase/internal/webhook/handler"
 )
 
 type (
@@ -64,6 +75,35 @@ func Bootstrap(d *AppDeps) *deps.App {
 		e.Get("/swagger/*", swagger.HandlerDefault)
 	}
 
+	// create notifier
+	notify, err := notifier.New(notifier.Opts{
+		Logger:   d.Logger,
+		Sink:     d.Config.NotifierSink,
+		FilePath: d.Config.NotifierFilePath,
+	})
+	if err != nil {
+		d.Logger.Error("Cannot create notifier", zap.Error(err))
+		panic(err)
+	}
+
+	// discover the OpenID Connect provider, if any
+	var oidc authentication.IOIDCService
+	if d.Config.OIDCIssuerURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		oidc, err = authentication.NewOIDCService(ctx, &authentication.OIDCConfig{
+			IssuerURL:    d.Config.OIDCIssuerURL,
+			ClientID:     d.Config.OIDCClientID,
+			ClientSecret: d.Config.OIDCClientSecret,
+			RedirectURL:  d.Config.OIDCRedirectURL,
+			Scopes:       validator.SplitCSV(d.Config.OIDCScopes),
+		})
+		cancel()
+		if err != nil {
+			d.Logger.Error("Cannot discover OIDC provider", zap.Error(err))
+			panic(err)
+		}
+	}
+
 	// set app instance
 	inst = &deps.App{
 		Config:    d.Config,
@@ -72,11 +112,89 @@ func Bootstrap(d *AppDeps) *deps.App {
 		Redis:     d.Redis,
 		Auth:      d.Auth,
 		Fiber:     e,
+		Grpc:      grpcserver.New(grpcserver.Opts{Logger: d.Logger, Auth: d.Auth}),
 		Validator: v,
+		Outbox:    outbox.NewStore(d.DB),
+		Notifier:  notify,
+		OIDC:      oidc,
+	}
+	if err := database.MigrateIfNeed(inst.DB.Gorm); err != nil {
+		d.Logger.Error("Cannot migrate database", zap.Error(err))
+		panic(err)
 	}
-	database.MigrateIfNeed(inst.DB.Gorm)
 	user_handler.NewHandler(inst)
+	apikey_handler.NewHandler(inst)
+	webhook_handler.NewHandler(inst)
 	book_handler.NewHandler(inst)
+	progress_handler.NewHandler(inst)
+	if _, err := graphql_handler.NewHandler(inst); err != nil {
+		d.Logger.Error("Cannot create GraphQL schema", zap.Error(err))
+		panic(err)
+	}
+
+	// relay the outbox to the configured sinks, after the webhook handler set up its dispatcher
+	inst.Workers = append(inst.Workers, newOutboxRelay(inst).Run)
+
+	// read the key set file again, to rotate the signing keys without a restart
+	if d.Config.JwtKeysFile != "" && d.Config.JwtKeysReloadInterval > 0 && d.Auth.Keys != nil {
+		inst.Workers = append(inst.Workers, reloadKeys(inst, time.Duration(d.Config.JwtKeysReloadInterval)*time.Second))
+	}
 
 	return inst
 }
+
+// reloadKeys returns a worker reloading the signing keys every interval. A key set that cannot be
+// read is logged, the keys in use are kept.
+func reloadKeys(d *deps.App, interval time.Duration) func(ctx context.Context) error {
+	l := logger.WithID(d.Logger, "server", "ReloadKeys")
+
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if err := d.Auth.Keys.Reload(); err != nil {
+					l.Error("Cannot reload signing keys, keeping the current keys", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// newOutboxRelay creates the outbox relay with the sinks listed in the config.
+func newOutboxRelay(d *deps.App) *outbox.Relay {
+	l := logger.WithID(d.Logger, "server", "NewOutboxRelay")
+
+	sinks := []outbox.ISink{}
+	for _, name := range validator.SplitCSV(d.Config.OutboxSinks) {
+		switch name {
+		case outbox.SinkLog:
+			sinks = append(sinks, &outbox.LogSink{Logger: d.Logger})
+		case outbox.SinkRedisStream:
+			sinks = append(sinks, &outbox.RedisStreamSink{
+				Redis:  d.Redis,
+				Stream: d.Config.OutboxRedisStream,
+				MaxLen: d.Config.OutboxRedisStreamMaxLen,
+			})
+		case outbox.SinkRedisPubSub:
+			sinks = append(sinks, &outbox.RedisPubSubSink{Redis: d.Redis, Channel: d.Config.OutboxRedisChannel})
+		case outbox.SinkWebhook:
+			sinks = append(sinks, &outbox.DispatcherSink{SinkName: outbox.SinkWebhook, Dispatcher: d.Webhooks})
+		default:
+			l.Warn("unknown outbox sink, skipped", zap.String("sink", name))
+		}
+	}
+
+	return outbox.NewRelay(outbox.RelayOpts{
+		Logger:       d.Logger,
+		DB:           d.DB,
+		Sinks:        sinks,
+		PollInterval: time.Duration(d.Config.OutboxPollInterval) * time.Millisecond,
+		BatchSize:    d.Config.OutboxBatchSize,
+		Retention:    time.Duration(d.Config.OutboxRetention) * time.Hour,
+	})
+}
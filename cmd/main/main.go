@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
+	"time"
 
 	"os"
 	"os/signal"
@@ -14,6 +16,7 @@ import (
 	"github.com/Alwanly/go-codebase/pkg/logger"
 	"github.com/Alwanly/go-codebase/pkg/middleware"
 	"github.com/Alwanly/go-codebase/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/validator"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -30,6 +33,10 @@ import (
 // @in header
 // @name Authorization
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
 func main() {
 
 	// load config
@@ -71,21 +78,71 @@ func main() {
 		panic(err)
 	}
 
+	// Setup signing keys, a key that does not match its algorithm stops the startup
+	var keys authentication.IKeyManager
+	if cfg.JwtKeysFile != "" {
+		keys, err = authentication.NewFileKeyManager(cfg.JwtKeysFile, cfg.JwtAlgorithm)
+		if err != nil {
+			l.Error("Cannot load signing keys", zap.Error(err))
+			panic(err)
+		}
+	} else if keys, err = authentication.NewStaticKeyManager(cfg.PrivateKey, cfg.PublicKey, cfg.JwtAlgorithm); err != nil {
+		if cfg.PrivateKey != "" {
+			l.Error("Invalid signing keys", zap.Error(err))
+			panic(err)
+		}
+		l.Warn("No signing key is set, tokens cannot be issued")
+	}
+
 	// Setup middleware
 	jwtConfig := middleware.SetJwtAuth(&authentication.JWTConfig{
 		PrivateKey:     cfg.PrivateKey,
 		PublicKey:      cfg.PublicKey,
+		Algorithm:      cfg.JwtAlgorithm,
+		Keys:           keys,
 		Audience:       cfg.JwtAudience,
 		Issuer:         cfg.JwtIssuer,
 		ExpirationTime: cfg.JwtExpirationTime,
 		RefreshTime:    cfg.JwtRefreshTime,
 	})
+	var basicAuthClients []authentication.BasicAuthClient
+	if cfg.BasicAuthClientsFile != "" {
+		basicAuthClients, err = authentication.LoadBasicAuthClients(cfg.BasicAuthClientsFile)
+		if err != nil {
+			l.Error("Cannot load basic auth clients", zap.Error(err))
+			panic(err)
+		}
+	}
 	basicAuthConfig := middleware.SetBasicAuth(&authentication.BasicAuthTConfig{
 		Username: cfg.BasicAuthUsername,
 		Password: cfg.BasicAuthPassword,
+		Clients:  basicAuthClients,
+	})
+
+	revocationConfig := middleware.SetRevocation(&authentication.RevocationConfig{
+		Redis:         redis,
+		TokenLifetime: time.Duration(max(cfg.JwtExpirationTime, cfg.JwtRefreshTime)) * time.Minute,
+	}, cfg.JwtRevocationFailOpen)
+
+	apiKeyConfig := middleware.SetAPIKeyAuth(&authentication.APIKeyConfig{
+		DB:               db,
+		LastUsedInterval: time.Duration(cfg.APIKeyLastUsedInterval) * time.Second,
+	})
+
+	sessionConfig := middleware.SetSessions(&authentication.SessionConfig{
+		DB:               db,
+		Redis:            redis,
+		LastSeenInterval: time.Duration(cfg.SessionLastSeenInterval) * time.Second,
 	})
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtConfig, basicAuthConfig)
+	verificationConfig := middleware.SetVerificationPolicy(validator.SplitCSV(cfg.EmailVerificationRequiredRoutes))
+
+	if cfg.EmailVerificationSecret == "" {
+		l.Error("Email verification secret is not set")
+		panic("EMAIL_VERIFICATION_SECRET is required")
+	}
+
+	authMiddleware := middleware.NewAuthMiddleware(jwtConfig, basicAuthConfig, revocationConfig, verificationConfig, apiKeyConfig, sessionConfig)
 	if authMiddleware == nil {
 		l.Error("Cannot create auth middleware")
 		panic("Cannot create auth middleware")
@@ -113,6 +170,24 @@ func main() {
 		return app.Fiber.Listen(fmt.Sprintf(":%d", cfg.Port))
 	})
 
+	// run grpc server
+	g.Go(func() error {
+		l.Info("Starting grpc server...", zap.Int("port", cfg.PortGrpc))
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.PortGrpc))
+		if err != nil {
+			l.Error("Cannot listen on grpc port", zap.Error(err))
+			return err
+		}
+		return app.Grpc.Serve(listener)
+	})
+
+	// run background workers
+	for _, worker := range app.Workers {
+		g.Go(func() error {
+			return worker(gCtx)
+		})
+	}
+
 	// graceful shutdown
 	g.Go(func() error {
 		<-gCtx.Done()
@@ -124,6 +199,9 @@ func main() {
 			return err
 		}
 
+		l.Info("Grpc server gracefully shutdown")
+		app.Grpc.Shutdown(10 * time.Second)
+
 		l.Info("Closing database connection")
 		if err := app.DB.Close(); err != nil {
 			l.Error("Cannot close database connection", zap.Error(err))
@@ -19,9 +19,85 @@ type GlobalConfig struct {
 	JwtExpirationTime int    `mapstructure:"JWT_EXPIRATION"`
 	JwtRefreshTime    int    `mapstructure:"JWT_REFRESH_EXPIRATION"`
 
-	// RSA keys
-	PublicKey  string `mapstructure:"PUBLIC_KEY"`
-	PrivateKey string `mapstructure:"PRIVATE_KEY"`
+	// BasicAuthClientsFile is a JSON file of basic auth clients with bcrypt hashed secrets, see
+	// authentication.LoadBasicAuthClients
+	BasicAuthClientsFile string `mapstructure:"BASIC_AUTH_CLIENTS_FILE"`
+
+	// JwtRevocationFailOpen accepts tokens when Redis cannot be reached to check revocations
+	JwtRevocationFailOpen bool `mapstructure:"JWT_REVOCATION_FAIL_OPEN"`
+
+	// Password reset, the TTL in minutes, the token is appended to the URL when set. Requests are
+	// counted per username and per IP over PasswordResetWindow seconds, the tokens wait in a queue
+	// of PasswordResetQueueSize until they are sent
+	PasswordResetTTL        int    `mapstructure:"PASSWORD_RESET_TTL"`
+	PasswordResetURL        string `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetWindow     int    `mapstructure:"PASSWORD_RESET_WINDOW"`
+	PasswordResetMaxPerUser int    `mapstructure:"PASSWORD_RESET_MAX_PER_USER"`
+	PasswordResetMaxPerIP   int    `mapstructure:"PASSWORD_RESET_MAX_PER_IP"`
+	PasswordResetQueueSize  int    `mapstructure:"PASSWORD_RESET_QUEUE_SIZE"`
+
+	// Email verification, the TTL in minutes, the resend interval in seconds, the token is appended
+	// to the URL when set. Required routes is a comma separated list of routes, see
+	// middleware.NewVerificationPolicy
+	EmailVerificationSecret         string `mapstructure:"EMAIL_VERIFICATION_SECRET"`
+	EmailVerificationTTL            int    `mapstructure:"EMAIL_VERIFICATION_TTL"`
+	EmailVerificationURL            string `mapstructure:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationResendInterval int    `mapstructure:"EMAIL_VERIFICATION_RESEND_INTERVAL"`
+	EmailVerificationRequiredRoutes string `mapstructure:"EMAIL_VERIFICATION_REQUIRED_ROUTES"`
+
+	// Login throttling, failures are counted per username and per IP over the window in seconds.
+	// After LoginDelayAfter failures of a username each failure delays its next attempt, starting
+	// at LoginDelayBase seconds and doubling up to LoginDelayMax, after LoginLockoutThreshold
+	// failures the username is locked for LoginLockoutDuration seconds. An IP is refused after
+	// LoginIPMaxFailures failures
+	LoginWindow           int `mapstructure:"LOGIN_WINDOW"`
+	LoginDelayAfter       int `mapstructure:"LOGIN_DELAY_AFTER"`
+	LoginDelayBase        int `mapstructure:"LOGIN_DELAY_BASE"`
+	LoginDelayMax         int `mapstructure:"LOGIN_DELAY_MAX"`
+	LoginLockoutThreshold int `mapstructure:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutDuration  int `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginIPMaxFailures    int `mapstructure:"LOGIN_IP_MAX_FAILURES"`
+
+	// Two-factor authentication, the issuer is shown by authenticator apps and the challenge of a
+	// login waiting for a second factor lives for TOTPChallengeTTL seconds
+	TOTPIssuer        string `mapstructure:"TOTP_ISSUER"`
+	TOTPChallengeTTL  int    `mapstructure:"TOTP_CHALLENGE_TTL"`
+	TOTPRecoveryCodes int    `mapstructure:"TOTP_RECOVERY_CODES"`
+
+	// OpenID Connect login, disabled when OIDCIssuerURL is empty. Users are linked by their verified
+	// email when OIDCLinkByEmail is set and created on their first login when OIDCAutoProvision is
+	// set. OIDCStateTTL in seconds is the time the user has to sign in at the provider
+	OIDCIssuerURL     string `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID      string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret  string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL   string `mapstructure:"OIDC_REDIRECT_URL"`
+	OIDCScopes        string `mapstructure:"OIDC_SCOPES"`
+	OIDCStateTTL      int    `mapstructure:"OIDC_STATE_TTL"`
+	OIDCLinkByEmail   bool   `mapstructure:"OIDC_LINK_BY_EMAIL"`
+	OIDCAutoProvision bool   `mapstructure:"OIDC_AUTO_PROVISION"`
+
+	// APIKeyLastUsedInterval in seconds, the last use of an API key is written at most once per
+	// interval
+	APIKeyLastUsedInterval int `mapstructure:"API_KEY_LAST_USED_INTERVAL"`
+
+	// SessionLastSeenInterval is the resolution in seconds of the last use of a session
+	SessionLastSeenInterval int `mapstructure:"SESSION_LAST_SEEN_INTERVAL"`
+
+	// Notifier
+	NotifierSink     string `mapstructure:"NOTIFIER_SINK"`
+	NotifierFilePath string `mapstructure:"NOTIFIER_FILE_PATH"`
+
+	// Signing keys, PEM encoded, and their algorithm, see authentication.ParsePrivateKeyPEM and
+	// authentication.CheckKeyAlgorithm for the formats and algorithms supported
+	PublicKey    string `mapstructure:"PUBLIC_KEY"`
+	PrivateKey   string `mapstructure:"PRIVATE_KEY"`
+	JwtAlgorithm string `mapstructure:"JWT_ALGORITHM"`
+
+	// JwtKeysFile is a JSON key set file replacing the keys, see authentication.KeySetFile, keys
+	// without an algorithm use JwtAlgorithm. It is read again every JwtKeysReloadInterval seconds
+	// to rotate the keys without a restart
+	JwtKeysFile           string `mapstructure:"JWT_KEYS_FILE"`
+	JwtKeysReloadInterval int    `mapstructure:"JWT_KEYS_RELOAD_INTERVAL"`
 
 	// Database
 	PostgresURI                string `mapstructure:"POSTGRES_URI"`
@@ -30,4 +106,34 @@ type GlobalConfig struct {
 
 	// Redis
 	RedisURI string `mapstructure:"REDIS_URI"`
+
+	// Book
+	BookStatsCacheTTL      int     `mapstructure:"BOOK_STATS_CACHE_TTL"`
+	BookCacheTTL           int     `mapstructure:"BOOK_CACHE_TTL"`
+	BookDuplicateThreshold float64 `mapstructure:"BOOK_DUPLICATE_THRESHOLD"`
+
+	// Webhook
+	WebhookWorkers        int `mapstructure:"WEBHOOK_WORKERS"`
+	WebhookPollInterval   int `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	WebhookDeliveryLease  int `mapstructure:"WEBHOOK_DELIVERY_LEASE"`
+	WebhookMaxAttempts    int `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryBaseDelay int `mapstructure:"WEBHOOK_RETRY_BASE_DELAY"`
+	WebhookRetryMaxDelay  int `mapstructure:"WEBHOOK_RETRY_MAX_DELAY"`
+
+	// Outbox
+	OutboxSinks             string `mapstructure:"OUTBOX_SINKS"`
+	OutboxPollInterval      int    `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize         int    `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxRetention         int    `mapstructure:"OUTBOX_RETENTION"`
+	OutboxRedisStream       string `mapstructure:"OUTBOX_REDIS_STREAM"`
+	OutboxRedisStreamMaxLen int64  `mapstructure:"OUTBOX_REDIS_STREAM_MAX_LEN"`
+	OutboxRedisChannel      string `mapstructure:"OUTBOX_REDIS_CHANNEL"`
+
+	// Server-Sent Events
+	SSEReplayBufferSize int `mapstructure:"SSE_REPLAY_BUFFER_SIZE"`
+	SSEHeartbeat        int `mapstructure:"SSE_HEARTBEAT"`
+
+	// GraphQL
+	GraphQLMaxDepth      int `mapstructure:"GRAPHQL_MAX_DEPTH"`
+	GraphQLMaxComplexity int `mapstructure:"GRAPHQL_MAX_COMPLEXITY"`
 }
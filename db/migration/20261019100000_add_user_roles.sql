@@ -0,0 +1,8 @@
+-- Create "roles" table
+CREATE TABLE "roles" ("name" character varying(32) NOT NULL, "description" character varying(255) NOT NULL DEFAULT '', PRIMARY KEY ("name"));
+-- Create "user_roles" table
+CREATE TABLE "user_roles" ("user_id" character varying(36) NOT NULL, "role" character varying(32) NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), PRIMARY KEY ("user_id", "role"), CONSTRAINT "user_roles_role_fkey" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "user_roles_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Seed roles
+INSERT INTO "roles" ("name", "description") VALUES ('admin', 'Manages users and the catalogue'), ('librarian', 'Curates the catalogue'), ('member', 'Reads and suggests books');
+-- Existing users are members
+INSERT INTO "user_roles" ("user_id", "role") SELECT "id", 'member' FROM "users";
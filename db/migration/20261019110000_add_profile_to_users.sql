@@ -0,0 +1,4 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "display_name" character varying(100) NOT NULL DEFAULT '', ADD COLUMN "email" character varying(255) NULL, ADD COLUMN "bio" character varying(500) NOT NULL DEFAULT '', ADD COLUMN "avatar_url" character varying(2048) NOT NULL DEFAULT '';
+-- Create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
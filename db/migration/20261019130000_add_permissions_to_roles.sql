@@ -0,0 +1,7 @@
+-- Create "permissions" table
+CREATE TABLE "permissions" ("name" character varying(64) NOT NULL, "description" character varying(255) NOT NULL DEFAULT '', PRIMARY KEY ("name"));
+-- Create "role_permissions" table
+CREATE TABLE "role_permissions" ("role" character varying(32) NOT NULL, "permission" character varying(64) NOT NULL, PRIMARY KEY ("role", "permission"), CONSTRAINT "role_permissions_permission_fkey" FOREIGN KEY ("permission") REFERENCES "permissions" ("name") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "role_permissions_role_fkey" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Seed permissions and grant them to the roles
+INSERT INTO "permissions" ("name", "description") VALUES ('book:create', 'Create books'), ('book:update', 'Update books'), ('book:delete', 'Delete books'), ('book:merge', 'Merge duplicate books'), ('webhook:manage', 'Manage webhook subscriptions'), ('user:manage', 'Assign roles and revoke tokens of users');
+INSERT INTO "role_permissions" ("role", "permission") VALUES ('admin', 'book:create'), ('admin', 'book:update'), ('admin', 'book:delete'), ('admin', 'book:merge'), ('admin', 'webhook:manage'), ('admin', 'user:manage'), ('librarian', 'book:create'), ('librarian', 'book:update'), ('librarian', 'book:delete'), ('member', 'book:create'), ('member', 'book:update');
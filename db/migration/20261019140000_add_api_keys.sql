@@ -0,0 +1,7 @@
+-- Create "api_keys" table
+CREATE TABLE "api_keys" ("id" character varying(36) NOT NULL, "name" character varying(255) NOT NULL, "prefix" character varying(16) NOT NULL, "key_hash" character varying(64) NOT NULL, "scopes" text NOT NULL DEFAULT '', "created_by" character varying(36) NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "expires_at" timestamptz NULL, "last_used_at" timestamptz NULL, "revoked_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- Create index "api_keys_key_hash_key" to table: "api_keys"
+CREATE UNIQUE INDEX "api_keys_key_hash_key" ON "api_keys" ("key_hash");
+-- Seed "apikey:manage" permission
+INSERT INTO "permissions" ("name", "description") VALUES ('apikey:manage', 'Create and revoke API keys');
+INSERT INTO "role_permissions" ("role", "permission") VALUES ('admin', 'apikey:manage');
@@ -0,0 +1,4 @@
+-- Create "auth_audit_events" table
+CREATE TABLE "auth_audit_events" ("id" character varying(36) NOT NULL, "event" character varying(64) NOT NULL, "user_id" character varying(36) NOT NULL DEFAULT '', "username" character varying(255) NOT NULL DEFAULT '', "ip" character varying(64) NOT NULL DEFAULT '', "client_id" character varying(255) NOT NULL DEFAULT '', "actor_id" character varying(36) NOT NULL DEFAULT '', "detail" text NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL DEFAULT now(), PRIMARY KEY ("id"));
+-- Create index "auth_audit_events_username_created_at_idx" to table: "auth_audit_events"
+CREATE INDEX "auth_audit_events_username_created_at_idx" ON "auth_audit_events" ("username", "created_at");
@@ -0,0 +1,4 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "totp_secret" character varying(64) NULL, ADD COLUMN "totp_enabled_at" timestamptz NULL;
+-- Create "user_recovery_codes" table
+CREATE TABLE "user_recovery_codes" ("user_id" character varying(36) NOT NULL, "code_hash" character varying(64) NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "used_at" timestamptz NULL, PRIMARY KEY ("user_id", "code_hash"), CONSTRAINT "user_recovery_codes_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
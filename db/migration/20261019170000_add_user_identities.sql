@@ -0,0 +1,4 @@
+-- Create "user_identities" table
+CREATE TABLE "user_identities" ("issuer" character varying(255) NOT NULL, "subject" character varying(255) NOT NULL, "user_id" character varying(36) NOT NULL, "email" character varying(255) NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL DEFAULT now(), "last_login_at" timestamptz NULL, PRIMARY KEY ("issuer", "subject"), CONSTRAINT "user_identities_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "user_identities_user_id_idx" to table: "user_identities"
+CREATE INDEX "user_identities_user_id_idx" ON "user_identities" ("user_id");
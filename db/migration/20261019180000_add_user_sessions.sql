@@ -0,0 +1,4 @@
+-- Create "user_sessions" table
+CREATE TABLE "user_sessions" ("id" character varying(36) NOT NULL, "user_id" character varying(36) NOT NULL, "user_agent" character varying(512) NOT NULL DEFAULT '', "ip" character varying(45) NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL DEFAULT now(), "last_seen_at" timestamptz NOT NULL DEFAULT now(), "expires_at" timestamptz NOT NULL, "revoked_at" timestamptz NULL, PRIMARY KEY ("id"), CONSTRAINT "user_sessions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "user_sessions_user_id_idx" to table: "user_sessions"
+CREATE INDEX "user_sessions_user_id_idx" ON "user_sessions" ("user_id");
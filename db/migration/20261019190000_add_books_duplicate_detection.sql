@@ -0,0 +1,16 @@
+-- Enable "pg_trgm" extension
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+-- Create "books" table, databases set up by earlier releases already have it
+CREATE TABLE IF NOT EXISTS "books" ("id" character varying(255) NOT NULL, "title" character varying(255) NOT NULL, "author" character varying(255) NOT NULL, "created_at" timestamptz NOT NULL, "created_by" character varying(255) NOT NULL, "updated_at" timestamptz NOT NULL, "updated_by" character varying(255) NOT NULL, PRIMARY KEY ("id"));
+-- Modify "books" table
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "normalized_title" character varying(255) NOT NULL DEFAULT '', ADD COLUMN IF NOT EXISTS "normalized_author" character varying(255) NOT NULL DEFAULT '', ADD COLUMN IF NOT EXISTS "status" character varying(16) NOT NULL DEFAULT 'published', ADD COLUMN IF NOT EXISTS "published_at" timestamptz NULL;
+-- Create index "idx_books_status" to table: "books"
+CREATE INDEX IF NOT EXISTS "idx_books_status" ON "books" ("status");
+-- Create index "idx_books_normalized_title_trgm" to table: "books"
+CREATE INDEX IF NOT EXISTS "idx_books_normalized_title_trgm" ON "books" USING gin ("normalized_title" gin_trgm_ops);
+-- Create index "idx_books_normalized_author_trgm" to table: "books"
+CREATE INDEX IF NOT EXISTS "idx_books_normalized_author_trgm" ON "books" USING gin ("normalized_author" gin_trgm_ops);
+-- Create "book_merges" table
+CREATE TABLE IF NOT EXISTS "book_merges" ("id" character varying(36) NOT NULL, "survivor_id" character varying(255) NOT NULL, "merged_id" character varying(255) NOT NULL, "merged_title" character varying(255) NOT NULL, "merged_author" character varying(255) NOT NULL, "merged_by" character varying(255) NOT NULL, "merged_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- Create index "idx_book_merges_survivor_id" to table: "book_merges"
+CREATE INDEX IF NOT EXISTS "idx_book_merges_survivor_id" ON "book_merges" ("survivor_id");
@@ -14,6 +14,37 @@ table "users" {
     null = false
     type = integer
   }
+  column "display_name" {
+    null    = false
+    type    = varchar(100)
+    default = ""
+  }
+  column "email" {
+    null = true
+    type = varchar(255)
+  }
+  column "email_verified_at" {
+    null = true
+    type = timestamptz
+  }
+  column "bio" {
+    null    = false
+    type    = varchar(500)
+    default = ""
+  }
+  column "avatar_url" {
+    null    = false
+    type    = varchar(2048)
+    default = ""
+  }
+  column "totp_secret" {
+    null = true
+    type = varchar(64)
+  }
+  column "totp_enabled_at" {
+    null = true
+    type = timestamptz
+  }
   column "created_at" {
     null = true
     type = bigint
@@ -33,4 +64,431 @@ table "users" {
   primary_key {
     columns = [column.id]
   }
-}
\ No newline at end of file
+  index "users_email_key" {
+    unique  = true
+    columns = [column.email]
+  }
+}
+
+table "roles" {
+  schema = schema.public
+  column "name" {
+    null = false
+    type = varchar(32)
+  }
+  column "description" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  primary_key {
+    columns = [column.name]
+  }
+}
+
+table "permissions" {
+  schema = schema.public
+  column "name" {
+    null = false
+    type = varchar(64)
+  }
+  column "description" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  primary_key {
+    columns = [column.name]
+  }
+}
+
+table "role_permissions" {
+  schema = schema.public
+  column "role" {
+    null = false
+    type = varchar(32)
+  }
+  column "permission" {
+    null = false
+    type = varchar(64)
+  }
+  primary_key {
+    columns = [column.role, column.permission]
+  }
+  foreign_key "role_permissions_role_fkey" {
+    columns     = [column.role]
+    ref_columns = [table.roles.column.name]
+    on_delete   = CASCADE
+  }
+  foreign_key "role_permissions_permission_fkey" {
+    columns     = [column.permission]
+    ref_columns = [table.permissions.column.name]
+    on_delete   = CASCADE
+  }
+}
+
+table "user_roles" {
+  schema = schema.public
+  column "user_id" {
+    null = false
+    type = varchar(36)
+  }
+  column "role" {
+    null = false
+    type = varchar(32)
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  primary_key {
+    columns = [column.user_id, column.role]
+  }
+  foreign_key "user_roles_user_id_fkey" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+  foreign_key "user_roles_role_fkey" {
+    columns     = [column.role]
+    ref_columns = [table.roles.column.name]
+    on_delete   = CASCADE
+  }
+}
+
+table "api_keys" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = varchar(36)
+  }
+  column "name" {
+    null = false
+    type = varchar(255)
+  }
+  column "prefix" {
+    null = false
+    type = varchar(16)
+  }
+  column "key_hash" {
+    null = false
+    type = varchar(64)
+  }
+  column "scopes" {
+    null    = false
+    type    = text
+    default = ""
+  }
+  column "created_by" {
+    null = false
+    type = varchar(36)
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
+  column "last_used_at" {
+    null = true
+    type = timestamptz
+  }
+  column "revoked_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "api_keys_key_hash_key" {
+    unique  = true
+    columns = [column.key_hash]
+  }
+}
+
+table "auth_audit_events" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = varchar(36)
+  }
+  column "event" {
+    null = false
+    type = varchar(64)
+  }
+  column "user_id" {
+    null    = false
+    type    = varchar(36)
+    default = ""
+  }
+  column "username" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  column "ip" {
+    null    = false
+    type    = varchar(64)
+    default = ""
+  }
+  column "client_id" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  column "actor_id" {
+    null    = false
+    type    = varchar(36)
+    default = ""
+  }
+  column "detail" {
+    null    = false
+    type    = text
+    default = ""
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "auth_audit_events_username_created_at_idx" {
+    columns = [column.username, column.created_at]
+  }
+}
+
+table "user_recovery_codes" {
+  schema = schema.public
+  column "user_id" {
+    null = false
+    type = varchar(36)
+  }
+  column "code_hash" {
+    null = false
+    type = varchar(64)
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  column "used_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.user_id, column.code_hash]
+  }
+  foreign_key "user_recovery_codes_user_id_fkey" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+}
+
+table "user_identities" {
+  schema = schema.public
+  column "issuer" {
+    null = false
+    type = varchar(255)
+  }
+  column "subject" {
+    null = false
+    type = varchar(255)
+  }
+  column "user_id" {
+    null = false
+    type = varchar(36)
+  }
+  column "email" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  column "last_login_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.issuer, column.subject]
+  }
+  foreign_key "user_identities_user_id_fkey" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+  index "user_identities_user_id_idx" {
+    columns = [column.user_id]
+  }
+}
+
+table "user_sessions" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = varchar(36)
+  }
+  column "user_id" {
+    null = false
+    type = varchar(36)
+  }
+  column "user_agent" {
+    null    = false
+    type    = varchar(512)
+    default = ""
+  }
+  column "ip" {
+    null    = false
+    type    = varchar(45)
+    default = ""
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  column "last_seen_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+  }
+  column "expires_at" {
+    null = false
+    type = timestamptz
+  }
+  column "revoked_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "user_sessions_user_id_fkey" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+  index "user_sessions_user_id_idx" {
+    columns = [column.user_id]
+  }
+}
+
+table "books" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = varchar(255)
+  }
+  column "title" {
+    null = false
+    type = varchar(255)
+  }
+  column "author" {
+    null = false
+    type = varchar(255)
+  }
+  column "normalized_title" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  column "normalized_author" {
+    null    = false
+    type    = varchar(255)
+    default = ""
+  }
+  column "status" {
+    null    = false
+    type    = varchar(16)
+    default = "published"
+  }
+  column "published_at" {
+    null = true
+    type = timestamptz
+  }
+  column "created_at" {
+    null = false
+    type = timestamptz
+  }
+  column "created_by" {
+    null = false
+    type = varchar(255)
+  }
+  column "updated_at" {
+    null = false
+    type = timestamptz
+  }
+  column "updated_by" {
+    null = false
+    type = varchar(255)
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_books_status" {
+    columns = [column.status]
+  }
+  index "idx_books_normalized_title_trgm" {
+    type = GIN
+    on {
+      column = column.normalized_title
+      ops    = gin_trgm_ops
+    }
+  }
+  index "idx_books_normalized_author_trgm" {
+    type = GIN
+    on {
+      column = column.normalized_author
+      ops    = gin_trgm_ops
+    }
+  }
+}
+
+table "book_merges" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = varchar(36)
+  }
+  column "survivor_id" {
+    null = false
+    type = varchar(255)
+  }
+  column "merged_id" {
+    null = false
+    type = varchar(255)
+  }
+  column "merged_title" {
+    null = false
+    type = varchar(255)
+  }
+  column "merged_author" {
+    null = false
+    type = varchar(255)
+  }
+  column "merged_by" {
+    null = false
+    type = varchar(255)
+  }
+  column "merged_at" {
+    null = false
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_book_merges_survivor_id" {
+    columns = [column.survivor_id]
+  }
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"github.com/Alwanly/go-codebase/internal/apikey/repository"
+	"github.com/Alwanly/go-codebase/internal/apikey/schema"
+	"github.com/Alwanly/go-codebase/internal/apikey/usecase"
+	"github.com/Alwanly/go-codebase/pkg/binding"
+	"github.com/Alwanly/go-codebase/pkg/deps"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.APIKey.Handler"
+
+type (
+	Handler struct {
+		Logger    *zap.Logger
+		Validator validator.IValidatorService
+		UseCase   usecase.IUseCase
+	}
+)
+
+// NewHandler registers the routes managing API keys, the keys themselves authenticate with
+// middleware.APIKeyAuth.
+func NewHandler(d *deps.App) *Handler {
+	repository := repository.NewRepository(repository.Repository{
+		DB: d.DB,
+	})
+	usecase := usecase.NewUseCase(usecase.UseCase{
+		Config:     d.Config,
+		Logger:     d.Logger,
+		Repository: repository,
+	})
+	handler := &Handler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	}
+
+	e := d.Fiber.Group("/apikeys/v1", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionAPIKeyManage))
+	e.Post("/", handler.Create)
+	e.Get("/", handler.List)
+	e.Get("/:id", handler.Get)
+	e.Delete("/:id", handler.Revoke)
+	return handler
+}
+
+// Create creates an API key, the key is only returned once.
+func (h *Handler) Create(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Create")
+
+	// bind model
+	model := &schema.RequestAPIKeyCreate{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// create API key
+	response := h.UseCase.Create(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// List returns a list of API keys.
+func (h *Handler) List(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "List")
+
+	// bind model
+	model := &schema.RequestAPIKeyList{
+		Page:     1,
+		PageSize: 10,
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get list of API keys
+	response := h.UseCase.List(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Get returns an API key by ID.
+func (h *Handler) Get(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Get")
+
+	// bind model
+	model := &schema.RequestAPIKeyGet{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get API key by ID
+	response := h.UseCase.Get(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Revoke revokes an API key by ID.
+func (h *Handler) Revoke(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Revoke")
+
+	// bind model
+	model := &schema.RequestAPIKeyRevoke{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// revoke API key
+	response := h.UseCase.Revoke(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
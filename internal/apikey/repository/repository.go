@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const ContextName = "Internal.APIKey.Repository"
+
+type (
+	Repository struct {
+		DB database.IDBService
+	}
+
+	IRepository interface {
+		CreateAPIKey(ctx context.Context, apiKey *model.APIKey) error
+		GetAPIKey(ctx context.Context, id string) *model.APIKey
+		ListAPIKeys(ctx context.Context, page, pageSize int) ([]model.APIKey, int64)
+		RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) error
+	}
+)
+
+func NewRepository(r Repository) IRepository {
+	return &Repository{
+		DB: r.DB,
+	}
+}
+
+func (r *Repository) CreateAPIKey(ctx context.Context, apiKey *model.APIKey) error {
+	return r.DB.GetTransaction(ctx).Create(apiKey).Error
+}
+
+func (r *Repository) GetAPIKey(ctx context.Context, id string) *model.APIKey {
+	var apiKey model.APIKey
+	if err := r.DB.GetTransaction(ctx).Where("id = ?", id).First(&apiKey).Error; err != nil {
+		return nil
+	}
+	return &apiKey
+}
+
+func (r *Repository) ListAPIKeys(ctx context.Context, page, pageSize int) ([]model.APIKey, int64) {
+	var apiKeys []model.APIKey
+	var total int64
+
+	tx := r.DB.GetTransaction(ctx).Model(&model.APIKey{}).Session(&gorm.Session{})
+	tx.Count(&total)
+	tx.Offset(utils.CalculatePageSkip(page, pageSize)).
+		Limit(pageSize).
+		Order("created_at DESC").
+		Find(&apiKeys)
+
+	return apiKeys, total
+}
+
+// RevokeAPIKey revokes the key, a key that is already revoked keeps its revocation time.
+func (r *Repository) RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) error {
+	return r.DB.GetTransaction(ctx).Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		UpdateColumn("revoked_at", revokedAt).Error
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+)
+
+type RequestAPIKeyCreate struct {
+	Name      string     `json:"name" validate:"required,max=255"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,dive,required,max=64,excludesall=0x2C"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestAPIKeyGet struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestAPIKeyRevoke struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestAPIKeyList struct {
+	Page     int `query:"page" validate:"required,min=1"`
+	PageSize int `query:"page_size" validate:"required,min=1,max=100"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseAPIKey never carries the key except right after it was created, only its hash is stored.
+type ResponseAPIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Key        string     `json:"key,omitempty"`
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// JoinScopes stores the scopes of a key as a comma separated list.
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, ",")
+}
+
+func ToAPIKeyResponse(apiKey *model.APIKey) ResponseAPIKey {
+	return ResponseAPIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     validator.SplitCSV(apiKey.Scopes),
+		CreatedBy:  apiKey.CreatedBy,
+		CreatedAt:  apiKey.CreatedAt,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+	}
+}
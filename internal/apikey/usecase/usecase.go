@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/apikey/repository"
+	"github.com/Alwanly/go-codebase/internal/apikey/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.APIKey.Usecase"
+
+type (
+	UseCase struct {
+		Config     *config.GlobalConfig
+		Logger     *zap.Logger
+		Repository repository.IRepository
+	}
+
+	IUseCase interface {
+		Create(context.Context, *schema.RequestAPIKeyCreate) wrapper.JSONResult
+		Get(context.Context, *schema.RequestAPIKeyGet) wrapper.JSONResult
+		List(context.Context, *schema.RequestAPIKeyList) wrapper.JSONResult
+		Revoke(context.Context, *schema.RequestAPIKeyRevoke) wrapper.JSONResult
+	}
+)
+
+func NewUseCase(uc UseCase) IUseCase {
+	return &UseCase{
+		Config:     uc.Config,
+		Logger:     uc.Logger,
+		Repository: uc.Repository,
+	}
+}
+
+// Create generates an API key, the key is only returned in this response.
+func (u *UseCase) Create(ctx context.Context, req *schema.RequestAPIKeyCreate) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Create")
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "expiresAt must be in the future", nil)
+	}
+
+	key, prefix, hash, err := authentication.GenerateAPIKey()
+	if err != nil {
+		l.Error("failed to generate an API key", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to create an API key", nil)
+	}
+
+	apiKey := &model.APIKey{
+		ID:        utils.GenerateUUID(),
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    schema.JoinScopes(req.Scopes),
+		CreatedBy: req.AuthUserData.UserID,
+		CreatedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := u.Repository.CreateAPIKey(ctx, apiKey); err != nil {
+		l.Error("failed to create an API key", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to create an API key", nil)
+	}
+
+	l.Info("API key created", zap.String("id", apiKey.ID), zap.String("prefix", prefix), zap.String("createdBy", apiKey.CreatedBy))
+
+	response := schema.ToAPIKeyResponse(apiKey)
+	response.Key = key
+	return wrapper.ResponseSuccess(http.StatusCreated, response)
+}
+
+func (u *UseCase) Get(ctx context.Context, req *schema.RequestAPIKeyGet) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Get")
+
+	apiKey := u.Repository.GetAPIKey(ctx, req.ID)
+	if apiKey == nil {
+		l.Error("API key not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "API key not found", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ToAPIKeyResponse(apiKey))
+}
+
+func (u *UseCase) List(ctx context.Context, req *schema.RequestAPIKeyList) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "List")
+
+	apiKeys, total := u.Repository.ListAPIKeys(ctx, req.Page, req.PageSize)
+	response := make([]schema.ResponseAPIKey, len(apiKeys))
+	for i := range apiKeys {
+		response[i] = schema.ToAPIKeyResponse(&apiKeys[i])
+	}
+
+	l.Debug("API keys listed", zap.Int64("total", total))
+	return wrapper.ResponsePagination(req.Page, req.PageSize, len(apiKeys), int(total), response, nil)
+}
+
+// Revoke revokes the key at once, it is kept so its name and last use remain visible.
+func (u *UseCase) Revoke(ctx context.Context, req *schema.RequestAPIKeyRevoke) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Revoke")
+
+	if u.Repository.GetAPIKey(ctx, req.ID) == nil {
+		l.Error("API key not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "API key not found", nil)
+	}
+
+	if err := u.Repository.RevokeAPIKey(ctx, req.ID, time.Now()); err != nil {
+		l.Error("failed to revoke an API key", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to revoke an API key", nil)
+	}
+
+	l.Info("API key revoked", zap.String("id", req.ID), zap.String("revokedBy", req.AuthUserData.UserID))
+	return wrapper.ResponseSuccess(http.StatusNoContent, nil)
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	bookv1 "github.com/Alwanly/go-codebase/api/proto/book/v1"
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/internal/book/usecase"
+	"github.com/Alwanly/go-codebase/pkg/grpcserver"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GrpcHandler serves BookService, every method requires a bearer token.
+type GrpcHandler struct {
+	bookv1.UnimplementedBookServiceServer
+
+	Logger    *zap.Logger
+	Validator validator.IValidatorService
+	UseCase   usecase.IUseCase
+}
+
+// CreateBook creates a new book.
+func (h *GrpcHandler) CreateBook(ctx context.Context, req *bookv1.CreateBookRequest) (*bookv1.CreateBookResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcCreateBook")
+
+	// bind model
+	model := &schema.RequestBookCreate{
+		Title:  req.GetTitle(),
+		Author: req.GetAuthor(),
+	}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// create a new book
+	response := h.UseCase.Create(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.(schema.ResponseBookCreate)
+	duplicates := make([]*bookv1.BookDuplicate, len(data.PossibleDuplicates))
+	for i, duplicate := range data.PossibleDuplicates {
+		duplicates[i] = &bookv1.BookDuplicate{
+			Id:     duplicate.ID,
+			Title:  duplicate.Title,
+			Author: duplicate.Author,
+			Score:  duplicate.Score,
+		}
+	}
+	return &bookv1.CreateBookResponse{Id: data.ID, PossibleDuplicates: duplicates}, nil
+}
+
+// GetBook returns a book.
+func (h *GrpcHandler) GetBook(ctx context.Context, req *bookv1.GetBookRequest) (*bookv1.Book, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcGetBook")
+
+	// bind model
+	model := &schema.RequestBookGet{ID: req.GetId()}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// get a book
+	response := h.UseCase.Get(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+	return toBook(response.Data.(schema.ResponseBookGet)), nil
+}
+
+// ListBooks returns a page of books, with the defaults of the REST endpoint for unset fields.
+func (h *GrpcHandler) ListBooks(ctx context.Context, req *bookv1.ListBooksRequest) (*bookv1.ListBooksResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcListBooks")
+
+	// bind model
+	model := &schema.RequestBookList{
+		Page:      1,
+		PageSize:  10,
+		SortBy:    "title",
+		SortOrder: "desc",
+		Status:    req.GetStatus(),
+	}
+	if req.GetPage() != 0 {
+		model.Page = int(req.GetPage())
+	}
+	if req.GetPageSize() != 0 {
+		model.PageSize = int(req.GetPageSize())
+	}
+	if req.GetSortBy() != "" {
+		model.SortBy = req.GetSortBy()
+	}
+	if req.GetSortOrder() != "" {
+		model.SortOrder = req.GetSortOrder()
+	}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// get list of books
+	response := h.UseCase.List(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.([]schema.ResponseBookGet)
+	books := make([]*bookv1.Book, len(data))
+	for i, book := range data {
+		books[i] = toBook(book)
+	}
+	return &bookv1.ListBooksResponse{
+		Books:     books,
+		Page:      int32(response.Meta.Page),
+		TotalData: int32(response.Meta.TotalData),
+		TotalPage: int32(response.Meta.TotalPage),
+	}, nil
+}
+
+// UpdateBook updates a book.
+func (h *GrpcHandler) UpdateBook(ctx context.Context, req *bookv1.UpdateBookRequest) (*bookv1.UpdateBookResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcUpdateBook")
+
+	// bind model
+	model := &schema.RequestBookUpdate{
+		ID:     req.GetId(),
+		Title:  req.GetTitle(),
+		Author: req.GetAuthor(),
+	}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// update a book
+	response := h.UseCase.Update(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+	return &bookv1.UpdateBookResponse{Id: response.Data.(schema.ResponseBookUpdate).ID}, nil
+}
+
+// DeleteBook deletes a book.
+func (h *GrpcHandler) DeleteBook(ctx context.Context, req *bookv1.DeleteBookRequest) (*bookv1.DeleteBookResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcDeleteBook")
+
+	// bind model
+	model := &schema.RequestBookDelete{ID: req.GetId()}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// delete a book
+	response := h.UseCase.Delete(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+	return &bookv1.DeleteBookResponse{}, nil
+}
+
+func toBook(book schema.ResponseBookGet) *bookv1.Book {
+	return &bookv1.Book{
+		Id:          book.ID,
+		Title:       book.Title,
+		Author:      book.Author,
+		Status:      book.Status,
+		PublishedAt: toTimestamp(book.PublishedAt),
+		CreatedAt:   toTimestamp(book.CreatedAt),
+		CreatedBy:   book.CreatedBy,
+		UpdatedAt:   toTimestamp(book.UpdatedAt),
+		UpdatedBy:   book.UpdatedBy,
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
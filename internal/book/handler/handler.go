@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"bufio"
+	"strings"
+	"time"
+
+	bookv1 "github.com/Alwanly/go-codebase/api/proto/book/v1"
 	"github.com/Alwanly/go-codebase/internal/book/repository"
 	"github.com/Alwanly/go-codebase/internal/book/schema"
 	"github.com/Alwanly/go-codebase/internal/book/usecase"
 	"github.com/Alwanly/go-codebase/pkg/binding"
 	"github.com/Alwanly/go-codebase/pkg/deps"
 	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/sse"
 	"github.com/Alwanly/go-codebase/pkg/validator"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -19,6 +26,8 @@ type (
 		Logger    *zap.Logger
 		Validator validator.IValidatorService
 		UseCase   usecase.IUseCase
+		Stream    usecase.IStream
+		Heartbeat time.Duration
 	}
 )
 
@@ -27,23 +36,52 @@ func NewHandler(d *deps.App) *Handler {
 		DB:    d.DB,
 		Redis: d.Redis,
 	})
+	stream := usecase.NewStream(usecase.Stream{
+		Logger:     d.Logger,
+		Repository: repository,
+		Channel:    d.Config.OutboxRedisChannel,
+		Broker:     sse.NewBroker(d.Config.SSEReplayBufferSize, 64),
+	})
+	d.Workers = append(d.Workers, stream.Run)
+
 	usecase := usecase.NewUseCase(usecase.UseCase{
 		Config:     d.Config,
 		Logger:     d.Logger,
 		Repository: repository,
+		Outbox:     d.Outbox,
 	})
 	handler := &Handler{
 		Logger:    d.Logger,
 		Validator: d.Validator,
 		UseCase:   usecase,
+		Stream:    stream,
+		Heartbeat: time.Duration(d.Config.SSEHeartbeat) * time.Second,
 	}
 
 	e := d.Fiber.Group("/books/v1", d.Auth.JwtAuth())
-	e.Post("/", handler.Create)
+	e.Post("/", middleware.RequirePermission(middleware.PermissionBookCreate), handler.Create)
 	e.Get("/", handler.List)
+	e.Get("/stats", handler.Stats)
+	e.Get("/events", handler.Events)
+	e.Get("/batch", handler.Batch)
+	e.Post("/batch", handler.Batch)
+	e.Get("/duplicates", handler.Duplicates)
 	e.Get("/:id", handler.Get)
-	e.Put("/:id", handler.Update)
-	e.Delete("/:id", handler.Delete)
+	e.Get("/:id/duplicates", handler.Duplicates)
+	e.Post("/:id/merge", middleware.RequirePermission(middleware.PermissionBookMerge), handler.Merge)
+	e.Get("/:id/transitions", handler.ListTransitions)
+	e.Post("/:id/transitions", middleware.RequirePermission(middleware.PermissionBookUpdate), handler.Transition)
+	e.Put("/:id", middleware.RequirePermission(middleware.PermissionBookUpdate), handler.Update)
+	e.Delete("/:id", middleware.RequirePermission(middleware.PermissionBookDelete), handler.Delete)
+
+	bookv1.RegisterBookServiceServer(d.Grpc, &GrpcHandler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	})
+	d.Grpc.Permissions[bookv1.BookService_CreateBook_FullMethodName] = []string{middleware.PermissionBookCreate}
+	d.Grpc.Permissions[bookv1.BookService_UpdateBook_FullMethodName] = []string{middleware.PermissionBookUpdate}
+	d.Grpc.Permissions[bookv1.BookService_DeleteBook_FullMethodName] = []string{middleware.PermissionBookDelete}
 	return handler
 }
 
@@ -69,6 +107,35 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 	return c.Status(response.Code).JSON(response)
 }
 
+// Batch returns many books by ID, from the ids query comma separated or the ids of the body.
+func (h *Handler) Batch(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Batch")
+
+	// bind model
+	model := &schema.RequestBookBatch{}
+	sources := []binding.Source{binding.BindFromQuery()}
+	if c.Method() == fiber.MethodPost {
+		sources = append(sources, binding.BindFromBody())
+	}
+	if err := binding.BindModel(l, c, model, sources...); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+	if c.Method() == fiber.MethodGet {
+		model.IDs = validator.SplitCSV(strings.Join(model.IDs, ","))
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get books
+	response := h.UseCase.Batch(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
 // List returns a list of books.
 func (h *Handler) List(c *fiber.Ctx) error {
 	l := logger.WithID(h.Logger, ContextName, "List")
@@ -102,7 +169,7 @@ func (h *Handler) Get(c *fiber.Ctx) error {
 
 	// bind model
 	model := &schema.RequestBookGet{}
-	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromQuery()); err != nil {
 		perr := err.(*binding.ModelBindingError)
 		return c.Status(perr.Code).JSON(perr.ResponseBody)
 	}
@@ -161,3 +228,180 @@ func (h *Handler) Delete(c *fiber.Ctx) error {
 	response := h.UseCase.Delete(c.UserContext(), model)
 	return c.Status(response.Code).JSON(response)
 }
+
+// Stats returns catalog statistics.
+func (h *Handler) Stats(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Stats")
+
+	// bind model
+	model := &schema.RequestBookStats{
+		Bucket: "day",
+		Limit:  5,
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get catalog statistics
+	response := h.UseCase.Stats(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Duplicates returns possible duplicates of a book, or of a title and author.
+func (h *Handler) Duplicates(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Duplicates")
+
+	// bind model
+	model := &schema.RequestBookDuplicates{
+		Limit: 10,
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// find possible duplicates
+	response := h.UseCase.Duplicates(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Merge merges duplicate books into the book given by ID.
+func (h *Handler) Merge(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Merge")
+
+	// bind model
+	model := &schema.RequestBookMerge{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// merge duplicates into the book
+	response := h.UseCase.Merge(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Transition moves a book through the publication workflow.
+func (h *Handler) Transition(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Transition")
+
+	// bind model
+	model := &schema.RequestBookTransition{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// apply the transition
+	response := h.UseCase.Transition(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// ListTransitions returns the publication history of a book.
+func (h *Handler) ListTransitions(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ListTransitions")
+
+	// bind model
+	model := &schema.RequestBookTransitionList{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get publication history
+	response := h.UseCase.ListTransitions(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Events streams book changes as Server-Sent Events, resuming after the Last-Event-ID header.
+func (h *Handler) Events(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Events")
+
+	// bind model
+	model := &schema.RequestBookEvents{}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery(), binding.BindFromHeaders()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// subscribe before answering so no change is missed between replay and live events
+	sub, replay := h.Stream.Subscribe(model)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.Stream.Unsubscribe(sub)
+
+		ticker := time.NewTicker(h.Heartbeat)
+		defer ticker.Stop()
+
+		// a first heartbeat sends the headers right away
+		if err := sse.WriteHeartbeat(w); err != nil {
+			return
+		}
+		for _, evt := range replay {
+			if err := sse.Write(w, evt); err != nil {
+				return
+			}
+		}
+
+		for {
+			select {
+			case evt, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				if err := sse.Write(w, evt); err != nil {
+					l.Debug("client disconnected", zap.Error(err))
+					return
+				}
+			case <-ticker.C:
+				if err := sse.WriteHeartbeat(w); err != nil {
+					l.Debug("client disconnected", zap.Error(err))
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Alwanly/go-codebase/internal/book/schema"
 	"github.com/Alwanly/go-codebase/model"
 	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
 	"github.com/Alwanly/go-codebase/pkg/redis"
 	"github.com/Alwanly/go-codebase/pkg/utils"
+	goredis "github.com/go-redis/redis/v9"
+	"gorm.io/gorm"
 )
 
 const ContextName = "Internal.User.Repository"
@@ -21,13 +25,45 @@ type (
 
 	IRepository interface {
 		Create(context.Context, *model.Book) error
-		Get(context.Context, string) *model.Book
+		Get(context.Context, string, []string) *model.Book
+		GetVisible(context.Context, string, []string, *middleware.AuthUserData) *model.Book
 		List(context.Context, schema.RequestBookList) ([]model.Book, int64)
+		GetUsers(context.Context, []string) []model.User
+		Count(ctx context.Context, before time.Time) (int64, error)
+		CountByBucket(ctx context.Context, bucket string, from, to time.Time) ([]schema.StatsBucket, error)
+		TopAuthors(ctx context.Context, from, to time.Time, limit int) ([]schema.StatsCount, error)
+		TopCreators(ctx context.Context, from, to time.Time, limit int) ([]schema.StatsCount, error)
+		FindDuplicates(ctx context.Context, book model.Book, threshold float64, limit int, user *middleware.AuthUserData) ([]schema.ResponseBookDuplicate, error)
+		GetByIDs(ctx context.Context, ids []string) ([]model.Book, error)
+		Merge(ctx context.Context, merges []model.BookMerge) error
+		Transition(ctx context.Context, book *model.Book, transition *model.BookTransition) error
+		ListTransitions(ctx context.Context, bookID string) []model.BookTransition
+		GetCachedStats(ctx context.Context, key string) *schema.ResponseBookStats
+		SetCachedStats(ctx context.Context, key string, stats *schema.ResponseBookStats, ttl time.Duration) error
+		GetCachedBooks(ctx context.Context, ids []string) (map[string]model.Book, map[string]string)
+		SetCachedBooks(ctx context.Context, books []model.Book, versions map[string]string, ttl time.Duration) error
+		DeleteCachedBooks(ctx context.Context, ids []string) error
 		Update(context.Context, *model.Book) error
 		Delete(context.Context, string) error
+		RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+		SubscribeChanges(ctx context.Context, channel string) *goredis.PubSub
 	}
 )
 
+// bookReferences lists the columns pointing at a book, they follow a merged book to its survivor.
+// When unique is set a book can only be referenced once per value of that column, the survivor's
+// row wins and otherwise the most recently updated one.
+var bookReferences = []struct{ table, column, unique string }{
+	{"book_merges", "survivor_id", ""},
+	{"book_transitions", "book_id", ""},
+	{"reading_progress", "book_id", "user_id"},
+}
+
+// duplicateScore weights title similarity over author similarity, the author is matched as a word
+// in either direction so "tolkien" matches "j r r tolkien".
+const duplicateScore = `similarity(normalized_title, @title) * 0.7 +
+	greatest(word_similarity(@author, normalized_author), word_similarity(normalized_author, @author)) * 0.3`
+
 func NewRepository(r Repository) IRepository {
 	return &Repository{
 		DB:    r.DB,
@@ -39,27 +75,90 @@ func (r *Repository) Create(ctx context.Context, book *model.Book) error {
 	return r.DB.GetTransaction(ctx).Create(book).Error
 }
 
-func (r *Repository) Get(ctx context.Context, id string) *model.Book {
+func (r *Repository) Get(ctx context.Context, id string, columns []string) *model.Book {
+	var book model.Book
+	tx := r.DB.GetTransaction(ctx)
+	if len(columns) > 0 {
+		tx = tx.Select(columns)
+	}
+
+	if err := tx.Where("id = ?", id).First(&book).Error; err != nil {
+		return nil
+	}
+	return &book
+}
+
+// VisibleTo limits books to those the user may see. Librarians and admins see every book,
+// others see published books whose publication time has passed and the books they created.
+func VisibleTo(user *middleware.AuthUserData) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if schema.CanSeeUnpublished(user) {
+			return tx
+		}
+
+		userID := ""
+		if user != nil {
+			userID = user.UserID
+		}
+		return tx.Where("(status = ? AND (published_at IS NULL OR published_at <= ?)) OR created_by = ?",
+			schema.StatusPublished, time.Now(), userID)
+	}
+}
+
+// Published keeps the books anyone may see, published ones whose publication time has come.
+func Published(tx *gorm.DB) *gorm.DB {
+	return tx.Where("books.status = ? AND (books.published_at IS NULL OR books.published_at <= ?)",
+		schema.StatusPublished, time.Now())
+}
+
+func (r *Repository) GetVisible(ctx context.Context, id string, columns []string, user *middleware.AuthUserData) *model.Book {
 	var book model.Book
-	r.DB.GetTransaction(ctx).Where("id = ?", id).First(&book)
+	tx := r.DB.GetTransaction(ctx).Scopes(VisibleTo(user))
+	if len(columns) > 0 {
+		tx = tx.Select(columns)
+	}
+
+	if err := tx.Where("id = ?", id).First(&book).Error; err != nil {
+		return nil
+	}
 	return &book
 }
 
 func (r *Repository) List(ctx context.Context, req schema.RequestBookList) ([]model.Book, int64) {
 	var books []model.Book
 	var total int64
-	tx := r.DB.GetTransaction(ctx)
+	tx := r.DB.GetTransaction(ctx).Scopes(VisibleTo(req.AuthUserData))
+	if req.Status != "" {
+		tx = tx.Where("status = ?", req.Status)
+	}
+	tx = tx.Session(&gorm.Session{})
 
 	tx.Model(&model.Book{}).Count(&total)
 
+	query := tx.Model(&model.Book{})
+	if columns := req.Columns(); len(columns) > 0 {
+		query = query.Select(columns)
+	}
+
 	offset := utils.CalculatePageSkip(req.Page, req.PageSize)
-	tx.Offset(offset).Limit(req.PageSize)
-	tx.Order(fmt.Sprintf("%s %s", req.SortBy, req.SortOrder))
-	tx.Find(&books)
+	query.Offset(offset).
+		Limit(req.PageSize).
+		Order(fmt.Sprintf("%s %s", req.SortBy, req.SortOrder)).
+		Find(&books)
 
 	return books, total
 }
 
+func (r *Repository) GetUsers(ctx context.Context, ids []string) []model.User {
+	var users []model.User
+	if len(ids) == 0 {
+		return users
+	}
+
+	r.DB.GetTransaction(ctx).Select("id", "username").Where("id IN ?", ids).Find(&users)
+	return users
+}
+
 func (r *Repository) Update(ctx context.Context, book *model.Book) error {
 	return r.DB.GetTransaction(ctx).Save(book).Error
 }
@@ -67,3 +166,256 @@ func (r *Repository) Update(ctx context.Context, book *model.Book) error {
 func (r *Repository) Delete(ctx context.Context, id string) error {
 	return r.DB.GetTransaction(ctx).Where("id = ?", id).Delete(&model.Book{}).Error
 }
+
+// FindDuplicates returns the books similar to the book among those the user may see, the most
+// similar first.
+func (r *Repository) FindDuplicates(ctx context.Context, book model.Book, threshold float64, limit int, user *middleware.AuthUserData) ([]schema.ResponseBookDuplicate, error) {
+	var duplicates []schema.ResponseBookDuplicate
+	tx := r.DB.GetTransaction(ctx)
+	err := tx.Raw(`
+		SELECT id, title, author, score FROM (
+			SELECT id, title, author, `+duplicateScore+` AS score
+			FROM (@books) books
+			WHERE (normalized_title % @title OR normalized_author % @author) AND id <> @id
+		) candidates
+		WHERE score >= @threshold
+		ORDER BY score DESC
+		LIMIT @limit`,
+		map[string]interface{}{
+			"books":     tx.Model(&model.Book{}).Scopes(VisibleTo(user)),
+			"id":        book.ID,
+			"title":     utils.NormalizeTitle(book.Title),
+			"author":    utils.NormalizeAuthor(book.Author),
+			"threshold": threshold,
+			"limit":     limit,
+		}).Scan(&duplicates).Error
+	return duplicates, err
+}
+
+func (r *Repository) GetByIDs(ctx context.Context, ids []string) ([]model.Book, error) {
+	var books []model.Book
+	if len(ids) == 0 {
+		return books, nil
+	}
+
+	if err := r.DB.GetTransaction(ctx).Where("id IN ?", ids).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (r *Repository) Merge(ctx context.Context, merges []model.BookMerge) error {
+	if len(merges) == 0 {
+		return nil
+	}
+
+	survivorID := merges[0].SurvivorID
+	mergedIDs := make([]string, len(merges))
+	for i, merge := range merges {
+		mergedIDs[i] = merge.MergedID
+	}
+
+	return r.DB.GetTransaction(ctx).Transaction(func(tx *gorm.DB) error {
+		// repoint everything referencing the duplicates to the survivor
+		for _, ref := range bookReferences {
+			if ref.unique != "" {
+				err := tx.Exec(fmt.Sprintf(`DELETE FROM %[1]s p WHERE p.%[2]s IN @merged AND EXISTS (
+					SELECT 1 FROM %[1]s o WHERE o.%[3]s = p.%[3]s AND o.id <> p.id AND (o.%[2]s = @survivor OR
+						(o.%[2]s IN @merged AND (o.updated_at, o.id) > (p.updated_at, p.id))))`, ref.table, ref.column, ref.unique),
+					map[string]interface{}{"merged": mergedIDs, "survivor": survivorID}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			err := tx.Table(ref.table).Where(fmt.Sprintf("%s IN ?", ref.column), mergedIDs).Update(ref.column, survivorID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&merges).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id IN ?", mergedIDs).Delete(&model.Book{}).Error
+	})
+}
+
+func (r *Repository) Transition(ctx context.Context, book *model.Book, transition *model.BookTransition) error {
+	return r.DB.GetTransaction(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(book).
+			Select("status", "published_at", "updated_at", "updated_by").
+			Updates(book).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(transition).Error
+	})
+}
+
+func (r *Repository) ListTransitions(ctx context.Context, bookID string) []model.BookTransition {
+	var transitions []model.BookTransition
+	r.DB.GetTransaction(ctx).Where("book_id = ?", bookID).Order("created_at").Find(&transitions)
+	return transitions
+}
+
+func (r *Repository) Count(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	err := r.DB.GetTransaction(ctx).Model(&model.Book{}).Where("created_at < ?", before).Scopes(Published).Count(&total).Error
+	return total, err
+}
+
+func (r *Repository) CountByBucket(ctx context.Context, bucket string, from, to time.Time) ([]schema.StatsBucket, error) {
+	var buckets []schema.StatsBucket
+	err := r.DB.GetTransaction(ctx).Model(&model.Book{}).
+		Select("date_trunc(?, created_at) AS bucket, count(*) AS count", bucket).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scopes(Published).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+func (r *Repository) TopAuthors(ctx context.Context, from, to time.Time, limit int) ([]schema.StatsCount, error) {
+	var counts []schema.StatsCount
+	err := r.DB.GetTransaction(ctx).Model(&model.Book{}).
+		Select("author AS key, count(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scopes(Published).
+		Group("author").
+		Order("count DESC, key").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *Repository) TopCreators(ctx context.Context, from, to time.Time, limit int) ([]schema.StatsCount, error) {
+	var counts []schema.StatsCount
+	err := r.DB.GetTransaction(ctx).Model(&model.Book{}).
+		Select("books.created_by AS key, users.username AS label, count(*) AS count").
+		Joins("LEFT JOIN users ON users.id = books.created_by").
+		Where("books.created_at >= ? AND books.created_at < ?", from, to).
+		Scopes(Published).
+		Group("books.created_by, users.username").
+		Order("count DESC, key").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *Repository) GetCachedStats(ctx context.Context, key string) *schema.ResponseBookStats {
+	value, err := r.Redis.Get(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	var stats schema.ResponseBookStats
+	if err := utils.JSONUnMarshal([]byte(value), &stats); err != nil {
+		return nil
+	}
+	return &stats
+}
+
+func (r *Repository) SetCachedStats(ctx context.Context, key string, stats *schema.ResponseBookStats, ttl time.Duration) error {
+	value, err := utils.JSONMarshal(stats)
+	if err != nil {
+		return err
+	}
+	return r.Redis.Set(ctx, key, value, ttl)
+}
+
+// MaxBookCacheTTL caps the time to live of a cached book, the version of a book outlives its
+// cached copies for that long.
+const MaxBookCacheTTL = 24 * time.Hour
+
+// bookVersionKey holds the version of the cached copy of a book, changing the book changes it.
+func bookVersionKey(id string) string {
+	return "books:version:" + id
+}
+
+// bookCacheKey is the key of a cached book at a version, the whole row is cached so any projection
+// can be served.
+func bookCacheKey(id string, version string) string {
+	return "books:item:" + id + ":" + version
+}
+
+// GetCachedBooks returns the cached books among the IDs and the current cache version of every ID,
+// by ID. Books that cannot be read from the cache are left out so they are read from the database,
+// and cached with SetCachedBooks at the version returned here.
+func (r *Repository) GetCachedBooks(ctx context.Context, ids []string) (map[string]model.Book, map[string]string) {
+	books := map[string]model.Book{}
+	versions := map[string]string{}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = bookVersionKey(id)
+	}
+	values, err := r.Redis.MGet(ctx, keys)
+	if err != nil {
+		return books, versions
+	}
+	for i, value := range values {
+		version, _ := value.(string)
+		versions[ids[i]] = version
+		keys[i] = bookCacheKey(ids[i], version)
+	}
+
+	values, err = r.Redis.MGet(ctx, keys)
+	if err != nil {
+		return books, versions
+	}
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var book model.Book
+		if err := utils.JSONUnMarshal([]byte(s), &book); err == nil {
+			books[ids[i]] = book
+		}
+	}
+	return books, versions
+}
+
+// SetCachedBooks caches the books at the versions read by GetCachedBooks before the books were read
+// from the database. A book changed meanwhile has a new version, so its stale copy is never read.
+func (r *Repository) SetCachedBooks(ctx context.Context, books []model.Book, versions map[string]string, ttl time.Duration) error {
+	values := map[string]interface{}{}
+	for _, book := range books {
+		version, ok := versions[book.ID]
+		if !ok {
+			continue
+		}
+		value, err := utils.JSONMarshal(book)
+		if err != nil {
+			return err
+		}
+		values[bookCacheKey(book.ID, version)] = value
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return r.Redis.MSet(ctx, values, min(ttl, MaxBookCacheTTL))
+}
+
+// DeleteCachedBooks gives the books a new cache version, their cached copies are no longer read
+// and expire with their ttl.
+func (r *Repository) DeleteCachedBooks(ctx context.Context, ids []string) error {
+	values := map[string]interface{}{}
+	for _, id := range ids {
+		values[bookVersionKey(id)] = utils.GenerateUUID()
+	}
+	return r.Redis.MSet(ctx, values, MaxBookCacheTTL)
+}
+
+func (r *Repository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.DB.RunInTransaction(ctx, fn)
+}
+
+// SubscribeChanges subscribes to the book changes the outbox relay publishes on the channel.
+func (r *Repository) SubscribeChanges(ctx context.Context, channel string) *goredis.PubSub {
+	return r.Redis.Subscribe(ctx, channel)
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/book/repository"
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newRepository(t *testing.T) (*repository.Repository, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+	return &repository.Repository{DB: &database.DBService{Gorm: db}}, mock
+}
+
+// published matches the condition that leaves out draft books and books scheduled for later, its
+// placeholders follow the n arguments of the query.
+func published(n int) string {
+	return regexp.QuoteMeta(fmt.Sprintf("(books.status = $%d AND (books.published_at IS NULL OR books.published_at <= $%d))", n+1, n+2))
+}
+
+func TestStats_LeaveOutDraftAndScheduledBooks(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		args  []driver.Value
+		run   func(r *repository.Repository) error
+	}{
+		{
+			name:  "count",
+			query: `FROM "books" WHERE created_at < \$1 AND ` + published(1),
+			args:  []driver.Value{from, schema.StatusPublished, sqlmock.AnyArg()},
+			run: func(r *repository.Repository) error {
+				_, err := r.Count(context.Background(), from)
+				return err
+			},
+		},
+		{
+			name:  "count by bucket",
+			query: `FROM "books" WHERE \(created_at >= \$2 AND created_at < \$3\) AND ` + published(3),
+			args:  []driver.Value{"day", from, to, schema.StatusPublished, sqlmock.AnyArg()},
+			run: func(r *repository.Repository) error {
+				_, err := r.CountByBucket(context.Background(), "day", from, to)
+				return err
+			},
+		},
+		{
+			name:  "top authors",
+			query: `FROM "books" WHERE \(created_at >= \$1 AND created_at < \$2\) AND ` + published(2),
+			args:  []driver.Value{from, to, schema.StatusPublished, sqlmock.AnyArg(), 5},
+			run: func(r *repository.Repository) error {
+				_, err := r.TopAuthors(context.Background(), from, to, 5)
+				return err
+			},
+		},
+		{
+			name:  "top creators",
+			query: `WHERE \(books.created_at >= \$1 AND books.created_at < \$2\) AND ` + published(2),
+			args:  []driver.Value{from, to, schema.StatusPublished, sqlmock.AnyArg(), 5},
+			run: func(r *repository.Repository) error {
+				_, err := r.TopCreators(context.Background(), from, to, 5)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, mock := newRepository(t)
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(sqlmock.NewRows([]string{"count"}))
+
+			assert.NoError(t, tt.run(r))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
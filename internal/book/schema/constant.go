@@ -1,10 +1,29 @@
 package schema
 
 import (
+	"time"
+
 	"github.com/Alwanly/go-codebase/model"
 	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/validator"
 )
 
+const IncludeCreator = "creator"
+
+// BookFields maps the names accepted by ?fields= to their column in the books table.
+var BookFields = map[string]string{
+	"id":          "id",
+	"title":       "title",
+	"author":      "author",
+	"status":      "status",
+	"publishedAt": "published_at",
+	"createdAt":   "created_at",
+	"createdBy":   "created_by",
+	"updatedAt":   "updated_at",
+	"updatedBy":   "updated_by",
+}
+
 type RequestBookCreate struct {
 	Title  string `json:"title" validate:"required,min=3,max=255"`
 	Author string `json:"author" validate:"required"`
@@ -13,19 +32,39 @@ type RequestBookCreate struct {
 }
 
 type ResponseBookCreate struct {
-	ID string `json:"id"`
+	ID                 string                  `json:"id"`
+	PossibleDuplicates []ResponseBookDuplicate `json:"possibleDuplicates,omitempty"`
+}
+
+// Projection holds the sparse fieldset and embedded relations requested by the client.
+type Projection struct {
+	Fields  string `query:"fields"`
+	Include string `query:"include" validate:"omitempty,csvoneof=creator"`
 }
 
 type RequestBookGet struct {
 	ID string `params:"id" validate:"required"`
+	Projection
 
 	AuthUserData *middleware.AuthUserData
 }
 
+type ResponseBookCreator struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
 type ResponseBookGet struct {
-	ID     string `json:"id"`
-	Title  string `json:"title"`
-	Author string `json:"author"`
+	ID          string               `json:"id"`
+	Title       string               `json:"title,omitempty"`
+	Author      string               `json:"author,omitempty"`
+	Status      string               `json:"status,omitempty"`
+	PublishedAt *time.Time           `json:"publishedAt,omitempty"`
+	CreatedAt   *time.Time           `json:"createdAt,omitempty"`
+	CreatedBy   string               `json:"createdBy,omitempty"`
+	UpdatedAt   *time.Time           `json:"updatedAt,omitempty"`
+	UpdatedBy   string               `json:"updatedBy,omitempty"`
+	Creator     *ResponseBookCreator `json:"creator,omitempty"`
 }
 
 type RequestBookList struct {
@@ -33,10 +72,26 @@ type RequestBookList struct {
 	PageSize  int    `query:"page_size" validate:"required,min=1,max=100"`
 	SortBy    string `query:"sort_by" validate:"required,oneof=title author"`
 	SortOrder string `query:"sort_order" validate:"required,oneof=asc desc"`
+	Status    string `query:"status" validate:"omitempty,oneof=draft in_review published archived"`
+	Projection
 
 	AuthUserData *middleware.AuthUserData
 }
 
+type RequestBookBatch struct {
+	IDs []string `query:"ids" json:"ids" validate:"required,min=1,max=100,dive,required,max=255"`
+	Projection
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseBookBatch holds the books in the order they were requested, the IDs of books that do not
+// exist or are not visible to the user are listed in missing.
+type ResponseBookBatch struct {
+	Books   []ResponseBookGet `json:"books"`
+	Missing []string          `json:"missing"`
+}
+
 type RequestBookUpdate struct {
 	ID string `params:"id" validate:"required"`
 
@@ -58,14 +113,197 @@ type RequestBookDelete struct {
 
 type ResponseBookDelete struct{}
 
-func (r *RequestBookList) ToResponse(books []model.Book) []ResponseBookGet {
+type RequestBookTransition struct {
+	ID        string     `params:"id" validate:"required"`
+	Action    string     `json:"action" validate:"required,oneof=submit publish reject archive"`
+	PublishAt *time.Time `json:"publishAt"`
+	Note      string     `json:"note" validate:"max=500"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseBookTransition struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+}
+
+type RequestBookTransitionList struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseBookTransitionHistory struct {
+	Action     string    `json:"action"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	Note       string    `json:"note,omitempty"`
+	ActorID    string    `json:"actorId"`
+	ActorRole  string    `json:"actorRole"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type RequestBookStats struct {
+	From   string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To     string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Bucket string `query:"bucket" validate:"required,oneof=day week month"`
+	Limit  int    `query:"limit" validate:"required,min=1,max=50"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestBookDuplicates struct {
+	ID        string  `params:"id"`
+	Title     string  `query:"title" validate:"required_without=ID,max=255"`
+	Author    string  `query:"author" validate:"required_without=ID,max=255"`
+	Threshold float64 `query:"threshold" validate:"omitempty,gt=0,lte=1"`
+	Limit     int     `query:"limit" validate:"required,min=1,max=50"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseBookDuplicate is a possible duplicate with its similarity score between 0 and 1.
+type ResponseBookDuplicate struct {
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Author string  `json:"author"`
+	Score  float64 `json:"score"`
+}
+
+type RequestBookMerge struct {
+	ID           string   `params:"id" validate:"required"`
+	DuplicateIDs []string `json:"duplicateIds" validate:"required,min=1,max=50,dive,required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseBookMerge struct {
+	ID     string   `json:"id"`
+	Merged []string `json:"merged"`
+}
+
+// StatsBucket is the number of books created in one time bucket and the catalog size at its end.
+type StatsBucket struct {
+	Bucket     time.Time `json:"bucket"`
+	Count      int64     `json:"count"`
+	Cumulative int64     `json:"cumulative"`
+	Growth     float64   `json:"growth"`
+}
+
+// StatsCount is a grouped count, Label is set when the key has a readable name.
+type StatsCount struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+type ResponseBookStats struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Bucket      string        `json:"bucket"`
+	TotalBooks  int64         `json:"totalBooks"`
+	Created     int64         `json:"created"`
+	Trend       []StatsBucket `json:"trend"`
+	TopAuthors  []StatsCount  `json:"topAuthors"`
+	TopCreators []StatsCount  `json:"topCreators"`
+}
+
+// Columns returns the columns to select for the requested fields, or nil to select every column.
+// The primary key is always selected, and the creator column is added when the creator is embedded.
+func (p *Projection) Columns() []string {
+	fields := validator.SplitCSV(p.Fields)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	columns := []string{BookFields["id"]}
+	for _, field := range fields {
+		if column := BookFields[field]; !utils.AnyInSlice(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	if p.HasInclude(IncludeCreator) && !utils.AnyInSlice(columns, BookFields["createdBy"]) {
+		columns = append(columns, BookFields["createdBy"])
+	}
+
+	return columns
+}
+
+// UnknownField returns the first requested field that is not a key of BookFields, empty when every
+// field is known.
+func (p *Projection) UnknownField() string {
+	for _, field := range validator.SplitCSV(p.Fields) {
+		if _, ok := BookFields[field]; !ok {
+			return field
+		}
+	}
+	return ""
+}
+
+// HasInclude reports whether the relation was requested with ?include=.
+func (p *Projection) HasInclude(relation string) bool {
+	return utils.AnyInSlice(validator.SplitCSV(p.Include), relation)
+}
+
+// hasField reports whether the field should be part of the response.
+func (p *Projection) hasField(field string) bool {
+	fields := validator.SplitCSV(p.Fields)
+	return len(fields) == 0 || utils.AnyInSlice(fields, field)
+}
+
+// ToResponse maps a book to its response using the requested fields, embedding the creator if given.
+func (p *Projection) ToResponse(book model.Book, creator *model.User) ResponseBookGet {
+	response := ResponseBookGet{ID: book.ID}
+	if p.hasField("title") {
+		response.Title = book.Title
+	}
+	if p.hasField("author") {
+		response.Author = book.Author
+	}
+	if p.hasField("status") {
+		response.Status = book.Status
+	}
+	if p.hasField("publishedAt") {
+		response.PublishedAt = book.PublishedAt
+	}
+	if p.hasField("createdAt") && !book.CreatedAt.IsZero() {
+		response.CreatedAt = utils.ToPointer(book.CreatedAt)
+	}
+	if p.hasField("createdBy") {
+		response.CreatedBy = book.CreatedBy
+	}
+	if p.hasField("updatedAt") && !book.UpdatedAt.IsZero() {
+		response.UpdatedAt = utils.ToPointer(book.UpdatedAt)
+	}
+	if p.hasField("updatedBy") {
+		response.UpdatedBy = book.UpdatedBy
+	}
+	if creator != nil {
+		response.Creator = &ResponseBookCreator{
+			ID:       creator.ID,
+			Username: creator.Username,
+		}
+	}
+	return response
+}
+
+func (r *RequestBookList) ToResponse(books []model.Book, creators map[string]model.User) []ResponseBookGet {
 	responseBooks := make([]ResponseBookGet, len(books))
 	for i, book := range books {
-		responseBooks[i] = ResponseBookGet{
-			ID:     book.ID,
-			Title:  book.Title,
-			Author: book.Author,
+		var creator *model.User
+		if c, ok := creators[book.CreatedBy]; ok {
+			creator = &c
 		}
+		responseBooks[i] = r.Projection.ToResponse(book, creator)
 	}
 	return responseBooks
 }
+
+type RequestBookEvents struct {
+	ID          string `query:"id"`
+	Author      string `query:"author" validate:"max=255"`
+	LastEventID string `reqHeader:"Last-Event-ID" validate:"max=64"`
+
+	AuthUserData *middleware.AuthUserData
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+)
+
+// AggregateBook is the aggregate type of book events in the outbox.
+const AggregateBook = "book"
+
+const (
+	EventBookCreated = "book.created"
+	EventBookUpdated = "book.updated"
+	EventBookDeleted = "book.deleted"
+)
+
+// BookEvent is the data of a book lifecycle event.
+type BookEvent struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Author      string     `json:"author"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CreatedBy   string     `json:"createdBy"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UpdatedBy   string     `json:"updatedBy,omitempty"`
+	// ActorID is the user who caused the event.
+	ActorID string `json:"actorId"`
+	// MergedInto is set when the book was deleted by merging it into another one.
+	MergedInto string `json:"mergedInto,omitempty"`
+}
+
+func ToBookEvent(book *model.Book, actorID string) BookEvent {
+	return BookEvent{
+		ID:          book.ID,
+		Title:       book.Title,
+		Author:      book.Author,
+		Status:      book.Status,
+		PublishedAt: book.PublishedAt,
+		CreatedAt:   book.CreatedAt,
+		CreatedBy:   book.CreatedBy,
+		UpdatedAt:   book.UpdatedAt,
+		UpdatedBy:   book.UpdatedBy,
+		ActorID:     actorID,
+	}
+}
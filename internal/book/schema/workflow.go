@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+
+	ActionSubmit  = "submit"
+	ActionPublish = "publish"
+	ActionReject  = "reject"
+	ActionArchive = "archive"
+)
+
+// transition is an edge of the publication state machine.
+type transition struct {
+	From  string
+	To    string
+	Roles []string
+	// Owner allows the creator of the book regardless of role.
+	Owner bool
+}
+
+// transitions is the publication state machine keyed by action.
+var transitions = map[string]transition{
+	ActionSubmit: {
+		From:  StatusDraft,
+		To:    StatusInReview,
+		Roles: []string{middleware.RoleAdmin, middleware.RoleLibrarian},
+		Owner: true,
+	},
+	ActionPublish: {
+		From:  StatusInReview,
+		To:    StatusPublished,
+		Roles: []string{middleware.RoleAdmin, middleware.RoleLibrarian},
+	},
+	ActionReject: {
+		From:  StatusInReview,
+		To:    StatusDraft,
+		Roles: []string{middleware.RoleAdmin, middleware.RoleLibrarian},
+	},
+	ActionArchive: {
+		From:  StatusPublished,
+		To:    StatusArchived,
+		Roles: []string{middleware.RoleAdmin, middleware.RoleLibrarian},
+	},
+}
+
+// NextStatus returns the status reached by applying the action to a book in the given status.
+// It returns false when the action is not allowed from that status.
+func NextStatus(status string, action string) (string, bool) {
+	t, ok := transitions[action]
+	if !ok || t.From != status {
+		return "", false
+	}
+	return t.To, true
+}
+
+// CanTransition reports whether the user may apply the action, owner tells if the user created the book.
+func CanTransition(action string, user *middleware.AuthUserData, owner bool) bool {
+	t, ok := transitions[action]
+	if !ok || user == nil {
+		return false
+	}
+	if t.Owner && owner {
+		return true
+	}
+	for _, role := range t.Roles {
+		if user.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanEdit reports whether the user may change or delete a book, owner tells if the user created it.
+func CanEdit(user *middleware.AuthUserData, owner bool) bool {
+	return user != nil && (owner || CanSeeUnpublished(user))
+}
+
+// CanSeeUnpublished reports whether the user sees books that are not published yet.
+func CanSeeUnpublished(user *middleware.AuthUserData) bool {
+	return user != nil && (user.HasRole(middleware.RoleAdmin) || user.HasRole(middleware.RoleLibrarian))
+}
+
+// IsVisible reports whether the user may see the book at the time, the same rule the repository
+// applies in SQL for books that are not read from the database.
+func IsVisible(book model.Book, user *middleware.AuthUserData, now time.Time) bool {
+	if CanSeeUnpublished(user) || (user != nil && book.CreatedBy == user.UserID) {
+		return true
+	}
+	return book.Status == StatusPublished && (book.PublishedAt == nil || !book.PublishedAt.After(now))
+}
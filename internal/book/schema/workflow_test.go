@@ -0,0 +1,137 @@
+package schema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	admin     = &middleware.AuthUserData{UserID: "admin-1", Roles: []string{middleware.RoleAdmin}}
+	librarian = &middleware.AuthUserData{UserID: "librarian-1", Roles: []string{middleware.RoleLibrarian}}
+	member    = &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		action string
+		next   string
+		ok     bool
+	}{
+		{status: schema.StatusDraft, action: schema.ActionSubmit, next: schema.StatusInReview, ok: true},
+		{status: schema.StatusInReview, action: schema.ActionPublish, next: schema.StatusPublished, ok: true},
+		{status: schema.StatusInReview, action: schema.ActionReject, next: schema.StatusDraft, ok: true},
+		{status: schema.StatusPublished, action: schema.ActionArchive, next: schema.StatusArchived, ok: true},
+		{status: schema.StatusDraft, action: schema.ActionPublish},
+		{status: schema.StatusArchived, action: schema.ActionSubmit},
+		{status: schema.StatusDraft, action: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status+"/"+tt.action, func(t *testing.T) {
+			next, ok := schema.NextStatus(tt.status, tt.action)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.next, next)
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		user    *middleware.AuthUserData
+		owner   bool
+		allowed bool
+	}{
+		{name: "owner submits", action: schema.ActionSubmit, user: member, owner: true, allowed: true},
+		{name: "member submits another book", action: schema.ActionSubmit, user: member},
+		{name: "librarian submits", action: schema.ActionSubmit, user: librarian, allowed: true},
+		{name: "owner publishes", action: schema.ActionPublish, user: member, owner: true},
+		{name: "librarian publishes", action: schema.ActionPublish, user: librarian, allowed: true},
+		{name: "admin rejects", action: schema.ActionReject, user: admin, allowed: true},
+		{name: "member archives", action: schema.ActionArchive, user: member, owner: true},
+		{name: "admin archives", action: schema.ActionArchive, user: admin, allowed: true},
+		{name: "unknown action", action: "unknown", user: admin},
+		{name: "no user", action: schema.ActionSubmit, owner: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, schema.CanTransition(tt.action, tt.user, tt.owner))
+		})
+	}
+}
+
+func TestCanSeeUnpublished(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *middleware.AuthUserData
+		allowed bool
+	}{
+		{name: "admin", user: admin, allowed: true},
+		{name: "librarian", user: librarian, allowed: true},
+		{name: "member", user: member},
+		{name: "no user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, schema.CanSeeUnpublished(tt.user))
+		})
+	}
+}
+
+func TestCanEdit(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *middleware.AuthUserData
+		owner   bool
+		allowed bool
+	}{
+		{name: "owner", user: member, owner: true, allowed: true},
+		{name: "member", user: member},
+		{name: "librarian", user: librarian, allowed: true},
+		{name: "admin", user: admin, allowed: true},
+		{name: "no user", owner: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, schema.CanEdit(tt.user, tt.owner))
+		})
+	}
+}
+
+func TestIsVisible(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		book    model.Book
+		user    *middleware.AuthUserData
+		visible bool
+	}{
+		{name: "published", book: model.Book{Status: schema.StatusPublished, PublishedAt: &past}, visible: true},
+		{name: "published without date", book: model.Book{Status: schema.StatusPublished}, visible: true},
+		{name: "scheduled", book: model.Book{Status: schema.StatusPublished, PublishedAt: &future}, user: member},
+		{name: "draft", book: model.Book{Status: schema.StatusDraft}, user: member},
+		{name: "own draft", book: model.Book{Status: schema.StatusDraft, CreatedBy: member.UserID}, user: member, visible: true},
+		{name: "scheduled for staff", book: model.Book{Status: schema.StatusPublished, PublishedAt: &future}, user: librarian, visible: true},
+		{name: "draft for admin", book: model.Book{Status: schema.StatusDraft}, user: admin, visible: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.visible, schema.IsVisible(tt.book, tt.user, now))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/book/repository"
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/sse"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const StreamContextName = "Internal.Book.Stream"
+
+type (
+	// Stream feeds the book changes published on the Redis channel by the outbox relay of any
+	// instance to the SSE subscribers of this instance.
+	Stream struct {
+		Logger     *zap.Logger
+		Repository repository.IRepository
+		Channel    string
+		Broker     *sse.Broker
+	}
+
+	IStream interface {
+		// Run relays the changes from the channel to the subscribers until the context is done, then
+		// disconnects them.
+		//
+		// Parameters:
+		//   - ctx: context
+		//
+		// Returns:
+		//   - error: always nil
+		Run(ctx context.Context) error
+
+		// Subscribe registers a subscriber for the changes the user may see and that match the request.
+		//
+		// Parameters:
+		//   - req: request
+		//
+		// Returns:
+		//   - *sse.Subscription: subscription
+		//   - []sse.Event: buffered events after the last event ID
+		Subscribe(req *schema.RequestBookEvents) (*sse.Subscription, []sse.Event)
+
+		// Unsubscribe removes the subscriber.
+		//
+		// Parameters:
+		//   - sub: subscription
+		Unsubscribe(sub *sse.Subscription)
+	}
+)
+
+func NewStream(s Stream) IStream {
+	return &Stream{
+		Logger:     s.Logger,
+		Repository: s.Repository,
+		Channel:    s.Channel,
+		Broker:     s.Broker,
+	}
+}
+
+func (s *Stream) Run(ctx context.Context) error {
+	l := logger.WithID(s.Logger, StreamContextName, "Run")
+
+	pubsub := s.Repository.SubscribeChanges(ctx, s.Channel)
+	defer pubsub.Close()
+	defer s.Broker.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var change struct {
+				ID   string           `json:"id"`
+				Type string           `json:"type"`
+				Data schema.BookEvent `json:"data"`
+			}
+			if err := utils.JSONUnMarshal([]byte(msg.Payload), &change); err != nil {
+				l.Warn("failed to decode change", zap.Error(err))
+				continue
+			}
+
+			publishedAt := ""
+			if change.Data.PublishedAt != nil {
+				publishedAt = change.Data.PublishedAt.Format(time.RFC3339Nano)
+			}
+			s.Broker.Publish(sse.Event{
+				ID:   change.ID,
+				Type: change.Type,
+				Data: []byte(msg.Payload),
+				Attributes: map[string]string{
+					"id":          change.Data.ID,
+					"author":      utils.NormalizeAuthor(change.Data.Author),
+					"status":      change.Data.Status,
+					"publishedAt": publishedAt,
+					"createdBy":   change.Data.CreatedBy,
+				},
+			})
+		}
+	}
+}
+
+func (s *Stream) Subscribe(req *schema.RequestBookEvents) (*sse.Subscription, []sse.Event) {
+	author := utils.NormalizeAuthor(req.Author)
+
+	return s.Broker.Subscribe(req.LastEventID, func(evt sse.Event) bool {
+		if req.ID != "" && evt.Attributes["id"] != req.ID {
+			return false
+		}
+		if author != "" && evt.Attributes["author"] != author {
+			return false
+		}
+		// the same visibility as listing books, a scheduled book stays hidden until its publication time
+		book := model.Book{Status: evt.Attributes["status"], CreatedBy: evt.Attributes["createdBy"]}
+		if publishedAt, err := time.Parse(time.RFC3339Nano, evt.Attributes["publishedAt"]); err == nil {
+			book.PublishedAt = &publishedAt
+		}
+		return schema.IsVisible(book, req.AuthUserData, time.Now())
+	})
+}
+
+func (s *Stream) Unsubscribe(sub *sse.Subscription) {
+	s.Broker.Unsubscribe(sub)
+}
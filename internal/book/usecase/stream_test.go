@@ -0,0 +1,41 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/internal/book/usecase"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/sse"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStream_SubscribeHidesBooksTheUserMayNotSee(t *testing.T) {
+	broker := sse.NewBroker(10, 10)
+	stream := usecase.NewStream(usecase.Stream{Logger: zap.NewNop(), Broker: broker})
+
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339Nano)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339Nano)
+	broker.Publish(sse.Event{ID: "published", Attributes: map[string]string{"status": schema.StatusPublished, "publishedAt": past, "createdBy": "other"}})
+	broker.Publish(sse.Event{ID: "scheduled", Attributes: map[string]string{"status": schema.StatusPublished, "publishedAt": future, "createdBy": "other"}})
+	broker.Publish(sse.Event{ID: "draft", Attributes: map[string]string{"status": schema.StatusDraft, "createdBy": "other"}})
+	broker.Publish(sse.Event{ID: "own", Attributes: map[string]string{"status": schema.StatusDraft, "createdBy": "member-1"}})
+
+	events := func(user *middleware.AuthUserData) []string {
+		sub, replay := stream.Subscribe(&schema.RequestBookEvents{LastEventID: "evicted", AuthUserData: user})
+		defer stream.Unsubscribe(sub)
+
+		ids := []string{}
+		for _, evt := range replay {
+			ids = append(ids, evt.ID)
+		}
+		return ids
+	}
+
+	member := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+	librarian := &middleware.AuthUserData{UserID: "librarian-1", Roles: []string{middleware.RoleLibrarian}}
+	assert.Equal(t, []string{"published", "own"}, events(member))
+	assert.Equal(t, []string{"published", "scheduled", "draft", "own"}, events(librarian))
+}
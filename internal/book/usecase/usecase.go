@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -10,6 +11,9 @@ import (
 	"github.com/Alwanly/go-codebase/internal/book/schema"
 	"github.com/Alwanly/go-codebase/model"
 	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/outbox"
+	"github.com/Alwanly/go-codebase/pkg/utils"
 	"github.com/Alwanly/go-codebase/pkg/wrapper"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -22,14 +26,21 @@ type (
 		Config     *config.GlobalConfig
 		Logger     *zap.Logger
 		Repository repository.IRepository
+		Outbox     outbox.IStore
 	}
 
 	IUseCase interface {
 		Create(context.Context, *schema.RequestBookCreate) wrapper.JSONResult
 		Get(context.Context, *schema.RequestBookGet) wrapper.JSONResult
 		List(context.Context, *schema.RequestBookList) wrapper.JSONResult
+		Batch(context.Context, *schema.RequestBookBatch) wrapper.JSONResult
 		Update(context.Context, *schema.RequestBookUpdate) wrapper.JSONResult
 		Delete(context.Context, *schema.RequestBookDelete) wrapper.JSONResult
+		Stats(context.Context, *schema.RequestBookStats) wrapper.JSONResult
+		Duplicates(context.Context, *schema.RequestBookDuplicates) wrapper.JSONResult
+		Merge(context.Context, *schema.RequestBookMerge) wrapper.JSONResult
+		Transition(context.Context, *schema.RequestBookTransition) wrapper.JSONResult
+		ListTransitions(context.Context, *schema.RequestBookTransitionList) wrapper.JSONResult
 	}
 )
 
@@ -38,9 +49,39 @@ func NewUseCase(uc UseCase) IUseCase {
 		Config:     uc.Config,
 		Logger:     uc.Logger,
 		Repository: uc.Repository,
+		Outbox:     uc.Outbox,
 	}
 }
 
+// withEvents runs the change and writes an event of the type for every book to the outbox, all in
+// one transaction so an event exists if and only if its change was committed. The cached books are
+// dropped once the change is committed.
+func (u *UseCase) withEvents(ctx context.Context, change func(ctx context.Context) error, eventType string, books ...schema.BookEvent) error {
+	err := u.Repository.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		for _, book := range books {
+			if err := u.Outbox.Add(ctx, schema.AggregateBook, book.ID, event.New(eventType, book)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+	if err := u.Repository.DeleteCachedBooks(ctx, ids); err != nil {
+		u.Logger.Warn("failed to drop cached books, they expire with their ttl", zap.Strings("ids", ids), zap.Error(err))
+	}
+	return nil
+}
+
 func (u *UseCase) Create(ctx context.Context, req *schema.RequestBookCreate) wrapper.JSONResult {
 	l := u.Logger.With(zap.String("usecase", "Create"))
 
@@ -50,70 +91,192 @@ func (u *UseCase) Create(ctx context.Context, req *schema.RequestBookCreate) wra
 		ID:        uuid.New().String(),
 		Title:     req.Title,
 		Author:    req.Author,
+		Status:    schema.StatusDraft,
 		CreatedBy: req.AuthUserData.UserID,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	if err := u.Repository.Create(ctx, book); err != nil {
+	create := func(ctx context.Context) error { return u.Repository.Create(ctx, book) }
+	if err := u.withEvents(ctx, create, schema.EventBookCreated, schema.ToBookEvent(book, req.AuthUserData.UserID)); err != nil {
 		l.Error("failed to create a book", zap.Error(err))
 		return wrapper.ResponseFailed(500, contract.StatusCodeInternalServerError, "Failed to create a book", nil)
 	}
 
 	l.Debug("book created", zap.String("id", book.ID))
 
-	return wrapper.ResponseSuccess(http.StatusCreated, schema.ResponseBookCreate{ID: book.ID})
+	// warn about possible duplicates, the book is created regardless
+	duplicates, err := u.Repository.FindDuplicates(ctx, *book, u.Config.BookDuplicateThreshold, 5, req.AuthUserData)
+	if err != nil {
+		l.Warn("failed to find duplicates", zap.Error(err))
+	}
+
+	return wrapper.ResponseSuccess(http.StatusCreated, schema.ResponseBookCreate{
+		ID:                 book.ID,
+		PossibleDuplicates: duplicates,
+	})
 }
 
 func (u *UseCase) Get(ctx context.Context, req *schema.RequestBookGet) wrapper.JSONResult {
 	l := u.Logger.With(zap.String("usecase", "Get"))
 
-	book := u.Repository.Get(ctx, req.ID)
+	if field := req.UnknownField(); field != "" {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, fmt.Sprintf("Unknown field %q", field), nil)
+	}
+
+	book := u.Repository.GetVisible(ctx, req.ID, req.Columns(), req.AuthUserData)
 
 	if book == nil {
 		l.Error("book not found", zap.String("id", req.ID))
 		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
 	}
 
-	return wrapper.ResponseSuccess(http.StatusOK, schema.ResponseBookGet{
-		ID:     book.ID,
-		Title:  book.Title,
-		Author: book.Author,
-	})
+	var creator *model.User
+	if req.HasInclude(schema.IncludeCreator) {
+		if users := u.Repository.GetUsers(ctx, []string{book.CreatedBy}); len(users) > 0 {
+			creator = &users[0]
+		}
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, req.ToResponse(*book, creator))
 }
 
 func (u *UseCase) List(ctx context.Context, req *schema.RequestBookList) wrapper.JSONResult {
 	l := u.Logger.With(zap.String("usecase", "List"))
 
+	if field := req.UnknownField(); field != "" {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, fmt.Sprintf("Unknown field %q", field), nil)
+	}
+
 	filter := schema.RequestBookList{
-		Page:      req.Page,
-		PageSize:  req.PageSize,
-		SortBy:    req.SortBy,
-		SortOrder: req.SortOrder,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		SortBy:     req.SortBy,
+		SortOrder:  req.SortOrder,
+		Status:     req.Status,
+		Projection: req.Projection,
 
 		AuthUserData: req.AuthUserData,
 	}
 	books, total := u.Repository.List(ctx, filter)
 
-	response := req.ToResponse(books)
+	creators := map[string]model.User{}
+	if req.HasInclude(schema.IncludeCreator) {
+		ids := []string{}
+		for _, book := range books {
+			if !utils.AnyInSlice(ids, book.CreatedBy) {
+				ids = append(ids, book.CreatedBy)
+			}
+		}
+		for _, user := range u.Repository.GetUsers(ctx, ids) {
+			creators[user.ID] = user
+		}
+	}
+
+	response := req.ToResponse(books, creators)
 	l.Debug("books listed", zap.Int64("total", total))
 	return wrapper.ResponsePagination(req.Page, req.PageSize, len(books), int(total), response, nil)
 }
 
+// Batch returns the books in the requested order, reading the cache first and the missed books
+// with a single query. Books the user may not see are reported missing as if they did not exist.
+func (u *UseCase) Batch(ctx context.Context, req *schema.RequestBookBatch) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "Batch"))
+
+	if field := req.UnknownField(); field != "" {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, fmt.Sprintf("Unknown field %q", field), nil)
+	}
+
+	ids := []string{}
+	for _, id := range req.IDs {
+		if !utils.AnyInSlice(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	books, versions := u.Repository.GetCachedBooks(ctx, ids)
+	misses := []string{}
+	for _, id := range ids {
+		if _, ok := books[id]; !ok {
+			misses = append(misses, id)
+		}
+	}
+	if len(misses) > 0 {
+		fetched, err := u.Repository.GetByIDs(ctx, misses)
+		if err != nil {
+			l.Error("failed to get books", zap.Error(err))
+			return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get books", nil)
+		}
+		for _, book := range fetched {
+			books[book.ID] = book
+		}
+
+		ttl := time.Duration(u.Config.BookCacheTTL) * time.Second
+		if err := u.Repository.SetCachedBooks(ctx, fetched, versions, ttl); err != nil {
+			l.Warn("failed to cache books", zap.Error(err))
+		}
+	}
+
+	now := time.Now()
+	found := []model.Book{}
+	response := schema.ResponseBookBatch{
+		Books:   []schema.ResponseBookGet{},
+		Missing: []string{},
+	}
+	for _, id := range ids {
+		book, ok := books[id]
+		if !ok || !schema.IsVisible(book, req.AuthUserData, now) {
+			response.Missing = append(response.Missing, id)
+			continue
+		}
+		found = append(found, book)
+	}
+
+	creators := map[string]model.User{}
+	if req.HasInclude(schema.IncludeCreator) {
+		creatorIDs := []string{}
+		for _, book := range found {
+			if !utils.AnyInSlice(creatorIDs, book.CreatedBy) {
+				creatorIDs = append(creatorIDs, book.CreatedBy)
+			}
+		}
+		for _, user := range u.Repository.GetUsers(ctx, creatorIDs) {
+			creators[user.ID] = user
+		}
+	}
+
+	for _, book := range found {
+		var creator *model.User
+		if c, ok := creators[book.CreatedBy]; ok {
+			creator = &c
+		}
+		response.Books = append(response.Books, req.ToResponse(book, creator))
+	}
+
+	l.Debug("books batch loaded", zap.Int("requested", len(ids)), zap.Int("cached", len(ids)-len(misses)), zap.Int("missing", len(response.Missing)))
+	return wrapper.ResponseSuccess(http.StatusOK, response)
+}
+
 func (u *UseCase) Update(ctx context.Context, req *schema.RequestBookUpdate) wrapper.JSONResult {
 	l := u.Logger.With(zap.String("usecase", "Update"))
 
-	book := u.Repository.Get(ctx, req.ID)
+	book := u.Repository.GetVisible(ctx, req.ID, nil, req.AuthUserData)
 	if book == nil {
 		l.Error("book not found", zap.String("id", req.ID))
 		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
 	}
 
+	if !schema.CanEdit(req.AuthUserData, book.CreatedBy == req.AuthUserData.UserID) {
+		l.Warn("update not allowed", zap.String("id", book.ID), zap.String("user", req.AuthUserData.UserID))
+		return wrapper.ResponseFailed(http.StatusForbidden, contract.StatusCodeForbidden, contract.ErrorInsufficientPrivilege, nil)
+	}
+
 	book.Title = req.Title
 	book.Author = req.Author
 	book.UpdatedAt = time.Now()
 
-	if err := u.Repository.Update(ctx, book); err != nil {
+	update := func(ctx context.Context) error { return u.Repository.Update(ctx, book) }
+	if err := u.withEvents(ctx, update, schema.EventBookUpdated, schema.ToBookEvent(book, req.AuthUserData.UserID)); err != nil {
 		l.Error("failed to update a book", zap.Error(err))
 		return wrapper.ResponseFailed(500, contract.StatusCodeInternalServerError, "Failed to update a book", nil)
 	}
@@ -126,13 +289,19 @@ func (u *UseCase) Update(ctx context.Context, req *schema.RequestBookUpdate) wra
 func (u *UseCase) Delete(ctx context.Context, req *schema.RequestBookDelete) wrapper.JSONResult {
 	l := u.Logger.With(zap.String("usecase", "Delete"))
 
-	book := u.Repository.Get(ctx, req.ID)
+	book := u.Repository.GetVisible(ctx, req.ID, nil, req.AuthUserData)
 	if book == nil {
 		l.Error("book not found", zap.String("id", req.ID))
 		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
 	}
 
-	if err := u.Repository.Delete(ctx, book.ID); err != nil {
+	if !schema.CanEdit(req.AuthUserData, book.CreatedBy == req.AuthUserData.UserID) {
+		l.Warn("delete not allowed", zap.String("id", book.ID), zap.String("user", req.AuthUserData.UserID))
+		return wrapper.ResponseFailed(http.StatusForbidden, contract.StatusCodeForbidden, contract.ErrorInsufficientPrivilege, nil)
+	}
+
+	remove := func(ctx context.Context) error { return u.Repository.Delete(ctx, book.ID) }
+	if err := u.withEvents(ctx, remove, schema.EventBookDeleted, schema.ToBookEvent(book, req.AuthUserData.UserID)); err != nil {
 		l.Error("failed to delete a book", zap.Error(err))
 		return wrapper.ResponseFailed(500, contract.StatusCodeInternalServerError, "Failed to delete a book", nil)
 	}
@@ -141,3 +310,261 @@ func (u *UseCase) Delete(ctx context.Context, req *schema.RequestBookDelete) wra
 
 	return wrapper.ResponseSuccess(http.StatusNoContent, schema.ResponseBookDelete{})
 }
+
+func (u *UseCase) Stats(ctx context.Context, req *schema.RequestBookStats) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "Stats"))
+
+	// resolve the date range, both ends are inclusive days in UTC
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.To != "" {
+		to, _ = time.Parse(time.DateOnly, req.To)
+	}
+	from := to.AddDate(0, 0, -29)
+	if req.From != "" {
+		from, _ = time.Parse(time.DateOnly, req.From)
+	}
+	if from.After(to) {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "from must not be after to", nil)
+	}
+	end := to.AddDate(0, 0, 1)
+
+	key := fmt.Sprintf("books:stats:%s:%s:%s:%d", from.Format(time.DateOnly), to.Format(time.DateOnly), req.Bucket, req.Limit)
+	if cached := u.Repository.GetCachedStats(ctx, key); cached != nil {
+		l.Debug("stats served from cache", zap.String("key", key))
+		return wrapper.ResponseSuccess(http.StatusOK, cached)
+	}
+
+	before, err := u.Repository.Count(ctx, from)
+	if err != nil {
+		l.Error("failed to count books", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get book statistics", nil)
+	}
+
+	trend, err := u.Repository.CountByBucket(ctx, req.Bucket, from, end)
+	if err != nil {
+		l.Error("failed to count books by bucket", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get book statistics", nil)
+	}
+
+	topAuthors, err := u.Repository.TopAuthors(ctx, from, end, req.Limit)
+	if err != nil {
+		l.Error("failed to get top authors", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get book statistics", nil)
+	}
+
+	topCreators, err := u.Repository.TopCreators(ctx, from, end, req.Limit)
+	if err != nil {
+		l.Error("failed to get top creators", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get book statistics", nil)
+	}
+
+	// accumulate the trend into a running total and the growth against the previous total
+	total := before
+	created := int64(0)
+	for i := range trend {
+		if total > 0 {
+			trend[i].Growth = float64(trend[i].Count) / float64(total) * 100
+		}
+		total += trend[i].Count
+		created += trend[i].Count
+		trend[i].Cumulative = total
+	}
+
+	stats := &schema.ResponseBookStats{
+		From:        from,
+		To:          to,
+		Bucket:      req.Bucket,
+		TotalBooks:  total,
+		Created:     created,
+		Trend:       trend,
+		TopAuthors:  topAuthors,
+		TopCreators: topCreators,
+	}
+
+	ttl := time.Duration(u.Config.BookStatsCacheTTL) * time.Second
+	if err := u.Repository.SetCachedStats(ctx, key, stats, ttl); err != nil {
+		l.Warn("failed to cache stats", zap.Error(err))
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, stats)
+}
+
+func (u *UseCase) Duplicates(ctx context.Context, req *schema.RequestBookDuplicates) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "Duplicates"))
+
+	book := &model.Book{Title: req.Title, Author: req.Author}
+	if req.ID != "" {
+		book = u.Repository.GetVisible(ctx, req.ID, nil, req.AuthUserData)
+		if book == nil {
+			l.Error("book not found", zap.String("id", req.ID))
+			return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
+		}
+	}
+
+	threshold := utils.IfThenElse(req.Threshold > 0, req.Threshold, u.Config.BookDuplicateThreshold)
+	duplicates, err := u.Repository.FindDuplicates(ctx, *book, threshold, req.Limit, req.AuthUserData)
+	if err != nil {
+		l.Error("failed to find duplicates", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to find duplicates", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, duplicates)
+}
+
+func (u *UseCase) Merge(ctx context.Context, req *schema.RequestBookMerge) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "Merge"))
+
+	if utils.AnyInSlice(req.DuplicateIDs, req.ID) {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "A book cannot be merged into itself", nil)
+	}
+
+	survivor := u.Repository.Get(ctx, req.ID, nil)
+	if survivor == nil {
+		l.Error("book not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
+	}
+
+	duplicates, err := u.Repository.GetByIDs(ctx, req.DuplicateIDs)
+	if err != nil {
+		l.Error("failed to get duplicates", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to merge books", nil)
+	}
+	found := make([]string, len(duplicates))
+	for i, duplicate := range duplicates {
+		found[i] = duplicate.ID
+	}
+	missing := []string{}
+	for _, id := range req.DuplicateIDs {
+		if !utils.AnyInSlice(found, id) && !utils.AnyInSlice(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		l.Error("duplicates not found", zap.Strings("ids", missing))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", missing)
+	}
+
+	now := time.Now()
+	merges := make([]model.BookMerge, len(duplicates))
+	for i, duplicate := range duplicates {
+		merges[i] = model.BookMerge{
+			ID:           utils.GenerateUUID(),
+			SurvivorID:   survivor.ID,
+			MergedID:     duplicate.ID,
+			MergedTitle:  duplicate.Title,
+			MergedAuthor: duplicate.Author,
+			MergedBy:     req.AuthUserData.UserID,
+			MergedAt:     now,
+		}
+	}
+
+	// the duplicates are gone once merged, downstream sees them deleted with a pointer to the survivor
+	deleted := make([]schema.BookEvent, len(duplicates))
+	for i := range duplicates {
+		deleted[i] = schema.ToBookEvent(&duplicates[i], req.AuthUserData.UserID)
+		deleted[i].MergedInto = survivor.ID
+	}
+
+	merge := func(ctx context.Context) error { return u.Repository.Merge(ctx, merges) }
+	if err := u.withEvents(ctx, merge, schema.EventBookDeleted, deleted...); err != nil {
+		l.Error("failed to merge books", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to merge books", nil)
+	}
+
+	l.Info("books merged", zap.String("survivor", survivor.ID), zap.Strings("merged", found), zap.String("by", req.AuthUserData.UserID))
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ResponseBookMerge{
+		ID:     survivor.ID,
+		Merged: found,
+	})
+}
+
+func (u *UseCase) Transition(ctx context.Context, req *schema.RequestBookTransition) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "Transition"))
+
+	book := u.Repository.GetVisible(ctx, req.ID, nil, req.AuthUserData)
+	if book == nil {
+		l.Error("book not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
+	}
+
+	next, ok := schema.NextStatus(book.Status, req.Action)
+	if !ok {
+		message := fmt.Sprintf("Cannot %s a book in %s status", req.Action, book.Status)
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, message, nil)
+	}
+
+	if !schema.CanTransition(req.Action, req.AuthUserData, book.CreatedBy == req.AuthUserData.UserID) {
+		l.Warn("transition not allowed", zap.String("id", book.ID), zap.String("action", req.Action), zap.String("user", req.AuthUserData.UserID))
+		return wrapper.ResponseFailed(http.StatusForbidden, contract.StatusCodeForbidden, contract.ErrorInsufficientPrivilege, nil)
+	}
+
+	if req.PublishAt != nil && req.Action != schema.ActionPublish {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "publishAt is only allowed when publishing", nil)
+	}
+
+	// a future publication time schedules the book, it stays hidden until then
+	now := time.Now()
+	if req.Action == schema.ActionPublish {
+		book.PublishedAt = utils.ToPointer(now)
+		if req.PublishAt != nil && req.PublishAt.After(now) {
+			book.PublishedAt = req.PublishAt
+		}
+	}
+
+	transition := &model.BookTransition{
+		ID:         utils.GenerateUUID(),
+		BookID:     book.ID,
+		Action:     req.Action,
+		FromStatus: book.Status,
+		ToStatus:   next,
+		Note:       req.Note,
+		ActorID:    req.AuthUserData.UserID,
+		ActorRole:  req.AuthUserData.Role,
+		CreatedAt:  now,
+	}
+
+	book.Status = next
+	book.UpdatedAt = now
+	book.UpdatedBy = req.AuthUserData.UserID
+
+	apply := func(ctx context.Context) error { return u.Repository.Transition(ctx, book, transition) }
+	if err := u.withEvents(ctx, apply, schema.EventBookUpdated, schema.ToBookEvent(book, req.AuthUserData.UserID)); err != nil {
+		l.Error("failed to transition a book", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to change book status", nil)
+	}
+
+	l.Debug("book transitioned", zap.String("id", book.ID), zap.String("from", transition.FromStatus), zap.String("to", next))
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ResponseBookTransition{
+		ID:          book.ID,
+		Status:      book.Status,
+		PublishedAt: book.PublishedAt,
+	})
+}
+
+func (u *UseCase) ListTransitions(ctx context.Context, req *schema.RequestBookTransitionList) wrapper.JSONResult {
+	l := u.Logger.With(zap.String("usecase", "ListTransitions"))
+
+	book := u.Repository.GetVisible(ctx, req.ID, []string{"id"}, req.AuthUserData)
+	if book == nil {
+		l.Error("book not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
+	}
+
+	transitions := u.Repository.ListTransitions(ctx, book.ID)
+	response := make([]schema.ResponseBookTransitionHistory, len(transitions))
+	for i, t := range transitions {
+		response[i] = schema.ResponseBookTransitionHistory{
+			Action:     t.Action,
+			FromStatus: t.FromStatus,
+			ToStatus:   t.ToStatus,
+			Note:       t.Note,
+			ActorID:    t.ActorID,
+			ActorRole:  t.ActorRole,
+			CreatedAt:  t.CreatedAt,
+		}
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, response)
+}
@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/internal/book/usecase"
+	repository "github.com/Alwanly/go-codebase/mocks/internal_/book/repository"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newUseCase(t *testing.T) (usecase.IUseCase, *repository.MockIRepository) {
+	repo := repository.NewMockIRepository(t)
+	return usecase.NewUseCase(usecase.UseCase{
+		Config:     &config.GlobalConfig{BookStatsCacheTTL: 300, BookCacheTTL: 300},
+		Logger:     zap.NewNop(),
+		Repository: repo,
+	}), repo
+}
+
+func day(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+func TestStats_AccumulatesBuckets(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetCachedStats(ctx, "books:stats:2026-10-01:2026-10-03:day:5").Return(nil)
+	repo.EXPECT().Count(ctx, day("2026-10-01")).Return(10, nil)
+	repo.EXPECT().CountByBucket(ctx, "day", day("2026-10-01"), day("2026-10-04")).Return([]schema.StatsBucket{
+		{Bucket: day("2026-10-01"), Count: 5},
+		{Bucket: day("2026-10-03"), Count: 3},
+	}, nil)
+	repo.EXPECT().TopAuthors(ctx, day("2026-10-01"), day("2026-10-04"), 5).Return([]schema.StatsCount{}, nil)
+	repo.EXPECT().TopCreators(ctx, day("2026-10-01"), day("2026-10-04"), 5).Return([]schema.StatsCount{}, nil)
+	repo.EXPECT().SetCachedStats(ctx, "books:stats:2026-10-01:2026-10-03:day:5", mock.Anything, 300*time.Second).Return(nil)
+
+	response := uc.Stats(ctx, &schema.RequestBookStats{From: "2026-10-01", To: "2026-10-03", Bucket: "day", Limit: 5})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	stats := response.Data.(*schema.ResponseBookStats)
+	assert.Equal(t, int64(18), stats.TotalBooks)
+	assert.Equal(t, int64(8), stats.Created)
+	assert.Equal(t, int64(15), stats.Trend[0].Cumulative)
+	assert.Equal(t, 50.0, stats.Trend[0].Growth)
+	assert.Equal(t, int64(18), stats.Trend[1].Cumulative)
+	assert.Equal(t, 20.0, stats.Trend[1].Growth)
+}
+
+func TestStats_EmptyCatalogHasNoGrowth(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetCachedStats(ctx, mock.Anything).Return(nil)
+	repo.EXPECT().Count(ctx, mock.Anything).Return(0, nil)
+	repo.EXPECT().CountByBucket(ctx, "week", mock.Anything, mock.Anything).Return([]schema.StatsBucket{
+		{Bucket: day("2026-09-28"), Count: 4},
+	}, nil)
+	repo.EXPECT().TopAuthors(ctx, mock.Anything, mock.Anything, 3).Return([]schema.StatsCount{}, nil)
+	repo.EXPECT().TopCreators(ctx, mock.Anything, mock.Anything, 3).Return([]schema.StatsCount{}, nil)
+	repo.EXPECT().SetCachedStats(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	response := uc.Stats(ctx, &schema.RequestBookStats{From: "2026-09-28", To: "2026-10-04", Bucket: "week", Limit: 3})
+
+	stats := response.Data.(*schema.ResponseBookStats)
+	assert.Equal(t, 0.0, stats.Trend[0].Growth)
+	assert.Equal(t, int64(4), stats.Trend[0].Cumulative)
+	assert.Equal(t, int64(4), stats.TotalBooks)
+}
+
+func TestStats_ServedFromCache(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	cached := &schema.ResponseBookStats{TotalBooks: 42}
+	repo.EXPECT().GetCachedStats(ctx, "books:stats:2026-10-01:2026-10-03:month:5").Return(cached)
+
+	response := uc.Stats(ctx, &schema.RequestBookStats{From: "2026-10-01", To: "2026-10-03", Bucket: "month", Limit: 5})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Same(t, cached, response.Data)
+}
+
+func TestStats_RejectsReversedRange(t *testing.T) {
+	uc, _ := newUseCase(t)
+
+	response := uc.Stats(context.Background(), &schema.RequestBookStats{From: "2026-10-05", To: "2026-10-01", Bucket: "day", Limit: 5})
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestUpdate_RejectsMemberOnAnotherBook(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetVisible(ctx, "book-1", []string(nil), user).Return(&model.Book{ID: "book-1", CreatedBy: "member-2"})
+
+	response := uc.Update(ctx, &schema.RequestBookUpdate{ID: "book-1", Title: "Title", Author: "Author", AuthUserData: user})
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestUpdate_HiddenBookIsNotFound(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetVisible(ctx, "book-1", []string(nil), user).Return(nil)
+
+	response := uc.Update(ctx, &schema.RequestBookUpdate{ID: "book-1", Title: "Title", Author: "Author", AuthUserData: user})
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestDelete_RejectsMemberOnAnotherBook(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetVisible(ctx, "book-1", []string(nil), user).Return(&model.Book{ID: "book-1", CreatedBy: "member-2"})
+
+	response := uc.Delete(ctx, &schema.RequestBookDelete{ID: "book-1", AuthUserData: user})
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func bookIDs(response *schema.ResponseBookBatch) []string {
+	ids := []string{}
+	for _, book := range response.Books {
+		ids = append(ids, book.ID)
+	}
+	return ids
+}
+
+func TestBatch_KeepsOrderAndDropsDuplicates(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+	versions := map[string]string{"b": "", "a": "", "c": ""}
+
+	repo.EXPECT().GetCachedBooks(ctx, []string{"b", "a", "c"}).Return(map[string]model.Book{}, versions)
+	repo.EXPECT().GetByIDs(ctx, []string{"b", "a", "c"}).Return([]model.Book{
+		{ID: "a", Status: schema.StatusPublished},
+		{ID: "b", Status: schema.StatusPublished},
+		{ID: "c", Status: schema.StatusPublished},
+	}, nil)
+	repo.EXPECT().SetCachedBooks(ctx, mock.Anything, versions, 300*time.Second).Return(nil)
+
+	response := uc.Batch(ctx, &schema.RequestBookBatch{IDs: []string{"b", "a", "b", "c", "a"}, AuthUserData: user})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	batch := response.Data.(schema.ResponseBookBatch)
+	assert.Equal(t, []string{"b", "a", "c"}, bookIDs(&batch))
+	assert.Empty(t, batch.Missing)
+}
+
+func TestBatch_ListsMissingAndHiddenBooks(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetCachedBooks(ctx, []string{"a", "gone", "draft"}).Return(map[string]model.Book{}, map[string]string{})
+	repo.EXPECT().GetByIDs(ctx, []string{"a", "gone", "draft"}).Return([]model.Book{
+		{ID: "a", Status: schema.StatusPublished},
+		{ID: "draft", Status: schema.StatusDraft, CreatedBy: "member-2"},
+	}, nil)
+	repo.EXPECT().SetCachedBooks(ctx, mock.Anything, map[string]string{}, mock.Anything).Return(nil)
+
+	response := uc.Batch(ctx, &schema.RequestBookBatch{IDs: []string{"a", "gone", "draft"}, AuthUserData: user})
+
+	batch := response.Data.(schema.ResponseBookBatch)
+	assert.Equal(t, []string{"a"}, bookIDs(&batch))
+	assert.Equal(t, []string{"gone", "draft"}, batch.Missing)
+}
+
+func TestBatch_ReadsOnlyCacheMisses(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+	versions := map[string]string{"a": "v1", "b": "v2"}
+
+	repo.EXPECT().GetCachedBooks(ctx, []string{"a", "b"}).Return(map[string]model.Book{
+		"a": {ID: "a", Title: "Cached", Status: schema.StatusPublished},
+	}, versions)
+	repo.EXPECT().GetByIDs(ctx, []string{"b"}).Return([]model.Book{{ID: "b", Status: schema.StatusPublished}}, nil)
+	repo.EXPECT().SetCachedBooks(ctx, []model.Book{{ID: "b", Status: schema.StatusPublished}}, versions, mock.Anything).Return(nil)
+
+	response := uc.Batch(ctx, &schema.RequestBookBatch{IDs: []string{"a", "b"}, AuthUserData: user})
+
+	batch := response.Data.(schema.ResponseBookBatch)
+	assert.Equal(t, []string{"a", "b"}, bookIDs(&batch))
+	assert.Equal(t, "Cached", batch.Books[0].Title)
+}
+
+func TestBatch_AllCachedSkipsDatabase(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetCachedBooks(ctx, []string{"a"}).Return(map[string]model.Book{
+		"a": {ID: "a", Status: schema.StatusPublished},
+	}, map[string]string{"a": ""})
+
+	response := uc.Batch(ctx, &schema.RequestBookBatch{IDs: []string{"a"}, AuthUserData: user})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	batch := response.Data.(schema.ResponseBookBatch)
+	assert.Equal(t, []string{"a"}, bookIDs(&batch))
+}
+
+func TestBatch_FailsWhenBooksCannotBeRead(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	user := &middleware.AuthUserData{UserID: "member-1", Roles: []string{middleware.RoleMember}}
+
+	repo.EXPECT().GetCachedBooks(ctx, []string{"a"}).Return(map[string]model.Book{}, map[string]string{})
+	repo.EXPECT().GetByIDs(ctx, []string{"a"}).Return(nil, errors.New("connection refused"))
+
+	response := uc.Batch(ctx, &schema.RequestBookBatch{IDs: []string{"a"}, AuthUserData: user})
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestGet_RejectsUnknownField(t *testing.T) {
+	uc, _ := newUseCase(t)
+
+	response := uc.Get(context.Background(), &schema.RequestBookGet{
+		ID:         "a",
+		Projection: schema.Projection{Fields: "title,password"},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestGet_AcceptsEveryBookField(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	fields := make([]string, 0, len(schema.BookFields))
+	for field := range schema.BookFields {
+		fields = append(fields, field)
+	}
+	req := &schema.RequestBookGet{ID: "a", Projection: schema.Projection{Fields: strings.Join(fields, ",")}}
+	repo.EXPECT().GetVisible(ctx, "a", mock.Anything, (*middleware.AuthUserData)(nil)).Return(&model.Book{ID: "a"})
+
+	response := uc.Get(ctx, req)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+}
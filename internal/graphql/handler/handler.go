@@ -0,0 +1,101 @@
+package handler
+
+import (
+	book_repository "github.com/Alwanly/go-codebase/internal/book/repository"
+	book_usecase "github.com/Alwanly/go-codebase/internal/book/usecase"
+	"github.com/Alwanly/go-codebase/internal/graphql/schema"
+	"github.com/Alwanly/go-codebase/internal/graphql/usecase"
+	user_repository "github.com/Alwanly/go-codebase/internal/user/repository"
+	user_usecase "github.com/Alwanly/go-codebase/internal/user/usecase"
+	"github.com/Alwanly/go-codebase/pkg/binding"
+	"github.com/Alwanly/go-codebase/pkg/deps"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.GraphQL.Handler"
+
+type (
+	Handler struct {
+		Logger    *zap.Logger
+		Validator validator.IValidatorService
+		UseCase   usecase.IUseCase
+	}
+)
+
+// NewHandler registers the GraphQL route, it fails when the GraphQL schema cannot be built.
+func NewHandler(d *deps.App) (*Handler, error) {
+	bookUseCase := book_usecase.NewUseCase(book_usecase.UseCase{
+		Config: d.Config,
+		Logger: d.Logger,
+		Repository: book_repository.NewRepository(book_repository.Repository{
+			DB:    d.DB,
+			Redis: d.Redis,
+		}),
+		Outbox: d.Outbox,
+	})
+	userUseCase := user_usecase.NewUseCase(user_usecase.UseCase{
+		Config:     d.Config,
+		Logger:     d.Logger,
+		Jwt:        d.Auth.Jwt,
+		Revocation: d.Auth.Revocation,
+		Repository: user_repository.NewRepository(user_repository.Repository{
+			DB:    d.DB,
+			Redis: d.Redis,
+		}),
+	})
+	usecase, err := usecase.NewUseCase(usecase.UseCase{
+		Config:    d.Config,
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		Book:      bookUseCase,
+		User:      userUseCase,
+	})
+	if err != nil {
+		return nil, err
+	}
+	handler := &Handler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	}
+
+	e := d.Fiber.Group("/graphql", d.Auth.JwtAuth())
+	e.Post("/", handler.Query)
+
+	// the playground only serves a page, the queries it sends still go through JwtAuth
+	if d.Config.Environment == "development" {
+		d.Fiber.Get("/graphiql", handler.Playground)
+	}
+	return handler, nil
+}
+
+// Query runs a GraphQL query.
+func (h *Handler) Query(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Query")
+
+	// bind model
+	model := &schema.RequestGraphQL{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// run query
+	response := h.UseCase.Execute(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Playground serves GraphiQL, it asks for the bearer token as the endpoint requires one.
+func (h *Handler) Playground(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(playgroundPage)
+}
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/graphql-go/graphql"
+)
+
+// ListFields maps the fields returning a list to the page size assumed by the complexity limit
+// when the query does not set one.
+var ListFields = map[string]int{
+	"books": DefaultPageSize,
+}
+
+const (
+	DefaultPageSize  = 10
+	DefaultSortBy    = "title"
+	DefaultSortOrder = "asc"
+
+	// UserBatchSize is the most users loaded in a single batch.
+	UserBatchSize = 100
+)
+
+type RequestGraphQL struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseGraphQL is the result of an operation as laid out by the GraphQL spec, with the HTTP
+// status it is sent with.
+type ResponseGraphQL struct {
+	Code int `json:"-"`
+	*graphql.Result
+}
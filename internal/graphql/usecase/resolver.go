@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	book_schema "github.com/Alwanly/go-codebase/internal/book/schema"
+	"github.com/Alwanly/go-codebase/internal/graphql/schema"
+	user_schema "github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/pkg/dataloader"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"github.com/graphql-go/graphql"
+)
+
+type (
+	requestKey struct{}
+
+	// request holds what the resolvers of a single request share.
+	request struct {
+		user  *middleware.AuthUserData
+		users *dataloader.Loader[string, user_schema.ResponseUser]
+	}
+)
+
+func fromContext(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// newSchema builds the schema, every resolver goes through the book and user use cases.
+func (u *UseCase) newSchema() (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"author":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"publishedAt": &graphql.Field{Type: graphql.DateTime},
+			"createdAt":   &graphql.Field{Type: graphql.DateTime},
+			"updatedAt":   &graphql.Field{Type: graphql.DateTime},
+			"creator":     &graphql.Field{Type: userType, Resolve: u.resolveCreator},
+		},
+	})
+
+	bookPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BookPage",
+		Fields: graphql.Fields{
+			"items":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType)))},
+			"page":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalData": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: u.resolveBook,
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(bookPageType),
+				Args: graphql.FieldConfigArgument{
+					"page":      &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"pageSize":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: schema.DefaultPageSize},
+					"sortBy":    &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: schema.DefaultSortBy},
+					"sortOrder": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: schema.DefaultSortOrder},
+					"status":    &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: u.resolveBooks,
+			},
+			"me": &graphql.Field{
+				Type:    userType,
+				Resolve: u.resolveMe,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+func (u *UseCase) resolveBook(p graphql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(string)
+	response := u.Book.Get(p.Context, &book_schema.RequestBookGet{
+		ID:           id,
+		AuthUserData: fromContext(p.Context).user,
+	})
+	if response.Code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := resultError(response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+func (u *UseCase) resolveBooks(p graphql.ResolveParams) (interface{}, error) {
+	req := &book_schema.RequestBookList{
+		AuthUserData: fromContext(p.Context).user,
+	}
+	req.Page, _ = p.Args["page"].(int)
+	req.PageSize, _ = p.Args["pageSize"].(int)
+	req.SortBy, _ = p.Args["sortBy"].(string)
+	req.SortOrder, _ = p.Args["sortOrder"].(string)
+	req.Status, _ = p.Args["status"].(string)
+
+	// the arguments get the same checks as the query of the REST endpoint
+	if err := u.Validator.ValidateStruct(req); err != nil {
+		messages := []string{}
+		for _, e := range u.Validator.TranslateError(err) {
+			messages = append(messages, e.Message)
+		}
+		return nil, errors.New(strings.Join(messages, ", "))
+	}
+
+	response := u.Book.List(p.Context, req)
+	if err := resultError(response); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"items":     response.Data,
+		"page":      response.Meta.Page,
+		"totalData": response.Meta.TotalData,
+		"totalPage": response.Meta.TotalPage,
+	}, nil
+}
+
+func (u *UseCase) resolveMe(p graphql.ResolveParams) (interface{}, error) {
+	r := fromContext(p.Context)
+	if r.user == nil {
+		return nil, nil
+	}
+	return loadUser(p.Context, r, r.user.UserID), nil
+}
+
+// resolveCreator returns a thunk, graphql-go calls it once every book of the level is resolved so
+// the creators of a whole page are loaded in one batch.
+func (u *UseCase) resolveCreator(p graphql.ResolveParams) (interface{}, error) {
+	book, ok := p.Source.(book_schema.ResponseBookGet)
+	if !ok || book.CreatedBy == "" {
+		return nil, nil
+	}
+	r := fromContext(p.Context)
+	return loadUser(p.Context, r, book.CreatedBy), nil
+}
+
+func loadUser(ctx context.Context, r *request, id string) func() (interface{}, error) {
+	thunk := r.users.Load(ctx, id)
+	return func() (interface{}, error) {
+		user, found, err := thunk()
+		if err != nil || !found {
+			return nil, err
+		}
+		return user, nil
+	}
+}
+
+// resultError turns a failed use case result into an error of the field.
+func resultError(response wrapper.JSONResult) error {
+	if response.Code >= http.StatusBadRequest {
+		return errors.New(response.Message)
+	}
+	return nil
+}
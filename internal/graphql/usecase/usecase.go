@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Alwanly/go-codebase/config"
+	book_usecase "github.com/Alwanly/go-codebase/internal/book/usecase"
+	"github.com/Alwanly/go-codebase/internal/graphql/schema"
+	user_schema "github.com/Alwanly/go-codebase/internal/user/schema"
+	user_usecase "github.com/Alwanly/go-codebase/internal/user/usecase"
+	"github.com/Alwanly/go-codebase/pkg/dataloader"
+	"github.com/Alwanly/go-codebase/pkg/gqllimit"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.GraphQL.Usecase"
+
+type (
+	UseCase struct {
+		Config    *config.GlobalConfig
+		Logger    *zap.Logger
+		Validator validator.IValidatorService
+		Book      book_usecase.IUseCase
+		User      user_usecase.IUseCase
+
+		schema graphql.Schema
+	}
+
+	IUseCase interface {
+		Execute(context.Context, *schema.RequestGraphQL) schema.ResponseGraphQL
+	}
+)
+
+// NewUseCase creates the use case and its GraphQL schema, it fails when the schema cannot be built.
+func NewUseCase(uc UseCase) (IUseCase, error) {
+	u := &UseCase{
+		Config:    uc.Config,
+		Logger:    uc.Logger,
+		Validator: uc.Validator,
+		Book:      uc.Book,
+		User:      uc.User,
+	}
+
+	s, err := u.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	u.schema = s
+	return u, nil
+}
+
+// Execute parses, validates and checks the query against the depth and complexity limits before
+// running it. Errors found before running are answered with a bad request, errors of the resolvers
+// are part of a successful response as the GraphQL spec lays out.
+func (u *UseCase) Execute(ctx context.Context, req *schema.RequestGraphQL) schema.ResponseGraphQL {
+	l := logger.WithID(u.Logger, ContextName, "Execute")
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		l.Debug("failed to parse query", zap.Error(err))
+		return failed(err)
+	}
+
+	validation := graphql.ValidateDocument(&u.schema, doc, nil)
+	if !validation.IsValid {
+		l.Debug("invalid query", zap.Any("errors", validation.Errors))
+		return schema.ResponseGraphQL{
+			Code:   http.StatusBadRequest,
+			Result: &graphql.Result{Errors: validation.Errors},
+		}
+	}
+
+	err = gqllimit.Check(doc, req.OperationName, req.Variables, gqllimit.Opts{
+		MaxDepth:      u.Config.GraphQLMaxDepth,
+		MaxComplexity: u.Config.GraphQLMaxComplexity,
+		ListFields:    schema.ListFields,
+	})
+	if err != nil {
+		l.Info("query rejected", zap.Error(err))
+		return failed(err)
+	}
+
+	// the loader lives as long as the request, so users are only cached for it
+	ctx = context.WithValue(ctx, requestKey{}, &request{
+		user:  req.AuthUserData,
+		users: dataloader.New(u.loadUsers, schema.UserBatchSize),
+	})
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        u.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	if result.HasErrors() {
+		l.Debug("query resolved with errors", zap.Any("errors", result.Errors))
+	}
+	return schema.ResponseGraphQL{Code: http.StatusOK, Result: result}
+}
+
+// loadUsers is the batch function of the user loader.
+func (u *UseCase) loadUsers(ctx context.Context, ids []string) (map[string]user_schema.ResponseUser, error) {
+	response := u.User.GetUsers(ctx, &user_schema.RequestUserBatch{
+		IDs:          ids,
+		AuthUserData: fromContext(ctx).user,
+	})
+	if response.Code != http.StatusOK {
+		return nil, errors.New(response.Message)
+	}
+
+	users := map[string]user_schema.ResponseUser{}
+	for _, user := range response.Data.([]user_schema.ResponseUser) {
+		users[user.ID] = user
+	}
+	return users, nil
+}
+
+func failed(err error) schema.ResponseGraphQL {
+	return schema.ResponseGraphQL{
+		Code:   http.StatusBadRequest,
+		Result: &graphql.Result{Errors: gqlerrors.FormatErrors(err)},
+	}
+}
@@ -0,0 +1,17 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/graphql/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewUseCase_BuildsSchema(t *testing.T) {
+	uc, err := usecase.NewUseCase(usecase.UseCase{Config: &config.GlobalConfig{}, Logger: zap.NewNop()})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, uc)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/progress/repository"
+	"github.com/Alwanly/go-codebase/internal/progress/schema"
+	"github.com/Alwanly/go-codebase/internal/progress/usecase"
+	"github.com/Alwanly/go-codebase/pkg/binding"
+	"github.com/Alwanly/go-codebase/pkg/deps"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.Progress.Handler"
+
+type (
+	Handler struct {
+		Logger    *zap.Logger
+		Validator validator.IValidatorService
+		UseCase   usecase.IUseCase
+	}
+)
+
+func NewHandler(d *deps.App) *Handler {
+	repository := repository.NewRepository(repository.Repository{
+		DB:    d.DB,
+		Redis: d.Redis,
+	})
+	usecase := usecase.NewUseCase(usecase.UseCase{
+		Config:     d.Config,
+		Logger:     d.Logger,
+		Repository: repository,
+	})
+	handler := &Handler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	}
+
+	e := d.Fiber.Group("/progress/v1", d.Auth.JwtAuth())
+	e.Get("/", handler.List)
+	e.Get("/reading", handler.Reading)
+	e.Get("/finished", handler.Finished)
+	e.Get("/stats", handler.Stats)
+	e.Get("/books/:bookId", handler.Get)
+	e.Put("/books/:bookId", handler.Update)
+	return handler
+}
+
+// Update records the progress of the current user on a book.
+func (h *Handler) Update(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Update")
+
+	// bind model
+	model := &schema.RequestProgressUpdate{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// update progress
+	response := h.UseCase.Update(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Get returns the progress of the current user on a book.
+func (h *Handler) Get(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Get")
+
+	// bind model
+	model := &schema.RequestProgressGet{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get progress
+	response := h.UseCase.Get(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// List returns the progress of the current user, optionally filtered by status.
+func (h *Handler) List(c *fiber.Ctx) error {
+	return h.list(c, "List", &schema.RequestProgressList{
+		Page:     1,
+		PageSize: 10,
+	})
+}
+
+// Reading returns the books the current user is reading.
+func (h *Handler) Reading(c *fiber.Ctx) error {
+	return h.list(c, "Reading", &schema.RequestProgressList{
+		Page:     1,
+		PageSize: 10,
+		Status:   schema.StatusReading,
+	})
+}
+
+// Finished returns the books the current user finished, in the current year unless ?year= is given.
+func (h *Handler) Finished(c *fiber.Ctx) error {
+	return h.list(c, "Finished", &schema.RequestProgressList{
+		Page:     1,
+		PageSize: 10,
+		Status:   schema.StatusFinished,
+		Year:     time.Now().UTC().Year(),
+	})
+}
+
+func (h *Handler) list(c *fiber.Ctx, name string, model *schema.RequestProgressList) error {
+	l := logger.WithID(h.Logger, ContextName, name)
+
+	// bind model, the status set by the caller wins over the query
+	status := model.Status
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+	if status != "" {
+		model.Status = status
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get list of progress
+	response := h.UseCase.List(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Stats returns the reading stats of the current user for a year.
+func (h *Handler) Stats(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Stats")
+
+	// bind model
+	model := &schema.RequestProgressStats{
+		Year: time.Now().UTC().Year(),
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get reading stats
+	response := h.UseCase.Stats(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
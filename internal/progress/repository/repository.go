@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	book_repository "github.com/Alwanly/go-codebase/internal/book/repository"
+	"github.com/Alwanly/go-codebase/internal/progress/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const ContextName = "Internal.Progress.Repository"
+
+type (
+	Repository struct {
+		DB    database.IDBService
+		Redis redis.IRedisService
+	}
+
+	IRepository interface {
+		BookExists(ctx context.Context, bookID string, user *middleware.AuthUserData) bool
+		Get(ctx context.Context, userID string, bookID string) *model.ReadingProgress
+		Save(ctx context.Context, progress *model.ReadingProgress) error
+		List(ctx context.Context, userID string, req schema.RequestProgressList) ([]schema.ResponseProgress, int64)
+		Stats(ctx context.Context, userID string, from, to time.Time) (*schema.ResponseProgressStats, error)
+	}
+)
+
+func NewRepository(r Repository) IRepository {
+	return &Repository{
+		DB:    r.DB,
+		Redis: r.Redis,
+	}
+}
+
+// BookExists reports whether the book exists and the user may see it.
+func (r *Repository) BookExists(ctx context.Context, bookID string, user *middleware.AuthUserData) bool {
+	var count int64
+	r.DB.GetTransaction(ctx).Model(&model.Book{}).Scopes(book_repository.VisibleTo(user)).Where("id = ?", bookID).Count(&count)
+	return count > 0
+}
+
+func (r *Repository) Get(ctx context.Context, userID string, bookID string) *model.ReadingProgress {
+	var progress model.ReadingProgress
+	err := r.DB.GetTransaction(ctx).Where("user_id = ? AND book_id = ?", userID, bookID).First(&progress).Error
+	if err != nil {
+		return nil
+	}
+	return &progress
+}
+
+func (r *Repository) Save(ctx context.Context, progress *model.ReadingProgress) error {
+	return r.DB.GetTransaction(ctx).Save(progress).Error
+}
+
+func (r *Repository) List(ctx context.Context, userID string, req schema.RequestProgressList) ([]schema.ResponseProgress, int64) {
+	var progresses []schema.ResponseProgress
+	var total int64
+
+	tx := r.DB.GetTransaction(ctx).
+		Table("reading_progress").
+		Joins("JOIN books ON books.id = reading_progress.book_id").
+		Where("reading_progress.user_id = ?", userID)
+	if req.Status != "" {
+		tx = tx.Where("reading_progress.status = ?", req.Status)
+	}
+	if req.Year > 0 {
+		from := time.Date(req.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		tx = tx.Where("reading_progress.finished_at >= ? AND reading_progress.finished_at < ?", from, from.AddDate(1, 0, 0))
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	tx.Count(&total)
+
+	offset := utils.CalculatePageSkip(req.Page, req.PageSize)
+	tx.Select("reading_progress.*, books.title, books.author").
+		Offset(offset).
+		Limit(req.PageSize).
+		Order("reading_progress.updated_at DESC").
+		Scan(&progresses)
+
+	return progresses, total
+}
+
+func (r *Repository) Stats(ctx context.Context, userID string, from, to time.Time) (*schema.ResponseProgressStats, error) {
+	var stats schema.ResponseProgressStats
+	err := r.DB.GetTransaction(ctx).Model(&model.ReadingProgress{}).
+		Select(`
+			count(*) FILTER (WHERE started_at >= @from AND started_at < @to) AS books_started,
+			count(*) FILTER (WHERE status = @finished AND finished_at >= @from AND finished_at < @to) AS books_finished,
+			count(*) FILTER (WHERE status = @abandoned AND updated_at >= @from AND updated_at < @to) AS books_abandoned,
+			coalesce(sum(greatest(total_pages, current_page)) FILTER (
+				WHERE status = @finished AND finished_at >= @from AND finished_at < @to), 0) AS pages_read,
+			coalesce(avg(extract(epoch FROM finished_at - started_at) / 86400) FILTER (
+				WHERE status = @finished AND finished_at >= @from AND finished_at < @to AND started_at IS NOT NULL), 0) AS average_days_to_finish,
+			count(*) FILTER (WHERE status = @reading) AS currently_reading`,
+			map[string]interface{}{
+				"from":      from,
+				"to":        to,
+				"reading":   schema.StatusReading,
+				"finished":  schema.StatusFinished,
+				"abandoned": schema.StatusAbandoned,
+			}).
+		Where("user_id = ?", userID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+)
+
+const (
+	StatusWantToRead = "want_to_read"
+	StatusReading    = "reading"
+	StatusFinished   = "finished"
+	StatusAbandoned  = "abandoned"
+)
+
+type RequestProgressUpdate struct {
+	BookID      string     `params:"bookId" validate:"required"`
+	Status      string     `json:"status" validate:"required,oneof=want_to_read reading finished abandoned"`
+	CurrentPage *int       `json:"currentPage" validate:"omitempty,min=0"`
+	TotalPages  *int       `json:"totalPages" validate:"omitempty,min=1"`
+	Percent     *float64   `json:"percent" validate:"omitempty,min=0,max=100"`
+	StartedAt   *time.Time `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestProgressGet struct {
+	BookID string `params:"bookId" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseProgress struct {
+	BookID      string     `json:"bookId"`
+	Title       string     `json:"title,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	Status      string     `json:"status"`
+	CurrentPage int        `json:"currentPage"`
+	TotalPages  int        `json:"totalPages"`
+	Percent     float64    `json:"percent"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type RequestProgressList struct {
+	Page     int    `query:"page" validate:"required,min=1"`
+	PageSize int    `query:"page_size" validate:"required,min=1,max=100"`
+	Status   string `query:"status" validate:"omitempty,oneof=want_to_read reading finished abandoned"`
+	// Year limits finished books to those finished in the year.
+	Year int `query:"year" validate:"omitempty,min=1900,max=9999"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestProgressStats struct {
+	Year int `query:"year" validate:"required,min=1900,max=9999"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseProgressStats struct {
+	Year                int     `json:"year"`
+	BooksStarted        int64   `json:"booksStarted"`
+	BooksFinished       int64   `json:"booksFinished"`
+	BooksAbandoned      int64   `json:"booksAbandoned"`
+	PagesRead           int64   `json:"pagesRead"`
+	AverageDaysToFinish float64 `json:"averageDaysToFinish"`
+	CurrentlyReading    int64   `json:"currentlyReading"`
+}
+
+// ToResponse maps a progress to its response without book details.
+func ToResponse(progress *model.ReadingProgress) ResponseProgress {
+	return ResponseProgress{
+		BookID:      progress.BookID,
+		Status:      progress.Status,
+		CurrentPage: progress.CurrentPage,
+		TotalPages:  progress.TotalPages,
+		Percent:     progress.Percent,
+		StartedAt:   progress.StartedAt,
+		FinishedAt:  progress.FinishedAt,
+		UpdatedAt:   progress.UpdatedAt,
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/progress/repository"
+	"github.com/Alwanly/go-codebase/internal/progress/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.Progress.Usecase"
+
+type (
+	UseCase struct {
+		Config     *config.GlobalConfig
+		Logger     *zap.Logger
+		Repository repository.IRepository
+	}
+
+	IUseCase interface {
+		Update(context.Context, *schema.RequestProgressUpdate) wrapper.JSONResult
+		Get(context.Context, *schema.RequestProgressGet) wrapper.JSONResult
+		List(context.Context, *schema.RequestProgressList) wrapper.JSONResult
+		Stats(context.Context, *schema.RequestProgressStats) wrapper.JSONResult
+	}
+)
+
+func NewUseCase(uc UseCase) IUseCase {
+	return &UseCase{
+		Config:     uc.Config,
+		Logger:     uc.Logger,
+		Repository: uc.Repository,
+	}
+}
+
+func (u *UseCase) Update(ctx context.Context, req *schema.RequestProgressUpdate) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Update")
+
+	if !u.Repository.BookExists(ctx, req.BookID, req.AuthUserData) {
+		l.Error("book not found", zap.String("bookId", req.BookID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil)
+	}
+
+	now := time.Now()
+	progress := u.Repository.Get(ctx, req.AuthUserData.UserID, req.BookID)
+	if progress == nil {
+		progress = &model.ReadingProgress{
+			ID:        utils.GenerateUUID(),
+			UserID:    req.AuthUserData.UserID,
+			BookID:    req.BookID,
+			CreatedAt: now,
+		}
+	}
+
+	progress.Status = req.Status
+	progress.UpdatedAt = now
+	if req.TotalPages != nil {
+		progress.TotalPages = *req.TotalPages
+	}
+	if req.StartedAt != nil {
+		progress.StartedAt = req.StartedAt
+	}
+	if req.FinishedAt != nil {
+		progress.FinishedAt = req.FinishedAt
+	}
+
+	// position is given either as a page or as a percentage, derive one from the other when possible
+	switch {
+	case req.CurrentPage != nil:
+		progress.CurrentPage = *req.CurrentPage
+		if progress.TotalPages > 0 {
+			progress.Percent = math.Round(float64(progress.CurrentPage)/float64(progress.TotalPages)*10000) / 100
+		}
+	case req.Percent != nil:
+		progress.Percent = *req.Percent
+		if progress.TotalPages > 0 {
+			progress.CurrentPage = int(math.Round(progress.Percent * float64(progress.TotalPages) / 100))
+		}
+	}
+
+	if progress.TotalPages > 0 && progress.CurrentPage > progress.TotalPages {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "currentPage must not exceed totalPages", nil)
+	}
+
+	// keep the dates consistent with the status
+	switch progress.Status {
+	case schema.StatusReading:
+		if progress.StartedAt == nil {
+			progress.StartedAt = utils.ToPointer(now)
+		}
+		progress.FinishedAt = nil
+	case schema.StatusFinished:
+		if progress.StartedAt == nil {
+			progress.StartedAt = utils.ToPointer(now)
+		}
+		if progress.FinishedAt == nil {
+			progress.FinishedAt = utils.ToPointer(now)
+		}
+		progress.CurrentPage = utils.IfThenElse(progress.TotalPages > 0, progress.TotalPages, progress.CurrentPage)
+		progress.Percent = 100
+	case schema.StatusWantToRead:
+		progress.StartedAt = nil
+		progress.FinishedAt = nil
+	}
+
+	if progress.StartedAt != nil && progress.FinishedAt != nil && progress.FinishedAt.Before(*progress.StartedAt) {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "finishedAt must not be before startedAt", nil)
+	}
+
+	if err := u.Repository.Save(ctx, progress); err != nil {
+		l.Error("failed to save progress", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to save progress", nil)
+	}
+
+	l.Debug("progress saved", zap.String("bookId", progress.BookID), zap.String("status", progress.Status))
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ToResponse(progress))
+}
+
+func (u *UseCase) Get(ctx context.Context, req *schema.RequestProgressGet) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Get")
+
+	progress := u.Repository.Get(ctx, req.AuthUserData.UserID, req.BookID)
+	if progress == nil {
+		l.Debug("progress not found", zap.String("bookId", req.BookID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Progress not found", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ToResponse(progress))
+}
+
+func (u *UseCase) List(ctx context.Context, req *schema.RequestProgressList) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "List")
+
+	progresses, total := u.Repository.List(ctx, req.AuthUserData.UserID, *req)
+
+	l.Debug("progress listed", zap.Int64("total", total))
+	return wrapper.ResponsePagination(req.Page, req.PageSize, len(progresses), int(total), progresses, nil)
+}
+
+func (u *UseCase) Stats(ctx context.Context, req *schema.RequestProgressStats) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Stats")
+
+	from := time.Date(req.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := u.Repository.Stats(ctx, req.AuthUserData.UserID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		l.Error("failed to get reading stats", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to get reading stats", nil)
+	}
+
+	stats.Year = req.Year
+	stats.AverageDaysToFinish = math.Round(stats.AverageDaysToFinish*10) / 10
+	return wrapper.ResponseSuccess(http.StatusOK, stats)
+}
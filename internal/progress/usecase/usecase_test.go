@@ -0,0 +1,170 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/progress/schema"
+	"github.com/Alwanly/go-codebase/internal/progress/usecase"
+	repository "github.com/Alwanly/go-codebase/mocks/internal_/progress/repository"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var reader = &middleware.AuthUserData{UserID: "user-1", Roles: []string{middleware.RoleMember}}
+
+func newUseCase(t *testing.T) (usecase.IUseCase, *repository.MockIRepository) {
+	repo := repository.NewMockIRepository(t)
+	return usecase.NewUseCase(usecase.UseCase{
+		Config:     &config.GlobalConfig{},
+		Logger:     zap.NewNop(),
+		Repository: repo,
+	}), repo
+}
+
+// saved captures the progress passed to Save.
+func saved(repo *repository.MockIRepository) *model.ReadingProgress {
+	progress := &model.ReadingProgress{}
+	repo.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, p *model.ReadingProgress) error {
+		*progress = *p
+		return nil
+	})
+	return progress
+}
+
+func TestUpdate_HiddenBookIsNotFound(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().BookExists(ctx, "book-1", reader).Return(false)
+
+	response := uc.Update(ctx, &schema.RequestProgressUpdate{BookID: "book-1", Status: schema.StatusReading, AuthUserData: reader})
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestUpdate_DerivesPercentFromPage(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().BookExists(ctx, "book-1", reader).Return(true)
+	repo.EXPECT().Get(ctx, reader.UserID, "book-1").Return(nil)
+	progress := saved(repo)
+
+	response := uc.Update(ctx, &schema.RequestProgressUpdate{
+		BookID:       "book-1",
+		Status:       schema.StatusReading,
+		CurrentPage:  utils.ToPointer(50),
+		TotalPages:   utils.ToPointer(300),
+		AuthUserData: reader,
+	})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, 16.67, progress.Percent)
+	assert.NotNil(t, progress.StartedAt)
+	assert.Nil(t, progress.FinishedAt)
+}
+
+func TestUpdate_DerivesPageFromPercent(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().BookExists(ctx, "book-1", reader).Return(true)
+	repo.EXPECT().Get(ctx, reader.UserID, "book-1").Return(&model.ReadingProgress{ID: "progress-1", TotalPages: 200})
+	progress := saved(repo)
+
+	response := uc.Update(ctx, &schema.RequestProgressUpdate{BookID: "book-1", Status: schema.StatusReading, Percent: utils.ToPointer(25.0), AuthUserData: reader})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "progress-1", progress.ID)
+	assert.Equal(t, 50, progress.CurrentPage)
+}
+
+func TestUpdate_FinishingCompletesTheBook(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().BookExists(ctx, "book-1", reader).Return(true)
+	repo.EXPECT().Get(ctx, reader.UserID, "book-1").Return(&model.ReadingProgress{ID: "progress-1", TotalPages: 200, CurrentPage: 120})
+	progress := saved(repo)
+
+	response := uc.Update(ctx, &schema.RequestProgressUpdate{BookID: "book-1", Status: schema.StatusFinished, AuthUserData: reader})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, 200, progress.CurrentPage)
+	assert.Equal(t, 100.0, progress.Percent)
+	assert.NotNil(t, progress.StartedAt)
+	assert.NotNil(t, progress.FinishedAt)
+}
+
+func TestUpdate_WantToReadClearsDates(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+	started := time.Now().Add(-24 * time.Hour)
+
+	repo.EXPECT().BookExists(ctx, "book-1", reader).Return(true)
+	repo.EXPECT().Get(ctx, reader.UserID, "book-1").Return(&model.ReadingProgress{ID: "progress-1", StartedAt: &started, FinishedAt: &started})
+	progress := saved(repo)
+
+	response := uc.Update(ctx, &schema.RequestProgressUpdate{BookID: "book-1", Status: schema.StatusWantToRead, AuthUserData: reader})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Nil(t, progress.StartedAt)
+	assert.Nil(t, progress.FinishedAt)
+}
+
+func TestUpdate_RejectsInconsistentProgress(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  schema.RequestProgressUpdate
+	}{
+		{
+			name: "page beyond total",
+			req:  schema.RequestProgressUpdate{Status: schema.StatusReading, CurrentPage: utils.ToPointer(301), TotalPages: utils.ToPointer(300)},
+		},
+		{
+			name: "finished before started",
+			req:  schema.RequestProgressUpdate{Status: schema.StatusFinished, StartedAt: &now, FinishedAt: utils.ToPointer(now.Add(-time.Hour))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo := newUseCase(t)
+			ctx := context.Background()
+
+			repo.EXPECT().BookExists(ctx, "book-1", reader).Return(true)
+			repo.EXPECT().Get(ctx, reader.UserID, "book-1").Return(nil)
+
+			req := tt.req
+			req.BookID = "book-1"
+			req.AuthUserData = reader
+			response := uc.Update(ctx, &req)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func TestStats_QueriesTheYear(t *testing.T) {
+	uc, repo := newUseCase(t)
+	ctx := context.Background()
+
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().Stats(ctx, reader.UserID, from, from.AddDate(1, 0, 0)).Return(&schema.ResponseProgressStats{AverageDaysToFinish: 12.345}, nil)
+
+	response := uc.Stats(ctx, &schema.RequestProgressStats{Year: 2026, AuthUserData: reader})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	stats := response.Data.(*schema.ResponseProgressStats)
+	assert.Equal(t, 2026, stats.Year)
+	assert.Equal(t, 12.3, stats.AverageDaysToFinish)
+}
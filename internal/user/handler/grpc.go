@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	authv1 "github.com/Alwanly/go-codebase/api/proto/auth/v1"
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/internal/user/usecase"
+	"github.com/Alwanly/go-codebase/pkg/grpcserver"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// GrpcHandler serves AuthService. Login, VerifyTwoFactor and Refresh require basic auth, Register
+// basic auth or an API key with the user:register scope, GetProfile a bearer token.
+type GrpcHandler struct {
+	authv1.UnimplementedAuthServiceServer
+
+	Logger    *zap.Logger
+	Validator validator.IValidatorService
+	UseCase   usecase.IUseCase
+}
+
+// Login authenticates a user and returns a token.
+func (h *GrpcHandler) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.TokenResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcLogin")
+
+	// bind model
+	model := &schema.AuthLoginRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		model.IP = peerIP(p)
+	}
+	model.UserAgent = userAgent(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// authenticate user
+	response := h.UseCase.Auth(ctx, model)
+	if throttled, ok := response.Data.(schema.AuthLoginThrottledResponse); ok {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(throttled.RetryAfter)))
+	}
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	if challenge, ok := response.Data.(schema.AuthLoginChallengeResponse); ok {
+		return &authv1.TokenResponse{
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresIn: int32(challenge.ExpiresIn),
+		}, nil
+	}
+	data := response.Data.(schema.AuthLoginResponse)
+	return &authv1.TokenResponse{Token: data.Token, RefreshToken: data.RefreshToken}, nil
+}
+
+// VerifyTwoFactor exchanges the challenge token of a login and a TOTP or recovery code for a
+// token.
+func (h *GrpcHandler) VerifyTwoFactor(ctx context.Context, req *authv1.VerifyTwoFactorRequest) (*authv1.TokenResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcVerifyTwoFactor")
+
+	// bind model
+	model := &schema.TwoFactorVerifyRequest{
+		ChallengeToken: req.GetChallengeToken(),
+		Code:           req.GetCode(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		model.IP = peerIP(p)
+	}
+	model.UserAgent = userAgent(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// verify code
+	response := h.UseCase.VerifyTwoFactor(ctx, model)
+	if throttled, ok := response.Data.(schema.AuthLoginThrottledResponse); ok {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(throttled.RetryAfter)))
+	}
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.(schema.AuthLoginResponse)
+	return &authv1.TokenResponse{Token: data.Token, RefreshToken: data.RefreshToken}, nil
+}
+
+// Register creates a user and returns a token.
+func (h *GrpcHandler) Register(ctx context.Context, req *authv1.RegisterRequest) (*authv1.TokenResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcRegister")
+
+	// bind model
+	model := &schema.AuthRegisterRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Email:    req.GetEmail(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		model.IP = peerIP(p)
+	}
+	model.UserAgent = userAgent(ctx)
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// register user
+	response := h.UseCase.Register(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.(schema.AuthRegisterResponse)
+	return &authv1.TokenResponse{Token: data.Token, RefreshToken: data.RefreshToken}, nil
+}
+
+// Refresh rotates a refresh token and returns a new token.
+func (h *GrpcHandler) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.TokenResponse, error) {
+	l := logger.WithID(h.Logger, ContextName, "GrpcRefresh")
+
+	// bind model
+	model := &schema.AuthRefreshRequest{RefreshToken: req.GetRefreshToken()}
+
+	// validate model
+	if err := grpcserver.ValidateModel(l, h.Validator, model); err != nil {
+		return nil, err
+	}
+
+	// rotate refresh token
+	response := h.UseCase.Refresh(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.(schema.AuthRefreshResponse)
+	return &authv1.TokenResponse{Token: data.Token, RefreshToken: data.RefreshToken}, nil
+}
+
+// GetProfile returns the user of the token.
+func (h *GrpcHandler) GetProfile(ctx context.Context, _ *authv1.GetProfileRequest) (*authv1.Profile, error) {
+	// bind model
+	model := &schema.ProfileRequest{}
+	model.AuthUserData, _ = middleware.AuthUserFromContext(ctx)
+
+	// get profile
+	response := h.UseCase.Profile(ctx, model)
+	if err := grpcserver.Error(response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.(schema.ProfileResponse)
+	return &authv1.Profile{
+		UserId:        data.ID,
+		Role:          data.Role,
+		Username:      data.Username,
+		DisplayName:   data.DisplayName,
+		Email:         data.Email,
+		EmailVerified: data.EmailVerified,
+		Bio:           data.Bio,
+		AvatarUrl:     data.AvatarURL,
+	}, nil
+}
+
+// peerIP returns the IP of the peer without the port.
+func peerIP(p *peer.Peer) string {
+	if addr, ok := p.Addr.(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+	return p.Addr.String()
+}
+
+// userAgent returns the user agent of the client from the metadata.
+func userAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
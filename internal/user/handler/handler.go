@@ -1,12 +1,18 @@
 package handler
 
 import (
+	"strconv"
+	"time"
+
+	authv1 "github.com/Alwanly/go-codebase/api/proto/auth/v1"
 	"github.com/Alwanly/go-codebase/internal/user/repository"
 	"github.com/Alwanly/go-codebase/internal/user/schema"
 	"github.com/Alwanly/go-codebase/internal/user/usecase"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
 	"github.com/Alwanly/go-codebase/pkg/binding"
 	"github.com/Alwanly/go-codebase/pkg/deps"
 	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
 	"github.com/Alwanly/go-codebase/pkg/validator"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -14,11 +20,20 @@ import (
 
 const ContextName = "Internal.User.Handler"
 
+// oidcStateCookie holds the state of the OpenID Connect login started by the browser.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/auth/v1/oidc"
+)
+
 type (
 	Handler struct {
 		Logger    *zap.Logger
 		Validator validator.IValidatorService
 		UseCase   usecase.IUseCase
+
+		// Keys publish the public keys verifying the tokens
+		Keys authentication.IKeyManager
 	}
 )
 
@@ -31,29 +46,88 @@ func NewHandler(d *deps.App) *Handler {
 		Config:     d.Config,
 		Logger:     d.Logger,
 		Jwt:        d.Auth.Jwt,
+		Revocation: d.Auth.Revocation,
+		Notifier:   d.Notifier,
 		Repository: repository,
+		OIDC:       d.OIDC,
 	})
+	d.Workers = append(d.Workers, usecase.RunPasswordResets)
 	handler := &Handler{
 		Logger:    d.Logger,
 		Validator: d.Validator,
 		UseCase:   usecase,
+		Keys:      d.Auth.Keys,
+	}
+
+	if handler.Keys != nil {
+		d.Fiber.Get("/.well-known/jwks.json", handler.JWKS)
 	}
 
 	e := d.Fiber.Group("/auth/v1")
 	e.Post("/login", d.Auth.BasicAuth(), handler.Login)
-	e.Post("/register", d.Auth.BasicAuth(), handler.Register)
+	e.Post("/register", d.Auth.ClientAuth(middleware.ScopeUserRegister), handler.Register)
+	e.Post("/refresh", d.Auth.BasicAuth(), handler.Refresh)
+	e.Post("/logout", d.Auth.JwtAuth(), handler.Logout)
+	e.Get("/sessions", d.Auth.JwtAuth(), handler.ListSessions)
+	e.Delete("/sessions/:id", d.Auth.JwtAuth(), handler.RevokeSession)
+	e.Post("/sessions/revoke-others", d.Auth.JwtAuth(), handler.RevokeOtherSessions)
+	e.Post("/2fa/verify", d.Auth.BasicAuth(), handler.VerifyTwoFactor)
+	if d.OIDC != nil {
+		// browser redirects, the state cookie ties the callback to the browser that started it
+		e.Get("/oidc/login", handler.OIDCLogin)
+		e.Get("/oidc/callback", handler.OIDCCallback)
+	}
+	e.Post("/2fa/enroll", d.Auth.JwtAuth(), handler.EnrollTwoFactor)
+	e.Post("/2fa/confirm", d.Auth.JwtAuth(), handler.ConfirmTwoFactor)
+	e.Post("/2fa/disable", d.Auth.JwtAuth(), handler.DisableTwoFactor)
+	e.Post("/2fa/recovery-codes", d.Auth.JwtAuth(), handler.RegenerateRecoveryCodes)
 	e.Get("/profile", d.Auth.JwtAuth(), handler.Profile)
+	e.Put("/profile", d.Auth.JwtAuth(), handler.UpdateProfile)
+	e.Patch("/profile", d.Auth.JwtAuth(), handler.UpdateProfile)
+	e.Post("/password/change", d.Auth.JwtAuth(), handler.ChangePassword)
+	e.Post("/password/forgot", d.Auth.BasicAuth(), handler.ForgotPassword)
+	e.Post("/password/reset", d.Auth.BasicAuth(), handler.ResetPassword)
+	e.Post("/email/verify", d.Auth.BasicAuth(), handler.VerifyEmail)
+	e.Post("/email/resend", d.Auth.JwtAuth(), handler.ResendVerification)
+	e.Post("/users/:id/revoke", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionUserManage), handler.RevokeUserTokens)
+	e.Post("/users/:id/unlock", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionUserManage), handler.UnlockUser)
+	e.Put("/users/:id/roles", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionUserManage), handler.SetUserRoles)
+	e.Get("/roles", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionUserManage), handler.ListRoles)
+
+	authv1.RegisterAuthServiceServer(d.Grpc, &GrpcHandler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	})
+	d.Grpc.AuthRules[authv1.AuthService_Login_FullMethodName] = middleware.GrpcAuthBasic
+	d.Grpc.AuthRules[authv1.AuthService_VerifyTwoFactor_FullMethodName] = middleware.GrpcAuthBasic
+	d.Grpc.AuthRules[authv1.AuthService_Register_FullMethodName] = middleware.GrpcAuthClient
+	d.Grpc.Permissions[authv1.AuthService_Register_FullMethodName] = []string{middleware.ScopeUserRegister}
+	d.Grpc.AuthRules[authv1.AuthService_Refresh_FullMethodName] = middleware.GrpcAuthBasic
 	return handler
 }
 
+// @Summary JSON Web Key Set
+// @Description Public keys verifying the tokens, keys being rotated in or out are listed next to the signing key
+// @ID jwks
+// @Produce json
+// @Success 200 {object} authentication.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) JWKS(c *fiber.Ctx) error {
+	// verifiers cache the keys, a new key is published ahead of signing with it
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.Keys.JWKS())
+}
+
 // @Summary User Login
-// @Description Authenticate a user and return a token
+// @Description Authenticate a user and return a token, or a challenge token (schema.AuthLoginChallengeResponse) to verify at /auth/v1/2fa/verify when the user enabled two-factor authentication
 // @ID user-login
 // @Accept json
 // @Produce json
 // @Param login body schema.AuthLoginRequest true "Login request"
 // @Security BasicAuth
 // @Success 200 {object} schema.AuthLoginResponse
+// @Failure 429 {object} schema.AuthLoginThrottledResponse
 // @Router /auth/v1/login [post]
 func (h *Handler) Login(c *fiber.Ctx) error {
 	l := logger.WithID(h.Logger, ContextName, "Login")
@@ -64,6 +138,8 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 		perr := err.(*binding.ModelBindingError)
 		return c.Status(perr.Code).JSON(perr.ResponseBody)
 	}
+	model.IP = c.IP()
+	model.UserAgent = c.Get(fiber.HeaderUserAgent)
 
 	// validate model
 	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
@@ -73,6 +149,229 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 
 	// process request
 	response := h.UseCase.Auth(c.UserContext(), model)
+	if throttled, ok := response.Data.(schema.AuthLoginThrottledResponse); ok {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(throttled.RetryAfter))
+	}
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Verify Two-Factor Code
+// @Description Complete the login of a user with two-factor authentication, exchanging the challenge token and a TOTP or recovery code for the tokens
+// @ID user-2fa-verify
+// @Accept json
+// @Produce json
+// @Param verify body schema.TwoFactorVerifyRequest true "Verify request"
+// @Security BasicAuth
+// @Success 200 {object} schema.AuthLoginResponse
+// @Failure 429 {object} schema.AuthLoginThrottledResponse
+// @Router /auth/v1/2fa/verify [post]
+func (h *Handler) VerifyTwoFactor(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "VerifyTwoFactor")
+
+	// bind model
+	model := &schema.TwoFactorVerifyRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+	model.IP = c.IP()
+	model.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.VerifyTwoFactor(c.UserContext(), model)
+	if throttled, ok := response.Data.(schema.AuthLoginThrottledResponse); ok {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(throttled.RetryAfter))
+	}
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Enroll Two-Factor
+// @Description Generate a TOTP secret for the authenticated user, returned with its otpauth URI and a QR code PNG. Two-factor authentication is enabled once a code is confirmed
+// @ID user-2fa-enroll
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} schema.TwoFactorEnrollResponse
+// @Router /auth/v1/2fa/enroll [post]
+func (h *Handler) EnrollTwoFactor(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "EnrollTwoFactor")
+
+	// bind model
+	model := &schema.TwoFactorEnrollRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.EnrollTwoFactor(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Confirm Two-Factor
+// @Description Enable two-factor authentication with a TOTP code of the enrolled secret, the recovery codes are returned once
+// @ID user-2fa-confirm
+// @Accept json
+// @Produce json
+// @Param confirm body schema.TwoFactorConfirmRequest true "Confirm request"
+// @Security BearerAuth
+// @Success 200 {object} schema.TwoFactorRecoveryCodesResponse
+// @Router /auth/v1/2fa/confirm [post]
+func (h *Handler) ConfirmTwoFactor(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ConfirmTwoFactor")
+
+	// bind model
+	model := &schema.TwoFactorConfirmRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ConfirmTwoFactor(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Disable Two-Factor
+// @Description Disable two-factor authentication, requires the password and a TOTP or recovery code
+// @ID user-2fa-disable
+// @Accept json
+// @Produce json
+// @Param disable body schema.TwoFactorDisableRequest true "Disable request"
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/2fa/disable [post]
+func (h *Handler) DisableTwoFactor(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "DisableTwoFactor")
+
+	// bind model
+	model := &schema.TwoFactorDisableRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.DisableTwoFactor(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Regenerate Recovery Codes
+// @Description Replace the recovery codes with new ones, requires a TOTP code
+// @ID user-2fa-recovery-codes
+// @Accept json
+// @Produce json
+// @Param recovery body schema.TwoFactorRecoveryCodesRequest true "Regenerate request"
+// @Security BearerAuth
+// @Success 200 {object} schema.TwoFactorRecoveryCodesResponse
+// @Router /auth/v1/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "RegenerateRecoveryCodes")
+
+	// bind model
+	model := &schema.TwoFactorRecoveryCodesRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.RegenerateRecoveryCodes(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary OpenID Connect Login
+// @Description Redirect the browser to the identity provider, for the authorization code flow with PKCE
+// @ID user-oidc-login
+// @Success 302
+// @Router /auth/v1/oidc/login [get]
+func (h *Handler) OIDCLogin(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "OIDCLogin")
+
+	// bind model
+	model := &schema.OIDCLoginRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.OIDCLogin(c.UserContext(), model)
+	data, ok := response.Data.(schema.OIDCLoginResponse)
+	if !ok {
+		return c.Status(response.Code).JSON(response)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    data.State,
+		Path:     oidcStateCookiePath,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(data.AuthorizationURL, fiber.StatusFound)
+}
+
+// @Summary OpenID Connect Callback
+// @Description Complete the login at the identity provider and return a token, or a challenge token when the user enabled two-factor authentication. The user linked to the identity is signed in, or linked by their verified email, or created.
+// @ID user-oidc-callback
+// @Produce json
+// @Param code query string false "Authorization code"
+// @Param state query string true "State of the login"
+// @Success 200 {object} schema.AuthLoginResponse
+// @Router /auth/v1/oidc/callback [get]
+func (h *Handler) OIDCCallback(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "OIDCCallback")
+
+	// bind model
+	model := &schema.OIDCCallbackRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+	model.StateCookie = c.Cookies(oidcStateCookie)
+	model.IP = c.IP()
+	model.UserAgent = c.Get(fiber.HeaderUserAgent)
+	c.Cookie(&fiber.Cookie{Name: oidcStateCookie, Path: oidcStateCookiePath, Expires: time.Unix(0, 0), HTTPOnly: true})
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.OIDCCallback(c.UserContext(), model)
 	return c.Status(response.Code).JSON(response)
 }
 
@@ -83,6 +382,7 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 // @Produce json
 // @Param register body schema.AuthRegisterRequest true "Register request"
 // @Security BasicAuth
+// @Security ApiKeyAuth
 // @Success 201 {object} schema.AuthRegisterResponse
 // @Router /auth/v1/register [post]
 func (h *Handler) Register(c *fiber.Ctx) error {
@@ -94,6 +394,8 @@ func (h *Handler) Register(c *fiber.Ctx) error {
 		perr := err.(*binding.ModelBindingError)
 		return c.Status(perr.Code).JSON(perr.ResponseBody)
 	}
+	model.IP = c.IP()
+	model.UserAgent = c.Get(fiber.HeaderUserAgent)
 
 	// validate model
 	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
@@ -106,9 +408,251 @@ func (h *Handler) Register(c *fiber.Ctx) error {
 	return c.Status(response.Code).JSON(response)
 }
 
-// Register
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new token pair, a refresh token can be used once
+// @ID user-refresh
+// @Accept json
+// @Produce json
+// @Param refresh body schema.AuthRefreshRequest true "Refresh request"
+// @Security BasicAuth
+// @Success 200 {object} schema.AuthRefreshResponse
+// @Router /auth/v1/refresh [post]
+func (h *Handler) Refresh(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Refresh")
+
+	// bind model
+	model := &schema.AuthRefreshRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.Refresh(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary User Logout
+// @Description Revoke the token of the request and the refresh tokens issued with it
+// @ID user-logout
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/logout [post]
+func (h *Handler) Logout(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Logout")
+
+	// bind model
+	model := &schema.AuthLogoutRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.Logout(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary List Sessions
+// @Description List the devices the authenticated user is logged in on, the most recently used first
+// @ID user-sessions-list
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} schema.SessionResponse
+// @Router /auth/v1/sessions [get]
+func (h *Handler) ListSessions(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ListSessions")
+
+	// bind model
+	model := &schema.SessionListRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ListSessions(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Revoke Session
+// @Description Sign the authenticated user out of one of their devices, its tokens are revoked
+// @ID user-sessions-revoke
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "RevokeSession")
+
+	// bind model
+	model := &schema.SessionRevokeRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.RevokeSession(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Revoke Other Sessions
+// @Description Sign the authenticated user out of every device but the one of the request
+// @ID user-sessions-revoke-others
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} schema.SessionRevokeOthersResponse
+// @Router /auth/v1/sessions/revoke-others [post]
+func (h *Handler) RevokeOtherSessions(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "RevokeOtherSessions")
+
+	// bind model
+	model := &schema.SessionRevokeOthersRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.RevokeOtherSessions(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Revoke User Tokens
+// @Description Revoke every token issued to a user so far, requires the user:manage permission
+// @ID user-revoke-tokens
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/users/{id}/revoke [post]
+func (h *Handler) RevokeUserTokens(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "RevokeUserTokens")
+
+	// bind model
+	model := &schema.RequestRevokeUserTokens{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.RevokeUserTokens(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Unlock User
+// @Description Lift the login lockout of a user after too many failed logins, requires the user:manage permission
+// @ID user-unlock
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/users/{id}/unlock [post]
+func (h *Handler) UnlockUser(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "UnlockUser")
+
+	// bind model
+	model := &schema.RequestUnlockUser{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.UnlockUser(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary List Roles
+// @Description List the roles and the permissions they grant, requires the user:manage permission
+// @ID user-roles-list
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} schema.RoleResponse
+// @Router /auth/v1/roles [get]
+func (h *Handler) ListRoles(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ListRoles")
+
+	// bind model
+	model := &schema.RoleListRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ListRoles(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Set User Roles
+// @Description Replace the roles of a user, removing a role revokes the tokens of the user, requires the user:manage permission
+// @ID user-roles-set
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param roles body schema.UserRolesRequest true "Roles"
+// @Security BearerAuth
+// @Success 200 {object} schema.UserRolesResponse
+// @Router /auth/v1/users/{id}/roles [put]
+func (h *Handler) SetUserRoles(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "SetUserRoles")
+
+	// bind model
+	model := &schema.UserRolesRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.SetUserRoles(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary User Profile
+// @Description Get the profile of the authenticated user
+// @ID user-profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} schema.ProfileResponse
+// @Router /auth/v1/profile [get]
 func (h *Handler) Profile(c *fiber.Ctx) error {
-	l := logger.WithID(h.Logger, ContextName, "Register")
+	l := logger.WithID(h.Logger, ContextName, "Profile")
 
 	// bind model
 	model := &schema.ProfileRequest{}
@@ -127,3 +671,181 @@ func (h *Handler) Profile(c *fiber.Ctx) error {
 	response := h.UseCase.Profile(c.UserContext(), model)
 	return c.Status(response.Code).JSON(response)
 }
+
+// @Summary Update User Profile
+// @Description Change the profile fields that are set, PUT clears the fields that are missing
+// @ID user-profile-update
+// @Accept json
+// @Produce json
+// @Param profile body schema.ProfileUpdateRequest true "Profile"
+// @Security BearerAuth
+// @Success 200 {object} schema.ProfileResponse
+// @Router /auth/v1/profile [patch]
+// @Router /auth/v1/profile [put]
+func (h *Handler) UpdateProfile(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "UpdateProfile")
+
+	// bind model
+	model := &schema.ProfileUpdateRequest{Replace: c.Method() == fiber.MethodPut}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.UpdateProfile(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Change Password
+// @Description Replace the password of the authenticated user, the current password is required
+// @ID user-password-change
+// @Accept json
+// @Produce json
+// @Param password body schema.PasswordChangeRequest true "Change password request"
+// @Security BearerAuth
+// @Success 204
+// @Router /auth/v1/password/change [post]
+func (h *Handler) ChangePassword(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ChangePassword")
+
+	// bind model
+	model := &schema.PasswordChangeRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ChangePassword(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Forgot Password
+// @Description Send a password reset token to the user, the response does not tell whether the user exists
+// @ID user-password-forgot
+// @Accept json
+// @Produce json
+// @Param password body schema.PasswordForgotRequest true "Forgot password request"
+// @Security BasicAuth
+// @Success 202
+// @Failure 429 {object} schema.AuthLoginThrottledResponse
+// @Router /auth/v1/password/forgot [post]
+func (h *Handler) ForgotPassword(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ForgotPassword")
+
+	// bind model
+	model := &schema.PasswordForgotRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+	model.IP = c.IP()
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ForgotPassword(c.UserContext(), model)
+	if throttled, ok := response.Data.(schema.AuthLoginThrottledResponse); ok {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(throttled.RetryAfter))
+	}
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Reset Password
+// @Description Replace the password with a reset token and revoke every token of the user
+// @ID user-password-reset
+// @Accept json
+// @Produce json
+// @Param password body schema.PasswordResetRequest true "Reset password request"
+// @Security BasicAuth
+// @Success 204
+// @Router /auth/v1/password/reset [post]
+func (h *Handler) ResetPassword(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ResetPassword")
+
+	// bind model
+	model := &schema.PasswordResetRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ResetPassword(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Verify Email
+// @Description Mark the email address of a verification token as verified, refresh the token to get the verified claim
+// @ID user-email-verify
+// @Accept json
+// @Produce json
+// @Param verify body schema.EmailVerifyRequest true "Verify request"
+// @Security BasicAuth
+// @Success 204
+// @Router /auth/v1/email/verify [post]
+func (h *Handler) VerifyEmail(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "VerifyEmail")
+
+	// bind model
+	model := &schema.EmailVerifyRequest{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.VerifyEmail(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// @Summary Resend Verification Email
+// @Description Send a new verification email to the authenticated user, throttled
+// @ID user-email-resend
+// @Produce json
+// @Security BearerAuth
+// @Success 202
+// @Router /auth/v1/email/resend [post]
+func (h *Handler) ResendVerification(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ResendVerification")
+
+	// bind model
+	model := &schema.EmailResendRequest{}
+	if err := binding.BindModel(l, c, model); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// process request
+	response := h.UseCase.ResendVerification(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
@@ -2,10 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
 	"github.com/Alwanly/go-codebase/pkg/database"
 	"github.com/Alwanly/go-codebase/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"gorm.io/gorm"
 )
 
 const ContextName = "Internal.User.Repository"
@@ -18,7 +24,59 @@ type (
 
 	IRepository interface {
 		Login(ctx context.Context, username string) (*model.User, error)
-		Register(ctx context.Context, model *model.User) (*model.User, error)
+		Register(ctx context.Context, user *model.User, role string) (*model.User, error)
+		GetByIDs(ctx context.Context, ids []string) ([]model.User, error)
+		GetByID(ctx context.Context, id string) (*model.User, error)
+		EmailTaken(ctx context.Context, email string, exceptID string) (bool, error)
+		UpdateProfile(ctx context.Context, user *model.User) error
+		GetByUsername(ctx context.Context, username string) (*model.User, error)
+		GetByEmail(ctx context.Context, email string) (*model.User, error)
+		UpdatePassword(ctx context.Context, id string, hash string) error
+		MarkEmailVerified(ctx context.Context, id string, email string) (bool, error)
+		ThrottleEmailVerification(ctx context.Context, userID string, interval time.Duration) (bool, error)
+
+		GetRoles(ctx context.Context, userID string) ([]string, []string, error)
+		GetUserRoles(ctx context.Context, userIDs []string) (map[string][]string, error)
+		ListRoles(ctx context.Context) ([]model.Role, []model.RolePermission, error)
+		SetUserRoles(ctx context.Context, userID string, roles []string) error
+
+		LoginBlockedFor(ctx context.Context, username string) (time.Duration, bool, error)
+		CountIPLoginFailures(ctx context.Context, ip string, window time.Duration) (int64, time.Duration, error)
+		AddLoginFailure(ctx context.Context, username string, ip string, window time.Duration) (int64, int64, error)
+		DelayLogin(ctx context.Context, username string, delay time.Duration) error
+		LockLogin(ctx context.Context, username string, duration time.Duration) error
+		ClearLoginFailures(ctx context.Context, username string) error
+		UnlockLogin(ctx context.Context, username string) (bool, error)
+		CreateAuditEvent(ctx context.Context, event *model.AuthAuditEvent) error
+
+		SetTOTPSecret(ctx context.Context, userID string, secret string) (bool, error)
+		EnableTOTP(ctx context.Context, userID string, codeHashes []string) (bool, error)
+		DisableTOTP(ctx context.Context, userID string) error
+		ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+		UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error)
+		UseTOTPCode(ctx context.Context, userID string, counter int64, ttl time.Duration) (bool, error)
+
+		SaveOIDCRequest(ctx context.Context, req *authentication.OIDCAuthRequest, ttl time.Duration) error
+		ConsumeOIDCRequest(ctx context.Context, state string) (*authentication.OIDCAuthRequest, error)
+		GetIdentity(ctx context.Context, issuer string, subject string) (*model.UserIdentity, error)
+		LinkIdentity(ctx context.Context, identity *model.UserIdentity) error
+		ProvisionUser(ctx context.Context, user *model.User, role string, identity *model.UserIdentity) (*model.User, error)
+		TouchIdentity(ctx context.Context, issuer string, subject string) error
+
+		CreatePasswordReset(ctx context.Context, userID string, tokenHash string, ttl time.Duration) error
+		ConsumePasswordReset(ctx context.Context, tokenHash string) (string, bool, error)
+		ThrottlePasswordReset(ctx context.Context, username string, ip string, window time.Duration, maxPerUser int, maxPerIP int) (time.Duration, error)
+
+		CreateTokenFamily(ctx context.Context, family string, tokenID string, ttl time.Duration) error
+		GetTokenFamily(ctx context.Context, family string) (string, bool, error)
+		RotateTokenFamily(ctx context.Context, family string, tokenID string, nextTokenID string, ttl time.Duration) (bool, error)
+		RevokeTokenFamily(ctx context.Context, family string) error
+
+		CreateSession(ctx context.Context, session *model.UserSession) error
+		ExtendSession(ctx context.Context, id string, expiresAt time.Time) error
+		ListSessions(ctx context.Context, userID string) ([]model.UserSession, error)
+		RevokeSession(ctx context.Context, userID string, id string) (bool, error)
+		RevokeSessions(ctx context.Context, userID string, exceptID string) error
 	}
 )
 
@@ -43,13 +101,616 @@ func (r *Repository) Login(ctx context.Context, username string) (*model.User, e
 	return &model, nil
 }
 
-func (r *Repository) Register(ctx context.Context, model *model.User) (*model.User, error) {
+// Register creates the user with the role.
+func (r *Repository) Register(ctx context.Context, user *model.User, role string) (*model.User, error) {
+	err := r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		tx := r.DB.GetTransaction(ctx)
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.UserRole{UserID: user.ID, Role: role, CreatedAt: user.CreatedAt}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *Repository) GetByIDs(ctx context.Context, ids []string) ([]model.User, error) {
+	var users []model.User
+	err := r.DB.GetTransaction(ctx).
+		Select("id", "username", "email_verified_at", "created_at").
+		Where("id IN ?", ids).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetByID returns the user, nil if there is none.
+func (r *Repository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	var user model.User
+	err := r.DB.GetTransaction(ctx).Where("id = ?", id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// EmailTaken checks whether another user than exceptID has the email.
+func (r *Repository) EmailTaken(ctx context.Context, email string, exceptID string) (bool, error) {
+	var count int64
+	err := r.DB.GetTransaction(ctx).Model(&model.User{}).
+		Where("email = ? AND id <> ?", email, exceptID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// UpdateProfile saves the profile fields and updated_at of the user, other fields are left as is.
+func (r *Repository) UpdateProfile(ctx context.Context, user *model.User) error {
+	return r.DB.GetTransaction(ctx).Model(user).
+		Select("display_name", "email", "email_verified_at", "bio", "avatar_url", "updated_at").
+		Updates(user).Error
+}
+
+// GetByUsername returns the user, nil if there is none.
+func (r *Repository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	var user model.User
+	err := r.DB.GetTransaction(ctx).Where("username = ?", username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetByEmail returns the user, nil if there is none.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := r.DB.GetTransaction(ctx).Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *Repository) UpdatePassword(ctx context.Context, id string, hash string) error {
+	return r.DB.GetTransaction(ctx).Model(&model.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"password": hash, "updated_at": time.Now()}).Error
+}
+
+// MarkEmailVerified marks the email of the user as verified, it fails when the user no longer has
+// this email or it is already verified.
+func (r *Repository) MarkEmailVerified(ctx context.Context, id string, email string) (bool, error) {
+	now := time.Now()
+	result := r.DB.GetTransaction(ctx).Model(&model.User{}).
+		Where("id = ? AND email = ? AND email_verified_at IS NULL", id, email).
+		Updates(map[string]interface{}{"email_verified_at": now, "updated_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ThrottleEmailVerification reports whether a verification email may be sent to the user, at most
+// one is sent per interval.
+func (r *Repository) ThrottleEmailVerification(ctx context.Context, userID string, interval time.Duration) (bool, error) {
+	return r.Redis.SetNX(ctx, "auth:verify:throttle:"+userID, 1, interval)
+}
+
+// GetRoles returns the roles of the user and the permissions they grant, sorted by name.
+func (r *Repository) GetRoles(ctx context.Context, userID string) ([]string, []string, error) {
 	tx := r.DB.GetTransaction(ctx)
 
-	cmd := tx.Create(model).Error
-	if cmd != nil {
-		return nil, cmd
+	var roles []string
+	err := tx.Model(&model.UserRole{}).
+		Where("user_id = ?", userID).
+		Order("role").
+		Pluck("role", &roles).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var permissions []string
+	err = tx.Model(&model.RolePermission{}).
+		Distinct("permission").
+		Where("role IN (?)", tx.Model(&model.UserRole{}).Select("role").Where("user_id = ?", userID)).
+		Order("permission").
+		Pluck("permission", &permissions).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return roles, permissions, nil
+}
+
+// GetUserRoles returns the roles of the users by user ID, sorted by name. Users without roles are
+// left out.
+func (r *Repository) GetUserRoles(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	var rows []model.UserRole
+	err := r.DB.GetTransaction(ctx).
+		Where("user_id IN ?", userIDs).
+		Order("user_id, role").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string][]string, len(userIDs))
+	for _, row := range rows {
+		roles[row.UserID] = append(roles[row.UserID], row.Role)
+	}
+	return roles, nil
+}
+
+// ListRoles returns every role and the permissions granted to them.
+func (r *Repository) ListRoles(ctx context.Context) ([]model.Role, []model.RolePermission, error) {
+	tx := r.DB.GetTransaction(ctx)
+
+	var roles []model.Role
+	if err := tx.Order("name").Find(&roles).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var grants []model.RolePermission
+	if err := tx.Order("role, permission").Find(&grants).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return roles, grants, nil
+}
+
+// SetUserRoles replaces the roles of the user.
+func (r *Repository) SetUserRoles(ctx context.Context, userID string, roles []string) error {
+	return r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		tx := r.DB.GetTransaction(ctx)
+		if err := tx.Where("user_id = ?", userID).Delete(&model.UserRole{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rows := make([]model.UserRole, 0, len(roles))
+		for _, role := range roles {
+			rows = append(rows, model.UserRole{UserID: userID, Role: role, CreatedAt: now})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.User{}).
+			Where("id = ?", userID).
+			Update("updated_at", now).Error
+	})
+}
+
+// loginFailuresKey and loginIPFailuresKey are sliding windows of failed logins, loginDelayKey and
+// loginLockKey exist while the username has to wait before its next attempt.
+func loginFailuresKey(username string) string {
+	return "auth:login:failures:user:" + username
+}
+
+func loginIPFailuresKey(ip string) string {
+	return "auth:login:failures:ip:" + ip
+}
+
+func loginDelayKey(username string) string {
+	return "auth:login:delay:" + username
+}
+
+func loginLockKey(username string) string {
+	return "auth:login:lock:" + username
+}
+
+// LoginBlockedFor returns how long the username has to wait before its next login attempt, locked
+// tells whether it is locked rather than delayed.
+func (r *Repository) LoginBlockedFor(ctx context.Context, username string) (time.Duration, bool, error) {
+	lock, err := r.Redis.TTL(ctx, loginLockKey(username))
+	if err != nil {
+		return 0, false, err
+	}
+	if lock > 0 {
+		return lock, true, nil
+	}
+
+	delay, err := r.Redis.TTL(ctx, loginDelayKey(username))
+	if err != nil {
+		return 0, false, err
+	}
+	return delay, false, nil
+}
+
+// CountIPLoginFailures returns the failed logins of the IP in the window, and the time until the
+// oldest of them leaves the window.
+func (r *Repository) CountIPLoginFailures(ctx context.Context, ip string, window time.Duration) (int64, time.Duration, error) {
+	return r.Redis.WindowCount(ctx, loginIPFailuresKey(ip), window)
+}
+
+// AddLoginFailure records a failed login and returns the failures of the username and of the IP in
+// the window.
+func (r *Repository) AddLoginFailure(ctx context.Context, username string, ip string, window time.Duration) (int64, int64, error) {
+	userFailures, err := r.Redis.WindowAdd(ctx, loginFailuresKey(username), window)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ip == "" {
+		return userFailures, 0, nil
+	}
+
+	ipFailures, err := r.Redis.WindowAdd(ctx, loginIPFailuresKey(ip), window)
+	if err != nil {
+		return 0, 0, err
+	}
+	return userFailures, ipFailures, nil
+}
+
+func (r *Repository) DelayLogin(ctx context.Context, username string, delay time.Duration) error {
+	return r.Redis.Set(ctx, loginDelayKey(username), 1, delay)
+}
+
+// LockLogin locks the username for the duration, its failures start over once it is unlocked.
+func (r *Repository) LockLogin(ctx context.Context, username string, duration time.Duration) error {
+	if err := r.Redis.Set(ctx, loginLockKey(username), 1, duration); err != nil {
+		return err
+	}
+	return r.ClearLoginFailures(ctx, username)
+}
+
+func (r *Repository) ClearLoginFailures(ctx context.Context, username string) error {
+	return r.Redis.DelMany(ctx, []string{loginFailuresKey(username), loginDelayKey(username)})
+}
+
+// UnlockLogin lifts the lockout and the delay of the username, locked tells whether it was locked.
+func (r *Repository) UnlockLogin(ctx context.Context, username string) (bool, error) {
+	lock, err := r.Redis.TTL(ctx, loginLockKey(username))
+	if err != nil {
+		return false, err
 	}
 
-	return model, nil
+	err = r.Redis.DelMany(ctx, []string{loginLockKey(username), loginFailuresKey(username), loginDelayKey(username)})
+	if err != nil {
+		return false, err
+	}
+	return lock > 0, nil
+}
+
+func (r *Repository) CreateAuditEvent(ctx context.Context, event *model.AuthAuditEvent) error {
+	return r.DB.GetTransaction(ctx).Create(event).Error
+}
+
+// SetTOTPSecret stores the secret of a pending enrollment, replacing a previous pending one. It
+// fails when two-factor authentication is already enabled.
+func (r *Repository) SetTOTPSecret(ctx context.Context, userID string, secret string) (bool, error) {
+	result := r.DB.GetTransaction(ctx).Model(&model.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL", userID).
+		Updates(map[string]interface{}{"totp_secret": secret, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// EnableTOTP enables two-factor authentication with the pending secret and stores the recovery
+// codes. It fails when there is no pending secret or it is already enabled.
+func (r *Repository) EnableTOTP(ctx context.Context, userID string, codeHashes []string) (bool, error) {
+	enabled := false
+	err := r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		result := r.DB.GetTransaction(ctx).Model(&model.User{}).
+			Where("id = ? AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{"totp_enabled_at": now, "updated_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		enabled = true
+		return r.ReplaceRecoveryCodes(ctx, userID, codeHashes)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// DisableTOTP removes the secret and the recovery codes of the user.
+func (r *Repository) DisableTOTP(ctx context.Context, userID string) error {
+	return r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		tx := r.DB.GetTransaction(ctx)
+		err := tx.Model(&model.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_secret": nil, "totp_enabled_at": nil, "updated_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+	})
+}
+
+// ReplaceRecoveryCodes replaces the recovery codes of the user, the previous codes stop working.
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	return r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		tx := r.DB.GetTransaction(ctx)
+		if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rows := make([]model.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			rows = append(rows, model.RecoveryCode{UserID: userID, CodeHash: hash, CreatedAt: now})
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// UseRecoveryCode marks a recovery code of the user as used, it fails when the code is unknown or
+// was already used.
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	result := r.DB.GetTransaction(ctx).Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UseTOTPCode records the use of the TOTP code of a period, it fails when the code of the period
+// was already used so an observed code cannot be replayed.
+func (r *Repository) UseTOTPCode(ctx context.Context, userID string, counter int64, ttl time.Duration) (bool, error) {
+	return r.Redis.SetNX(ctx, "auth:totp:used:"+userID+":"+strconv.FormatInt(counter, 10), 1, ttl)
+}
+
+// oidcRequestKey holds a login in progress at the identity provider, keyed by its state.
+func oidcRequestKey(state string) string {
+	return "auth:oidc:state:" + state
+}
+
+func (r *Repository) SaveOIDCRequest(ctx context.Context, req *authentication.OIDCAuthRequest, ttl time.Duration) error {
+	value, err := utils.JSONMarshal(req)
+	if err != nil {
+		return err
+	}
+	return r.Redis.Set(ctx, oidcRequestKey(req.State), string(value), ttl)
+}
+
+// ConsumeOIDCRequest returns the login of the state and removes it, so a callback is accepted
+// once. It returns nil for unknown, used and expired states.
+func (r *Repository) ConsumeOIDCRequest(ctx context.Context, state string) (*authentication.OIDCAuthRequest, error) {
+	value, err := r.Redis.GetDel(ctx, oidcRequestKey(state))
+	if errors.Is(err, redis.ErrNil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var req authentication.OIDCAuthRequest
+	if err := utils.JSONUnMarshal([]byte(value), &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetIdentity returns the link of an account at the identity provider, nil if there is none.
+func (r *Repository) GetIdentity(ctx context.Context, issuer string, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	err := r.DB.GetTransaction(ctx).Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *Repository) LinkIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	return r.DB.GetTransaction(ctx).Create(identity).Error
+}
+
+// ProvisionUser creates a user with the role on their first login at the identity provider, linked
+// to their account there.
+func (r *Repository) ProvisionUser(ctx context.Context, user *model.User, role string, identity *model.UserIdentity) (*model.User, error) {
+	err := r.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := r.Register(ctx, user, role); err != nil {
+			return err
+		}
+		return r.LinkIdentity(ctx, identity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *Repository) TouchIdentity(ctx context.Context, issuer string, subject string) error {
+	return r.DB.GetTransaction(ctx).Model(&model.UserIdentity{}).
+		Where("issuer = ? AND subject = ?", issuer, subject).
+		Update("last_login_at", time.Now()).Error
+}
+
+// passwordResetKey maps the hash of a reset token to its user, passwordResetUserKey the user to
+// the hash of their latest token.
+func passwordResetKey(tokenHash string) string {
+	return "auth:reset:token:" + tokenHash
+}
+
+func passwordResetUserKey(userID string) string {
+	return "auth:reset:user:" + userID
+}
+
+// CreatePasswordReset stores a reset token of the user, the previous token of the user stops
+// working.
+func (r *Repository) CreatePasswordReset(ctx context.Context, userID string, tokenHash string, ttl time.Duration) error {
+	previous, err := r.Redis.Get(ctx, passwordResetUserKey(userID))
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return err
+	}
+	if previous != "" {
+		if err := r.Redis.Del(ctx, passwordResetKey(previous)); err != nil {
+			return err
+		}
+	}
+
+	return r.Redis.MSet(ctx, map[string]interface{}{
+		passwordResetKey(tokenHash):  userID,
+		passwordResetUserKey(userID): tokenHash,
+	}, ttl)
+}
+
+// ConsumePasswordReset returns the user of a reset token and removes the token, so it can be used
+// once. found is false for unknown, used and expired tokens.
+func (r *Repository) ConsumePasswordReset(ctx context.Context, tokenHash string) (string, bool, error) {
+	userID, err := r.Redis.GetDel(ctx, passwordResetKey(tokenHash))
+	if errors.Is(err, redis.ErrNil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := r.Redis.Del(ctx, passwordResetUserKey(userID)); err != nil {
+		return "", false, err
+	}
+	return userID, true, nil
+}
+
+func passwordResetRequestsUserKey(username string) string {
+	return "auth:reset:requests:user:" + username
+}
+
+func passwordResetRequestsIPKey(ip string) string {
+	return "auth:reset:requests:ip:" + ip
+}
+
+// ThrottlePasswordReset counts a reset request of the username from the IP, unless either already
+// reached its limit in the window, then it returns the time until the oldest request of the limit
+// leaves the window. A limit of 0 is no limit, an empty IP is not counted.
+func (r *Repository) ThrottlePasswordReset(ctx context.Context, username string, ip string, window time.Duration, maxPerUser int, maxPerIP int) (time.Duration, error) {
+	type limit struct {
+		key string
+		max int
+	}
+	limits := []limit{{passwordResetRequestsUserKey(username), maxPerUser}}
+	if ip != "" {
+		limits = append(limits, limit{passwordResetRequestsIPKey(ip), maxPerIP})
+	}
+
+	for _, limit := range limits {
+		if limit.max <= 0 {
+			continue
+		}
+		count, retryAfter, err := r.Redis.WindowCount(ctx, limit.key, window)
+		if err != nil {
+			return 0, err
+		}
+		if count >= int64(limit.max) {
+			return max(retryAfter, time.Second), nil
+		}
+	}
+
+	for _, limit := range limits {
+		if _, err := r.Redis.WindowAdd(ctx, limit.key, window); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+// tokenFamilyKey is the key of a token family, it holds the jti of the only refresh token of the
+// family that may still be used.
+func tokenFamilyKey(family string) string {
+	return "auth:refresh:family:" + family
+}
+
+func (r *Repository) CreateTokenFamily(ctx context.Context, family string, tokenID string, ttl time.Duration) error {
+	return r.Redis.Set(ctx, tokenFamilyKey(family), tokenID, ttl)
+}
+
+// GetTokenFamily returns the current refresh jti of the family, found is false once the family is
+// revoked or expired.
+func (r *Repository) GetTokenFamily(ctx context.Context, family string) (string, bool, error) {
+	tokenID, err := r.Redis.Get(ctx, tokenFamilyKey(family))
+	if errors.Is(err, redis.ErrNil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return tokenID, true, nil
+}
+
+// RotateTokenFamily moves the family to the next refresh jti, it fails when the family no longer
+// holds tokenID so a token can only be rotated once.
+func (r *Repository) RotateTokenFamily(ctx context.Context, family string, tokenID string, nextTokenID string, ttl time.Duration) (bool, error) {
+	return r.Redis.CompareAndSet(ctx, tokenFamilyKey(family), tokenID, nextTokenID, ttl)
+}
+
+func (r *Repository) RevokeTokenFamily(ctx context.Context, family string) error {
+	return r.Redis.Del(ctx, tokenFamilyKey(family))
+}
+
+func (r *Repository) CreateSession(ctx context.Context, session *model.UserSession) error {
+	return r.DB.GetTransaction(ctx).Create(session).Error
+}
+
+// ExtendSession records a refresh of the session, it expires with its latest refresh token.
+func (r *Repository) ExtendSession(ctx context.Context, id string, expiresAt time.Time) error {
+	return r.DB.GetTransaction(ctx).Model(&model.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"last_seen_at": time.Now(), "expires_at": expiresAt}).Error
+}
+
+// ListSessions returns the sessions of the user that are neither revoked nor expired, the most
+// recently used first.
+func (r *Repository) ListSessions(ctx context.Context, userID string) ([]model.UserSession, error) {
+	var sessions []model.UserSession
+	err := r.DB.GetTransaction(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession revokes a session of the user, found is false when the user has no such active
+// session.
+func (r *Repository) RevokeSession(ctx context.Context, userID string, id string) (bool, error) {
+	tx := r.DB.GetTransaction(ctx).Model(&model.UserSession{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, time.Now()).
+		Update("revoked_at", time.Now())
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// RevokeSessions revokes the active sessions of the user but exceptID, all of them when it is
+// empty.
+func (r *Repository) RevokeSessions(ctx context.Context, userID string, exceptID string) error {
+	return r.DB.GetTransaction(ctx).Model(&model.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, exceptID, time.Now()).
+		Update("revoked_at", time.Now()).Error
 }
@@ -1,13 +1,40 @@
 package schema
 
 import (
-	"github.com/Alwanly/go-codebase/model"
+	"time"
+
 	"github.com/Alwanly/go-codebase/pkg/middleware"
 )
 
+// Events of the authentication audit log.
+const (
+	AuditLoginLocked    = "login.locked"
+	AuditLoginUnlocked  = "login.unlocked"
+	AuditLoginIPBlocked = "login.ip_blocked"
+
+	AuditTwoFactorEnabled         = "2fa.enabled"
+	AuditTwoFactorDisabled        = "2fa.disabled"
+	AuditRecoveryCodesRegenerated = "2fa.recovery_codes_regenerated"
+	AuditRecoveryCodeUsed         = "2fa.recovery_code_used"
+
+	AuditOIDCLinked      = "oidc.linked"
+	AuditOIDCProvisioned = "oidc.provisioned"
+)
+
 type AuthLoginRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+
+	// IP of the caller, login failures are throttled per IP
+	IP string `json:"-"`
+	// UserAgent of the caller, recorded with the IP on the session of the login
+	UserAgent string `json:"-"`
+}
+
+// AuthLoginThrottledResponse tells when the login may be attempted again, in seconds, also sent
+// as the Retry-After header.
+type AuthLoginThrottledResponse struct {
+	RetryAfter int `json:"retryAfter"`
 }
 
 type AuthLoginResponse struct {
@@ -15,9 +42,23 @@ type AuthLoginResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// AuthLoginChallengeResponse is returned in place of the tokens when the user enabled two-factor
+// authentication, the challenge token is exchanged with a code at /auth/v1/2fa/verify.
+type AuthLoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ExpiresIn         int    `json:"expiresIn"`
+}
+
 type AuthRegisterRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	// Email is optional, an account without one works except on the routes requiring a verified email
+	Email string `json:"email" validate:"omitempty,email,max=255"`
+
+	// IP and UserAgent of the caller, recorded on the session of the registration
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type AuthRegisterResponse struct {
@@ -25,10 +66,248 @@ type AuthRegisterResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type AuthRefreshResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+type AuthLogoutRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+type AuthLogoutResponse struct{}
+
+type RequestRevokeUserTokens struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseRevokeUserTokens struct{}
+
+type RequestUnlockUser struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseUnlockUser struct{}
+
 type ProfileRequest struct {
 	AuthUserData *middleware.AuthUserData
 }
 
+// ProfileResponse is the view of a user for the user themself.
 type ProfileResponse struct {
-	model.User
+	ID            string    `json:"id"`
+	Username      string    `json:"username"`
+	Role          string    `json:"role"`
+	DisplayName   string    `json:"displayName"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"emailVerified"`
+	Bio           string    `json:"bio"`
+	AvatarURL     string    `json:"avatarUrl"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// ProfileUpdateRequest changes the profile fields that are set, or all of them when Replace is
+// set, clearing the missing ones. An empty string clears a field too.
+type ProfileUpdateRequest struct {
+	DisplayName *string `json:"displayName" validate:"omitempty,max=100"`
+	Email       *string `json:"email" validate:"omitempty,max=255,len=0|email"`
+	Bio         *string `json:"bio" validate:"omitempty,max=500"`
+	AvatarURL   *string `json:"avatarUrl" validate:"omitempty,max=2048,len=0|http_url"`
+
+	Replace bool `json:"-"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestUserBatch struct {
+	IDs []string `validate:"required,min=1,max=100,dive,required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseUser is the public view of a user, safe to show to other users.
+type ResponseUser struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required,min=8,max=72,nefield=CurrentPassword"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type PasswordChangeResponse struct{}
+
+type PasswordForgotRequest struct {
+	Username string `json:"username" validate:"required"`
+
+	// IP of the caller, reset requests are throttled per username and per IP
+	IP string `json:"-"`
+}
+
+// PasswordForgotResponse is the same whether the user exists or not.
+type PasswordForgotResponse struct{}
+
+type PasswordResetRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8,max=72"`
+}
+
+type PasswordResetResponse struct{}
+
+type EmailVerifyRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type EmailVerifyResponse struct{}
+
+type EmailResendRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+type EmailResendResponse struct{}
+
+type RoleListRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRolesRequest replaces the roles of a user.
+type UserRolesRequest struct {
+	ID    string   `params:"id" validate:"required"`
+	Roles []string `json:"roles" validate:"required,min=1,dive,required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type UserRolesResponse struct {
+	UserID string   `json:"userId"`
+	Role   string   `json:"role"`
+	Roles  []string `json:"roles"`
+}
+
+type TwoFactorEnrollRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+// TwoFactorEnrollResponse is the pending TOTP secret, enrollment completes once a code of the
+// secret is confirmed. The QR code is a PNG image of the URI.
+type TwoFactorEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode []byte `json:"qrCode" swaggertype:"string" format:"base64"`
+}
+
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// TwoFactorRecoveryCodesResponse lists recovery codes, they are shown once.
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// TwoFactorVerifyRequest completes a login, the code is a TOTP code or a recovery code.
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required,max=32"`
+
+	// IP of the caller, failed codes are throttled like failed logins
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TwoFactorDisableRequest requires the password and a TOTP or recovery code.
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=32"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type TwoFactorDisableResponse struct{}
+
+// TwoFactorRecoveryCodesRequest replaces the recovery codes, it requires a TOTP code.
+type TwoFactorRecoveryCodesRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// OIDCLoginRequest starts a login at the OpenID Connect provider.
+type OIDCLoginRequest struct{}
+
+// OIDCLoginResponse is the URL of the identity provider to redirect the user to, the state is
+// also set in a cookie to check the callback comes from the same browser.
+type OIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"-"`
+}
+
+// OIDCCallbackRequest is the redirect from the identity provider, with an authorization code or
+// an error.
+type OIDCCallbackRequest struct {
+	Code             string `query:"code" validate:"required_without=Error"`
+	State            string `query:"state" validate:"required"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+
+	// StateCookie is the state of the login started by this browser
+	StateCookie string `json:"-" query:"-"`
+
+	// IP and UserAgent of the browser, recorded on the session of the login
+	IP        string `json:"-" query:"-"`
+	UserAgent string `json:"-" query:"-"`
+}
+
+type SessionListRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+// SessionResponse is a login of the user on a device, Current is the session of the request.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+type SessionRevokeRequest struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type SessionRevokeResponse struct{}
+
+// SessionRevokeOthersRequest signs out every device but the one of the request.
+type SessionRevokeOthersRequest struct {
+	AuthUserData *middleware.AuthUserData
+}
+
+type SessionRevokeOthersResponse struct {
+	Revoked int `json:"revoked"`
 }
@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/internal/user/usecase"
+	repository "github.com/Alwanly/go-codebase/mocks/internal_/user/repository"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func loginConfig() *config.GlobalConfig {
+	return &config.GlobalConfig{
+		LoginWindow:           900,
+		LoginDelayAfter:       3,
+		LoginDelayBase:        1,
+		LoginDelayMax:         60,
+		LoginLockoutThreshold: 10,
+		LoginLockoutDuration:  900,
+		LoginIPMaxFailures:    5,
+	}
+}
+
+var wrongPassword = &schema.AuthLoginRequest{Username: "reader", Password: "wrong", IP: "10.0.0.1"}
+
+// newLoginUseCase expects a failed login of an unknown username, the repository counting the
+// failures given.
+func newLoginUseCase(t *testing.T, cfg *config.GlobalConfig, userFailures, ipFailures int64) (usecase.IUseCase, *repository.MockIRepository) {
+	repo := repository.NewMockIRepository(t)
+	window := 900 * time.Second
+
+	repo.EXPECT().LoginBlockedFor(mock.Anything, "reader").Return(0, false, nil)
+	repo.EXPECT().CountIPLoginFailures(mock.Anything, "10.0.0.1", window).Return(ipFailures-1, 0, nil)
+	repo.EXPECT().Login(mock.Anything, "reader").Return(nil, errors.New("record not found"))
+	repo.EXPECT().AddLoginFailure(mock.Anything, "reader", "10.0.0.1", window).Return(userFailures, ipFailures, nil)
+
+	return usecase.NewUseCase(usecase.UseCase{Config: cfg, Logger: zap.NewNop(), Repository: repo}), repo
+}
+
+func TestAuth_LoginDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int64
+		delay    time.Duration
+	}{
+		{name: "first delayed failure", failures: 3, delay: time.Second},
+		{name: "doubles", failures: 4, delay: 2 * time.Second},
+		{name: "doubles again", failures: 8, delay: 32 * time.Second},
+		{name: "clamped to the max", failures: 9, delay: 60 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo := newLoginUseCase(t, loginConfig(), tt.failures, 1)
+			repo.EXPECT().DelayLogin(mock.Anything, "reader", tt.delay).Return(nil)
+
+			response := uc.Auth(context.Background(), wrongPassword)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func TestAuth_LoginDelayShiftIsCapped(t *testing.T) {
+	cfg := loginConfig()
+	cfg.LoginLockoutThreshold = 1000
+
+	// without the cap the shift overflows and the delay drops to zero
+	uc, repo := newLoginUseCase(t, cfg, 500, 1)
+	repo.EXPECT().DelayLogin(mock.Anything, "reader", 60*time.Second).Return(nil)
+
+	response := uc.Auth(context.Background(), wrongPassword)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestAuth_LoginNotDelayedBeforeThreshold(t *testing.T) {
+	// the mock fails on any DelayLogin, LockLogin or CreateAuditEvent call
+	uc, _ := newLoginUseCase(t, loginConfig(), 2, 1)
+
+	response := uc.Auth(context.Background(), wrongPassword)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestAuth_LoginLockedAtThreshold(t *testing.T) {
+	uc, repo := newLoginUseCase(t, loginConfig(), 10, 1)
+	repo.EXPECT().LockLogin(mock.Anything, "reader", 900*time.Second).Return(nil)
+	repo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event *model.AuthAuditEvent) bool {
+		return event.Event == schema.AuditLoginLocked && event.Username == "reader"
+	})).Return(nil)
+
+	response := uc.Auth(context.Background(), wrongPassword)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestAuth_LoginIPBlockedAtThreshold(t *testing.T) {
+	uc, repo := newLoginUseCase(t, loginConfig(), 1, 5)
+	repo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event *model.AuthAuditEvent) bool {
+		return event.Event == schema.AuditLoginIPBlocked && event.IP == "10.0.0.1"
+	})).Return(nil).Once()
+
+	response := uc.Auth(context.Background(), wrongPassword)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestAuth_LoginIPNotBlockedBelowThreshold(t *testing.T) {
+	uc, _ := newLoginUseCase(t, loginConfig(), 1, 4)
+
+	response := uc.Auth(context.Background(), wrongPassword)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// oidcUsernameMaxLength leaves room in the username column for the suffix of a taken username.
+const oidcUsernameMaxLength = 27
+
+// OIDCLogin starts a login at the identity provider, with a state, a nonce and a PKCE code
+// verifier kept until the callback.
+func (u *UseCase) OIDCLogin(ctx context.Context, req *schema.OIDCLoginRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "OIDCLogin")
+
+	if u.OIDC == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "OpenID Connect login is not enabled", nil)
+	}
+
+	authReq, err := authentication.NewOIDCAuthRequest()
+	if err != nil {
+		l.Error("failed to generate login state", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to start login", nil)
+	}
+	if err := u.Repository.SaveOIDCRequest(ctx, authReq, time.Duration(u.Config.OIDCStateTTL)*time.Second); err != nil {
+		l.Error("failed to save login state", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to start login", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.OIDCLoginResponse{
+		AuthorizationURL: u.OIDC.AuthCodeURL(authReq),
+		State:            authReq.State,
+	})
+}
+
+// OIDCCallback completes a login at the identity provider. The user linked to the identity is
+// signed in, otherwise the identity is linked to the user with its verified email or a user is
+// created for it, as the config allows. Users with two-factor authentication get a challenge
+// token as they do on a password login.
+func (u *UseCase) OIDCCallback(ctx context.Context, req *schema.OIDCCallbackRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "OIDCCallback")
+
+	if u.OIDC == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "OpenID Connect login is not enabled", nil)
+	}
+
+	// the state is consumed whatever the outcome, so a callback cannot be replayed
+	authReq, err := u.Repository.ConsumeOIDCRequest(ctx, req.State)
+	if err != nil {
+		l.Error("failed to get login state", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to complete login", nil)
+	}
+	if authReq == nil || subtle.ConstantTimeCompare([]byte(req.StateCookie), []byte(req.State)) != 1 {
+		l.Info("unknown, expired or foreign login state")
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid or expired login", nil)
+	}
+	if req.Error != "" {
+		l.Info("login refused by the identity provider", zap.String("error", req.Error), zap.String("description", req.ErrorDescription))
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "login refused by the identity provider", nil)
+	}
+
+	identity, err := u.OIDC.Exchange(ctx, req.Code, authReq)
+	if err != nil {
+		l.Warn("failed to exchange authorization code", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid login", nil)
+	}
+	l = l.With(zap.String("issuer", identity.Issuer), zap.String("subject", identity.Subject))
+
+	user, response, ok := u.oidcUser(ctx, l, identity)
+	if !ok {
+		return response
+	}
+
+	if user.TwoFactorEnabled() {
+		return u.challengeLogin(l, user)
+	}
+
+	token, refreshToken, err := u.createTokens(ctx, user, req.UserAgent, req.IP)
+	if err != nil {
+		l.Error("failed to generate token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate token", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthLoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
+
+// oidcUser returns the user of the identity, linking or creating it when needed.
+func (u *UseCase) oidcUser(ctx context.Context, l *zap.Logger, identity *authentication.OIDCIdentity) (*model.User, wrapper.JSONResult, bool) {
+	failed := wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to complete login", nil)
+
+	link, err := u.Repository.GetIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		l.Error("failed to get identity", zap.Error(err))
+		return nil, failed, false
+	}
+	if link != nil {
+		user, err := u.Repository.GetByID(ctx, link.UserID)
+		if err != nil {
+			l.Error("failed to get user", zap.Error(err))
+			return nil, failed, false
+		}
+		if user == nil {
+			return nil, wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid login", nil), false
+		}
+
+		if err := u.Repository.TouchIdentity(ctx, identity.Issuer, identity.Subject); err != nil {
+			l.Error("failed to record identity login", zap.Error(err))
+		}
+		return user, wrapper.JSONResult{}, true
+	}
+
+	now := time.Now()
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	newLink := &model.UserIdentity{
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: &now,
+	}
+
+	if email != "" {
+		user, err := u.Repository.GetByEmail(ctx, email)
+		if err != nil {
+			l.Error("failed to get user", zap.Error(err))
+			return nil, failed, false
+		}
+		if user != nil {
+			// an unverified email could be set by anyone at the provider to take over the user
+			if !u.Config.OIDCLinkByEmail || !identity.EmailVerified {
+				l.Info("identity not linked to the user with its email")
+				return nil, wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "An account with this email already exists", nil), false
+			}
+
+			newLink.UserID = user.ID
+			if err := u.Repository.LinkIdentity(ctx, newLink); err != nil {
+				l.Error("failed to link identity", zap.Error(err))
+				return nil, failed, false
+			}
+			u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditOIDCLinked, UserID: user.ID, Username: user.Username, Detail: identity.Issuer})
+			return user, wrapper.JSONResult{}, true
+		}
+	}
+
+	if !u.Config.OIDCAutoProvision {
+		l.Info("no user linked to the identity")
+		return nil, wrapper.ResponseFailed(http.StatusForbidden, contract.StatusCodeForbidden, "No account is linked to this identity", nil), false
+	}
+
+	user, err := u.provisionUser(ctx, identity, email, newLink)
+	if err != nil {
+		l.Error("failed to provision user", zap.Error(err))
+		return nil, failed, false
+	}
+	l.Info("user provisioned", zap.String("userId", user.ID))
+	u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditOIDCProvisioned, UserID: user.ID, Username: user.Username, Detail: identity.Issuer})
+	return user, wrapper.JSONResult{}, true
+}
+
+// provisionUser creates a member for the identity. The user has a random password, they can set
+// one with the password reset flow.
+func (u *UseCase) provisionUser(ctx context.Context, identity *authentication.OIDCIdentity, email string, link *model.UserIdentity) (*model.User, error) {
+	username, err := u.oidcUsername(ctx, identity, email)
+	if err != nil {
+		return nil, err
+	}
+
+	password, _, err := authentication.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := authentication.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		ID:          uuid.NewString(),
+		Username:    username,
+		Password:    hash,
+		DisplayName: truncate(identity.Name, 100),
+		CreatedAt:   link.CreatedAt,
+		UpdatedAt:   link.CreatedAt,
+	}
+	if email != "" {
+		user.Email = &email
+		if identity.EmailVerified {
+			user.EmailVerifiedAt = &link.CreatedAt
+		}
+	}
+
+	link.UserID = user.ID
+	return u.Repository.ProvisionUser(ctx, user, middleware.RoleMember, link)
+}
+
+// oidcUsername derives a free username from the preferred username or the email of the identity,
+// a random suffix is added while it is taken.
+func (u *UseCase) oidcUsername(ctx context.Context, identity *authentication.OIDCIdentity, email string) (string, error) {
+	base := strings.TrimSpace(identity.Username)
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	if base == "" {
+		base = "user"
+	}
+	base = truncate(base, oidcUsernameMaxLength)
+
+	username := base
+	for range 5 {
+		user, err := u.Repository.GetByUsername(ctx, username)
+		if err != nil {
+			return "", err
+		}
+		if user == nil {
+			return username, nil
+		}
+
+		suffix := make([]byte, 4)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		username = base + "-" + hex.EncodeToString(suffix)
+	}
+	return "", errors.New("no free username for " + base)
+}
+
+// truncate cuts s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestForgotPassword_UnknownUserIsAccepted(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().ThrottlePasswordReset(ctx, "nobody", "", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	repo.EXPECT().GetByUsername(ctx, "nobody").Return(nil, nil)
+
+	response := uc.ForgotPassword(ctx, &schema.PasswordForgotRequest{Username: "nobody"})
+
+	assert.Equal(t, http.StatusAccepted, response.Code)
+}
+
+func TestForgotPassword_ThrottledRequestIsRefused(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().ThrottlePasswordReset(ctx, "reader", "10.0.0.1", mock.Anything, mock.Anything, mock.Anything).Return(90*time.Second, nil)
+
+	response := uc.ForgotPassword(ctx, &schema.PasswordForgotRequest{Username: "reader", IP: "10.0.0.1"})
+
+	assert.Equal(t, http.StatusTooManyRequests, response.Code)
+	assert.Equal(t, schema.AuthLoginThrottledResponse{RetryAfter: 90}, response.Data)
+}
+
+func TestForgotPassword_ThrottlingFailsOpen(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().ThrottlePasswordReset(ctx, "nobody", "", mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("redis down"))
+	repo.EXPECT().GetByUsername(ctx, "nobody").Return(nil, nil)
+
+	response := uc.ForgotPassword(ctx, &schema.PasswordForgotRequest{Username: "nobody"})
+
+	assert.Equal(t, http.StatusAccepted, response.Code)
+}
+
+func TestForgotPassword_SendsTokenInTheBackground(t *testing.T) {
+	uc, repo, notify := newProfileUseCase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := make(chan notifier.Message, 1)
+	workerCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go func() { _ = uc.RunPasswordResets(workerCtx) }()
+
+	repo.EXPECT().ThrottlePasswordReset(ctx, "reader", "", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	repo.EXPECT().GetByUsername(ctx, "reader").Return(&model.User{ID: "user-1", Email: utils.ToPointer("reader@example.com")}, nil)
+	repo.EXPECT().CreatePasswordReset(mock.Anything, "user-1", mock.Anything, mock.Anything).Return(nil)
+	notify.EXPECT().Notify(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, msg notifier.Message) error {
+		sent <- msg
+		return nil
+	})
+
+	response := uc.ForgotPassword(ctx, &schema.PasswordForgotRequest{Username: "reader"})
+	// the request is over, the token is still sent
+	cancel()
+
+	assert.Equal(t, http.StatusAccepted, response.Code)
+	select {
+	case msg := <-sent:
+		assert.Equal(t, notifier.KindPasswordReset, msg.Kind)
+		assert.Equal(t, "reader@example.com", msg.To)
+	case <-time.After(time.Second):
+		t.Fatal("reset token was not sent")
+	}
+}
@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/internal/user/usecase"
+	repository "github.com/Alwanly/go-codebase/mocks/internal_/user/repository"
+	notifier_mocks "github.com/Alwanly/go-codebase/mocks/pkg/notifier"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var profileUser = &middleware.AuthUserData{UserID: "user-1", Roles: []string{middleware.RoleMember}}
+
+func newProfileUseCase(t *testing.T) (usecase.IUseCase, *repository.MockIRepository, *notifier_mocks.MockINotifier) {
+	repo := repository.NewMockIRepository(t)
+	notify := notifier_mocks.NewMockINotifier(t)
+	return usecase.NewUseCase(usecase.UseCase{
+		Config:     &config.GlobalConfig{EmailVerificationSecret: "secret", EmailVerificationTTL: 60},
+		Logger:     zap.NewNop(),
+		Notifier:   notify,
+		Repository: repo,
+	}), repo, notify
+}
+
+// existingUser returns a user with a complete, verified profile.
+func existingUser() *model.User {
+	return &model.User{
+		ID:              "user-1",
+		Username:        "reader",
+		DisplayName:     "Reader",
+		Email:           utils.ToPointer("reader@example.com"),
+		EmailVerifiedAt: utils.ToPointer(time.Now().Add(-time.Hour)),
+		Bio:             "Reads a lot",
+		AvatarURL:       "https://example.com/reader.png",
+	}
+}
+
+// updated captures the user passed to UpdateProfile.
+func updated(repo *repository.MockIRepository) *model.User {
+	user := &model.User{}
+	repo.EXPECT().UpdateProfile(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, u *model.User) error {
+		*user = *u
+		return nil
+	})
+	return user
+}
+
+func TestUpdateProfile_PatchKeepsMissingFields(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, "user-1").Return(existingUser(), nil)
+	repo.EXPECT().GetRoles(ctx, "user-1").Return([]string{middleware.RoleMember}, nil, nil)
+	user := updated(repo)
+
+	response := uc.UpdateProfile(ctx, &schema.ProfileUpdateRequest{DisplayName: utils.ToPointer("  New name "), AuthUserData: profileUser})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "New name", user.DisplayName)
+	assert.Equal(t, "Reads a lot", user.Bio)
+	assert.Equal(t, "https://example.com/reader.png", user.AvatarURL)
+	assert.Equal(t, "reader@example.com", *user.Email)
+	assert.NotNil(t, user.EmailVerifiedAt)
+}
+
+func TestUpdateProfile_ReplaceClearsMissingFields(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, "user-1").Return(existingUser(), nil)
+	repo.EXPECT().GetRoles(ctx, "user-1").Return([]string{middleware.RoleMember}, nil, nil)
+	user := updated(repo)
+
+	response := uc.UpdateProfile(ctx, &schema.ProfileUpdateRequest{Bio: utils.ToPointer("Bio"), Replace: true, AuthUserData: profileUser})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "Bio", user.Bio)
+	assert.Empty(t, user.DisplayName)
+	assert.Empty(t, user.AvatarURL)
+	assert.Nil(t, user.Email)
+	assert.Nil(t, user.EmailVerifiedAt)
+}
+
+func TestUpdateProfile_EmailChangeResetsVerification(t *testing.T) {
+	uc, repo, notify := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, "user-1").Return(existingUser(), nil)
+	repo.EXPECT().EmailTaken(ctx, "new@example.com", "user-1").Return(false, nil)
+	repo.EXPECT().GetRoles(ctx, "user-1").Return([]string{middleware.RoleMember}, nil, nil)
+	user := updated(repo)
+	notify.EXPECT().Notify(ctx, mock.MatchedBy(func(msg notifier.Message) bool {
+		return msg.Kind == notifier.KindEmailVerification && msg.To == "new@example.com"
+	})).Return(nil)
+
+	response := uc.UpdateProfile(ctx, &schema.ProfileUpdateRequest{Email: utils.ToPointer(" New@Example.com "), AuthUserData: profileUser})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "new@example.com", *user.Email)
+	assert.Nil(t, user.EmailVerifiedAt)
+	assert.False(t, response.Data.(schema.ProfileResponse).EmailVerified)
+}
+
+func TestUpdateProfile_SameEmailKeepsVerification(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, "user-1").Return(existingUser(), nil)
+	repo.EXPECT().EmailTaken(ctx, "reader@example.com", "user-1").Return(false, nil)
+	repo.EXPECT().GetRoles(ctx, "user-1").Return([]string{middleware.RoleMember}, nil, nil)
+	user := updated(repo)
+
+	response := uc.UpdateProfile(ctx, &schema.ProfileUpdateRequest{Email: utils.ToPointer("Reader@example.com"), AuthUserData: profileUser})
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotNil(t, user.EmailVerifiedAt)
+}
+
+func TestUpdateProfile_EmailTaken(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, "user-1").Return(existingUser(), nil)
+	repo.EXPECT().EmailTaken(ctx, "other@example.com", "user-1").Return(true, nil)
+
+	response := uc.UpdateProfile(ctx, &schema.ProfileUpdateRequest{Email: utils.ToPointer("other@example.com"), AuthUserData: profileUser})
+
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
+func TestProfileUpdateRequest_AvatarURL(t *testing.T) {
+	v, err := validator.NewValidator()
+	require.NoError(t, err)
+
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{url: "https://example.com/avatar.png", valid: true},
+		{url: "http://example.com/avatar.png", valid: true},
+		{url: "", valid: true},
+		{url: "javascript:alert(1)"},
+		{url: "data:image/png;base64,AAAA"},
+		{url: "ftp://example.com/avatar.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := v.ValidateStruct(&schema.ProfileUpdateRequest{AvatarURL: utils.ToPointer(tt.url)})
+			assert.Equal(t, tt.valid, err == nil, err)
+		})
+	}
+}
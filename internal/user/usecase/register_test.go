@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegister_WithoutEmail(t *testing.T) {
+	uc, repo, _ := newProfileUseCase(t)
+	ctx := context.Background()
+
+	// no email check and no verification mail, the email is stored as null
+	repo.EXPECT().Register(ctx, mock.MatchedBy(func(user *model.User) bool {
+		return user.Username == "reader" && user.Email == nil
+	}), mock.Anything).RunAndReturn(func(_ context.Context, user *model.User, _ string) (*model.User, error) {
+		return user, nil
+	})
+	// stops before the tokens are signed
+	repo.EXPECT().GetRoles(ctx, mock.Anything).Return(nil, nil, errors.New("connection refused"))
+
+	response := uc.Register(ctx, &schema.AuthRegisterRequest{Username: "reader", Password: "secret"})
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRegister_NormalizesEmail(t *testing.T) {
+	uc, repo, notify := newProfileUseCase(t)
+	ctx := context.Background()
+
+	repo.EXPECT().EmailTaken(ctx, "reader@example.com", "").Return(false, nil)
+	repo.EXPECT().Register(ctx, mock.MatchedBy(func(user *model.User) bool {
+		return user.Email != nil && *user.Email == "reader@example.com"
+	}), mock.Anything).RunAndReturn(func(_ context.Context, user *model.User, _ string) (*model.User, error) {
+		return user, nil
+	})
+	notify.EXPECT().Notify(ctx, mock.Anything).Return(nil)
+	repo.EXPECT().GetRoles(ctx, mock.Anything).Return(nil, nil, errors.New("connection refused"))
+
+	response := uc.Register(ctx, &schema.AuthRegisterRequest{Username: "reader", Password: "secret", Email: " Reader@Example.com "})
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
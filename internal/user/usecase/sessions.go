@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+)
+
+// ListSessions returns the devices the user is logged in on, the most recently used first.
+func (u *UseCase) ListSessions(ctx context.Context, req *schema.SessionListRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ListSessions")
+
+	sessions, err := u.Repository.ListSessions(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to list sessions", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to list sessions", nil)
+	}
+
+	items := make([]schema.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		items = append(items, toSession(session, req.AuthUserData.Family))
+	}
+	return wrapper.ResponseSuccess(http.StatusOK, items)
+}
+
+// RevokeSession signs the user out of one of their devices, the current one included.
+func (u *UseCase) RevokeSession(ctx context.Context, req *schema.SessionRevokeRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "RevokeSession").With(zap.String("sessionId", req.ID))
+	failed := wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to revoke session", nil)
+
+	found, err := u.hasSession(ctx, req.AuthUserData.UserID, req.ID)
+	if err != nil {
+		l.Error("failed to list sessions", zap.Error(err))
+		return failed
+	}
+	if !found {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Session not found", nil)
+	}
+
+	// the tokens are revoked before the session is marked, so a failure can be retried
+	if err := u.revokeSessionTokens(ctx, []string{req.ID}); err != nil {
+		l.Error("failed to revoke session tokens", zap.Error(err))
+		return failed
+	}
+	if _, err := u.Repository.RevokeSession(ctx, req.AuthUserData.UserID, req.ID); err != nil {
+		l.Error("failed to revoke session", zap.Error(err))
+		return failed
+	}
+
+	l.Info("session revoked", zap.String("userId", req.AuthUserData.UserID))
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.SessionRevokeResponse{})
+}
+
+// RevokeOtherSessions signs the user out of every device but the one of the request.
+func (u *UseCase) RevokeOtherSessions(ctx context.Context, req *schema.SessionRevokeOthersRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "RevokeOtherSessions")
+	failed := wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to revoke sessions", nil)
+
+	sessions, err := u.Repository.ListSessions(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to list sessions", zap.Error(err))
+		return failed
+	}
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ID != req.AuthUserData.Family {
+			ids = append(ids, session.ID)
+		}
+	}
+
+	if err := u.revokeSessionTokens(ctx, ids); err != nil {
+		l.Error("failed to revoke session tokens", zap.Error(err))
+		return failed
+	}
+	if err := u.Repository.RevokeSessions(ctx, req.AuthUserData.UserID, req.AuthUserData.Family); err != nil {
+		l.Error("failed to revoke sessions", zap.Error(err))
+		return failed
+	}
+
+	l.Info("other sessions revoked", zap.String("userId", req.AuthUserData.UserID), zap.Int("revoked", len(ids)))
+	return wrapper.ResponseSuccess(http.StatusOK, schema.SessionRevokeOthersResponse{Revoked: len(ids)})
+}
+
+// createSession records the session of a new login, keyed by its token family.
+func (u *UseCase) createSession(ctx context.Context, user *model.User, family string, userAgent string, ip string) error {
+	now := time.Now()
+	return u.Repository.CreateSession(ctx, &model.UserSession{
+		ID:         family,
+		UserID:     user.ID,
+		UserAgent:  truncate(userAgent, 512),
+		IP:         truncate(ip, 45),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(u.refreshTTL()),
+	})
+}
+
+// hasSession reports whether the session is an active session of the user.
+func (u *UseCase) hasSession(ctx context.Context, userID string, id string) (bool, error) {
+	sessions, err := u.Repository.ListSessions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if session.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// revokeSessionTokens stops the refresh tokens of the sessions and revokes their access tokens
+// until they expire.
+func (u *UseCase) revokeSessionTokens(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := u.Repository.RevokeTokenFamily(ctx, id); err != nil {
+			return err
+		}
+		if err := u.Revocation.RevokeFamily(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeUser revokes every token of the user and ends their sessions.
+func (u *UseCase) revokeUser(ctx context.Context, l *zap.Logger, userID string) error {
+	if err := u.Revocation.RevokeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	// the tokens are revoked already, the sessions are only listed
+	if err := u.Repository.RevokeSessions(ctx, userID, ""); err != nil {
+		l.Error("failed to revoke sessions", zap.Error(err))
+	}
+	return nil
+}
+
+func toSession(session model.UserSession, current string) schema.SessionResponse {
+	return schema.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == current,
+	}
+}
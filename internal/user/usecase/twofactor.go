@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/user/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+)
+
+// totpCodeTTL covers every period a TOTP code is accepted in, a used code is remembered as long.
+const totpCodeTTL = (2*authentication.TOTPSkew + 2) * authentication.TOTPPeriod
+
+// challengeLogin returns a challenge token in place of the tokens of a user with two-factor
+// authentication, it proves the password was verified.
+func (u *UseCase) challengeLogin(l *zap.Logger, user *model.User) wrapper.JSONResult {
+	ttl := time.Duration(u.Config.TOTPChallengeTTL) * time.Second
+	token, err := u.Jwt.GenerateChallengeToken(authentication.JWTClaims{
+		"userId":   user.ID,
+		"username": user.Username,
+	}, ttl)
+	if err != nil {
+		l.Error("failed to generate challenge token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate token", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthLoginChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int(ttl / time.Second),
+	})
+}
+
+// VerifyTwoFactor exchanges the challenge token of a login and a TOTP or recovery code for the
+// tokens. Wrong codes count as failed logins of the user, so they are throttled and lock the user
+// the same way.
+func (u *UseCase) VerifyTwoFactor(ctx context.Context, req *schema.TwoFactorVerifyRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "VerifyTwoFactor")
+
+	claims, err := u.Jwt.ParseToken(req.ChallengeToken)
+	if err != nil || claims.String(authentication.ClaimType) != authentication.TokenTypeChallenge {
+		l.Info("invalid challenge token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid challenge token", nil)
+	}
+	revoked, err := u.Revocation.IsRevoked(ctx, *claims)
+	if err != nil && !u.Config.JwtRevocationFailOpen {
+		l.Error("failed to check token revocation", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusServiceUnavailable, contract.StatusCodeInternalServerError, "failed to verify code", nil)
+	}
+	if revoked {
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid challenge token", nil)
+	}
+
+	attempt := &schema.AuthLoginRequest{Username: claims.String("username"), IP: req.IP}
+	l = l.With(zap.String("userId", claims.String("userId")))
+	if response, throttled := u.throttleLogin(ctx, l, attempt); throttled {
+		return response
+	}
+
+	user, err := u.Repository.GetByID(ctx, claims.String("userId"))
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to verify code", nil)
+	}
+	if user == nil || !user.TwoFactorEnabled() {
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid challenge token", nil)
+	}
+
+	valid, err := u.verifySecondFactor(ctx, l, user, req.Code)
+	if err != nil {
+		l.Error("failed to verify code", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to verify code", nil)
+	}
+	if !valid {
+		l.Info("two-factor code invalid")
+		u.loginFailed(ctx, l, attempt, user.ID)
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "invalid code", nil)
+	}
+
+	// the challenge is used once
+	if err := u.Revocation.RevokeToken(ctx, claims.String(authentication.ClaimTokenID), claims.Time("exp")); err != nil {
+		l.Error("failed to revoke challenge token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to verify code", nil)
+	}
+	if err := u.Repository.ClearLoginFailures(ctx, user.Username); err != nil {
+		l.Error("failed to clear login failures", zap.Error(err))
+	}
+
+	token, refreshToken, err := u.createTokens(ctx, user, req.UserAgent, req.IP)
+	if err != nil {
+		l.Error("failed to generate token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate token", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthLoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
+
+// EnrollTwoFactor generates a TOTP secret for the user, replacing a pending one. Two-factor
+// authentication is enabled once a code of the secret is confirmed.
+func (u *UseCase) EnrollTwoFactor(ctx context.Context, req *schema.TwoFactorEnrollRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "EnrollTwoFactor")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to enroll", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+	if user.TwoFactorEnabled() {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is already enabled", nil)
+	}
+
+	key, err := authentication.GenerateTOTPKey(u.Config.TOTPIssuer, user.Username)
+	if err != nil {
+		l.Error("failed to generate totp secret", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to enroll", nil)
+	}
+
+	set, err := u.Repository.SetTOTPSecret(ctx, user.ID, key.Secret)
+	if err != nil {
+		l.Error("failed to store totp secret", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to enroll", nil)
+	}
+	if !set {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is already enabled", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.TwoFactorEnrollResponse{
+		Secret: key.Secret,
+		URI:    key.URI,
+		QRCode: key.QRCode,
+	})
+}
+
+// ConfirmTwoFactor enables two-factor authentication with a code of the pending secret and
+// returns the recovery codes.
+func (u *UseCase) ConfirmTwoFactor(ctx context.Context, req *schema.TwoFactorConfirmRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ConfirmTwoFactor")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to confirm", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+	if user.TwoFactorEnabled() {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is already enabled", nil)
+	}
+	if user.TOTPSecret == nil {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "No pending enrollment", nil)
+	}
+
+	valid, err := u.verifyTOTP(ctx, user, req.Code)
+	if err != nil {
+		l.Error("failed to verify code", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to confirm", nil)
+	}
+	if !valid {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "invalid code", nil)
+	}
+
+	codes, hashes, err := authentication.GenerateRecoveryCodes(u.Config.TOTPRecoveryCodes)
+	if err != nil {
+		l.Error("failed to generate recovery codes", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to confirm", nil)
+	}
+
+	enabled, err := u.Repository.EnableTOTP(ctx, user.ID, hashes)
+	if err != nil {
+		l.Error("failed to enable two-factor authentication", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to confirm", nil)
+	}
+	if !enabled {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is already enabled", nil)
+	}
+
+	u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditTwoFactorEnabled, UserID: user.ID, Username: user.Username, ActorID: user.ID})
+	return wrapper.ResponseSuccess(http.StatusOK, schema.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor disables two-factor authentication, it requires the password and a TOTP or
+// recovery code.
+func (u *UseCase) DisableTwoFactor(ctx context.Context, req *schema.TwoFactorDisableRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "DisableTwoFactor")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to disable", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+	if !user.TwoFactorEnabled() {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is not enabled", nil)
+	}
+
+	// wrong passwords and codes count as failed logins, so a stolen access token cannot be used to
+	// guess them
+	attempt := &schema.AuthLoginRequest{Username: user.Username}
+	if response, throttled := u.throttleLogin(ctx, l, attempt); throttled {
+		return response
+	}
+
+	if !authentication.VerifyPassword(req.Password, user.Password) {
+		l.Info("password invalid", zap.String("userId", user.ID))
+		u.loginFailed(ctx, l, attempt, user.ID)
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeUserOrPasswordInvalid, "password invalid", nil)
+	}
+	valid, err := u.verifySecondFactor(ctx, l, user, req.Code)
+	if err != nil {
+		l.Error("failed to verify code", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to disable", nil)
+	}
+	if !valid {
+		u.loginFailed(ctx, l, attempt, user.ID)
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "invalid code", nil)
+	}
+
+	if err := u.Repository.DisableTOTP(ctx, user.ID); err != nil {
+		l.Error("failed to disable two-factor authentication", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to disable", nil)
+	}
+
+	u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditTwoFactorDisabled, UserID: user.ID, Username: user.Username, ActorID: user.ID})
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.TwoFactorDisableResponse{})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user, it requires a TOTP code.
+func (u *UseCase) RegenerateRecoveryCodes(ctx context.Context, req *schema.TwoFactorRecoveryCodesRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "RegenerateRecoveryCodes")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to regenerate recovery codes", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+	if !user.TwoFactorEnabled() {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Two-factor authentication is not enabled", nil)
+	}
+
+	attempt := &schema.AuthLoginRequest{Username: user.Username}
+	if response, throttled := u.throttleLogin(ctx, l, attempt); throttled {
+		return response
+	}
+
+	valid, err := u.verifyTOTP(ctx, user, req.Code)
+	if err != nil {
+		l.Error("failed to verify code", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to regenerate recovery codes", nil)
+	}
+	if !valid {
+		u.loginFailed(ctx, l, attempt, user.ID)
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "invalid code", nil)
+	}
+
+	codes, hashes, err := authentication.GenerateRecoveryCodes(u.Config.TOTPRecoveryCodes)
+	if err != nil {
+		l.Error("failed to generate recovery codes", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to regenerate recovery codes", nil)
+	}
+	if err := u.Repository.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		l.Error("failed to store recovery codes", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to regenerate recovery codes", nil)
+	}
+
+	u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditRecoveryCodesRegenerated, UserID: user.ID, Username: user.Username, ActorID: user.ID})
+	return wrapper.ResponseSuccess(http.StatusOK, schema.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// verifySecondFactor checks a TOTP code, or a recovery code which is then used up.
+func (u *UseCase) verifySecondFactor(ctx context.Context, l *zap.Logger, user *model.User, code string) (bool, error) {
+	if isTOTPCode(code) {
+		return u.verifyTOTP(ctx, user, code)
+	}
+
+	used, err := u.Repository.UseRecoveryCode(ctx, user.ID, authentication.HashRecoveryCode(code))
+	if err != nil || !used {
+		return false, err
+	}
+	u.audit(ctx, l, &model.AuthAuditEvent{Event: schema.AuditRecoveryCodeUsed, UserID: user.ID, Username: user.Username})
+	return true, nil
+}
+
+// verifyTOTP checks a TOTP code of the secret of the user, each code is accepted once.
+func (u *UseCase) verifyTOTP(ctx context.Context, user *model.User, code string) (bool, error) {
+	if user.TOTPSecret == nil {
+		return false, nil
+	}
+
+	counter, valid := authentication.ValidateTOTP(code, *user.TOTPSecret, time.Now())
+	if !valid {
+		return false, nil
+	}
+	return u.Repository.UseTOTPCode(ctx, user.ID, counter, totpCodeTTL)
+}
+
+// isTOTPCode tells a TOTP code, six digits, from a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Alwanly/go-codebase/config"
@@ -13,6 +15,9 @@ import (
 	"github.com/Alwanly/go-codebase/pkg/authentication"
 	"github.com/Alwanly/go-codebase/pkg/contract"
 	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/Alwanly/go-codebase/pkg/utils"
 	"github.com/Alwanly/go-codebase/pkg/wrapper"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -25,13 +30,45 @@ type (
 		Config     *config.GlobalConfig
 		Logger     *zap.Logger
 		Jwt        authentication.IJwtService
+		Revocation authentication.IRevocationService
+		Notifier   notifier.INotifier
 		Repository repository.IRepository
+
+		// OIDC is nil when no identity provider is configured
+		OIDC authentication.IOIDCService
+
+		// passwordResets holds the users whose reset token is waiting to be sent
+		passwordResets chan *model.User
 	}
 
 	IUseCase interface {
 		Auth(ctx context.Context, req *schema.AuthLoginRequest) wrapper.JSONResult
 		Register(ctx context.Context, req *schema.AuthRegisterRequest) wrapper.JSONResult
+		Refresh(ctx context.Context, req *schema.AuthRefreshRequest) wrapper.JSONResult
+		Logout(ctx context.Context, req *schema.AuthLogoutRequest) wrapper.JSONResult
+		RevokeUserTokens(ctx context.Context, req *schema.RequestRevokeUserTokens) wrapper.JSONResult
+		UnlockUser(context.Context, *schema.RequestUnlockUser) wrapper.JSONResult
+		OIDCLogin(context.Context, *schema.OIDCLoginRequest) wrapper.JSONResult
+		OIDCCallback(context.Context, *schema.OIDCCallbackRequest) wrapper.JSONResult
+		VerifyTwoFactor(context.Context, *schema.TwoFactorVerifyRequest) wrapper.JSONResult
+		EnrollTwoFactor(context.Context, *schema.TwoFactorEnrollRequest) wrapper.JSONResult
+		ConfirmTwoFactor(context.Context, *schema.TwoFactorConfirmRequest) wrapper.JSONResult
+		DisableTwoFactor(context.Context, *schema.TwoFactorDisableRequest) wrapper.JSONResult
+		RegenerateRecoveryCodes(context.Context, *schema.TwoFactorRecoveryCodesRequest) wrapper.JSONResult
+		ListRoles(context.Context, *schema.RoleListRequest) wrapper.JSONResult
+		SetUserRoles(context.Context, *schema.UserRolesRequest) wrapper.JSONResult
 		Profile(context.Context, *schema.ProfileRequest) wrapper.JSONResult
+		UpdateProfile(context.Context, *schema.ProfileUpdateRequest) wrapper.JSONResult
+		ChangePassword(context.Context, *schema.PasswordChangeRequest) wrapper.JSONResult
+		ForgotPassword(context.Context, *schema.PasswordForgotRequest) wrapper.JSONResult
+		RunPasswordResets(ctx context.Context) error
+		ResetPassword(context.Context, *schema.PasswordResetRequest) wrapper.JSONResult
+		VerifyEmail(context.Context, *schema.EmailVerifyRequest) wrapper.JSONResult
+		ResendVerification(context.Context, *schema.EmailResendRequest) wrapper.JSONResult
+		GetUsers(context.Context, *schema.RequestUserBatch) wrapper.JSONResult
+		ListSessions(context.Context, *schema.SessionListRequest) wrapper.JSONResult
+		RevokeSession(context.Context, *schema.SessionRevokeRequest) wrapper.JSONResult
+		RevokeOtherSessions(context.Context, *schema.SessionRevokeOthersRequest) wrapper.JSONResult
 	}
 )
 
@@ -40,48 +77,185 @@ func NewUseCase(uc UseCase) IUseCase {
 		Config:     uc.Config,
 		Logger:     uc.Logger,
 		Jwt:        uc.Jwt,
+		Revocation: uc.Revocation,
+		Notifier:   uc.Notifier,
 		Repository: uc.Repository,
+		OIDC:       uc.OIDC,
+
+		passwordResets: make(chan *model.User, max(uc.Config.PasswordResetQueueSize, 1)),
 	}
 }
 
 func (u *UseCase) Auth(ctx context.Context, req *schema.AuthLoginRequest) wrapper.JSONResult {
-	l := logger.WithID(u.Logger, ContextName, "Auth")
+	l := logger.WithID(u.Logger, ContextName, "Auth").With(zap.String("clientId", middleware.ClientFromContext(ctx)))
+
+	if response, throttled := u.throttleLogin(ctx, l, req); throttled {
+		return response
+	}
 
 	user, err := u.Repository.Login(ctx, req.Username)
 	if err != nil {
 		l.Error("username not found", zap.Error(err))
+		u.loginFailed(ctx, l, req, "")
 		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeUserOrPasswordInvalid, "username or password invalid", nil)
 	}
 
 	if !authentication.VerifyPassword(req.Password, user.Password) {
 		l.Error("password invalid", zap.Error(err))
+		u.loginFailed(ctx, l, req, user.ID)
 		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeUserOrPasswordInvalid, "username or password invalid", nil)
 	}
 
-	dataClaims := make(authentication.JWTClaims)
+	// failures are cleared once the second factor is verified, so the password cannot reset the
+	// count of wrong codes
+	if user.TwoFactorEnabled() {
+		return u.challengeLogin(l, user)
+	}
 
-	dataClaims["userId"] = user.ID
-	token, err := u.Jwt.GenerateToken(dataClaims)
+	if err := u.Repository.ClearLoginFailures(ctx, req.Username); err != nil {
+		l.Error("failed to clear login failures", zap.Error(err))
+	}
 
+	token, refreshToken, err := u.createTokens(ctx, user, req.UserAgent, req.IP)
 	if err != nil {
 		l.Error("failed to generate token", zap.Error(err))
 		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate token", nil)
 	}
 
-	refreshToken, err := u.Jwt.RefreshToken(token)
-	if err != nil {
-		l.Error("failed to refresh token", zap.Error(err))
-		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to refresh token", nil)
-	}
-
 	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthLoginResponse{
 		Token:        token,
 		RefreshToken: refreshToken,
 	})
 }
 
+// throttleLogin refuses the login while the username is locked or delayed, or the IP failed too
+// often. Throttling fails open, a login is not refused because Redis cannot be reached.
+func (u *UseCase) throttleLogin(ctx context.Context, l *zap.Logger, req *schema.AuthLoginRequest) (wrapper.JSONResult, bool) {
+	retryAfter, locked, err := u.Repository.LoginBlockedFor(ctx, req.Username)
+	if err != nil {
+		l.Error("failed to check login lockout", zap.Error(err))
+		return wrapper.JSONResult{}, false
+	}
+	if locked {
+		return loginThrottled("account is temporarily locked", retryAfter), true
+	}
+	if retryAfter > 0 {
+		return loginThrottled("too many failed login attempts", retryAfter), true
+	}
+
+	if req.IP == "" {
+		return wrapper.JSONResult{}, false
+	}
+	failures, retryAfter, err := u.Repository.CountIPLoginFailures(ctx, req.IP, u.loginWindow())
+	if err != nil {
+		l.Error("failed to count login failures", zap.Error(err))
+		return wrapper.JSONResult{}, false
+	}
+	if failures >= int64(u.Config.LoginIPMaxFailures) {
+		return loginThrottled("too many failed login attempts", retryAfter), true
+	}
+	return wrapper.JSONResult{}, false
+}
+
+// loginFailed counts a failed login, delays the next attempt of the username once it failed
+// LoginDelayAfter times, doubling the delay at each failure, and locks it once it failed
+// LoginLockoutThreshold times.
+func (u *UseCase) loginFailed(ctx context.Context, l *zap.Logger, req *schema.AuthLoginRequest, userID string) {
+	userFailures, ipFailures, err := u.Repository.AddLoginFailure(ctx, req.Username, req.IP, u.loginWindow())
+	if err != nil {
+		l.Error("failed to count login failure", zap.Error(err))
+		return
+	}
+
+	switch {
+	case userFailures >= int64(u.Config.LoginLockoutThreshold):
+		duration := time.Duration(u.Config.LoginLockoutDuration) * time.Second
+		if err := u.Repository.LockLogin(ctx, req.Username, duration); err != nil {
+			l.Error("failed to lock login", zap.Error(err))
+			return
+		}
+		l.Warn("login locked", zap.String("username", req.Username), zap.Int64("failures", userFailures))
+		u.audit(ctx, l, &model.AuthAuditEvent{
+			Event:    schema.AuditLoginLocked,
+			UserID:   userID,
+			Username: req.Username,
+			IP:       req.IP,
+			Detail:   fmt.Sprintf("%d failed logins, locked for %s", userFailures, duration),
+		})
+	case userFailures >= int64(u.Config.LoginDelayAfter):
+		// the shift is capped so it cannot overflow, LoginDelayMax caps the delay anyway
+		shift := min(userFailures-int64(u.Config.LoginDelayAfter), 30)
+		delay := min(time.Duration(u.Config.LoginDelayBase)*time.Second<<shift, time.Duration(u.Config.LoginDelayMax)*time.Second)
+		if err := u.Repository.DelayLogin(ctx, req.Username, delay); err != nil {
+			l.Error("failed to delay login", zap.Error(err))
+		}
+	}
+
+	if u.Config.LoginIPMaxFailures > 0 && ipFailures == int64(u.Config.LoginIPMaxFailures) {
+		l.Warn("login blocked for IP", zap.String("ip", req.IP))
+		u.audit(ctx, l, &model.AuthAuditEvent{
+			Event:    schema.AuditLoginIPBlocked,
+			Username: req.Username,
+			IP:       req.IP,
+			Detail:   fmt.Sprintf("%d failed logins", ipFailures),
+		})
+	}
+}
+
+// UnlockUser lifts the login lockout and delay of a user.
+func (u *UseCase) UnlockUser(ctx context.Context, req *schema.RequestUnlockUser) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "UnlockUser")
+
+	user, err := u.Repository.GetByID(ctx, req.ID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to unlock user", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	locked, err := u.Repository.UnlockLogin(ctx, user.Username)
+	if err != nil {
+		l.Error("failed to unlock user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to unlock user", nil)
+	}
+
+	if locked {
+		u.audit(ctx, l, &model.AuthAuditEvent{
+			Event:    schema.AuditLoginUnlocked,
+			UserID:   user.ID,
+			Username: user.Username,
+			ActorID:  req.AuthUserData.UserID,
+		})
+	}
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.ResponseUnlockUser{})
+}
+
+// audit writes an event to the audit log, a failure is logged and does not fail the request.
+func (u *UseCase) audit(ctx context.Context, l *zap.Logger, event *model.AuthAuditEvent) {
+	event.ID = uuid.NewString()
+	event.ClientID = middleware.ClientFromContext(ctx)
+	event.CreatedAt = time.Now()
+	if err := u.Repository.CreateAuditEvent(ctx, event); err != nil {
+		l.Error("failed to write audit event", zap.String("event", event.Event), zap.Error(err))
+	}
+}
+
+// loginThrottled refuses a throttled login or password reset, the caller may retry after the
+// duration.
+func loginThrottled(message string, retryAfter time.Duration) wrapper.JSONResult {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	return wrapper.ResponseFailed(http.StatusTooManyRequests, contract.StatusCodeTooManyRequests, message,
+		schema.AuthLoginThrottledResponse{RetryAfter: max(seconds, 1)})
+}
+
+func (u *UseCase) loginWindow() time.Duration {
+	return time.Duration(u.Config.LoginWindow) * time.Second
+}
+
 func (u *UseCase) Register(ctx context.Context, req *schema.AuthRegisterRequest) wrapper.JSONResult {
-	l := logger.WithID(u.Logger, ContextName, "Register")
+	l := logger.WithID(u.Logger, ContextName, "Register").With(zap.String("clientId", middleware.ClientFromContext(ctx)))
 
 	hash, err := authentication.HashPassword(req.Password)
 	if err != nil {
@@ -95,43 +269,721 @@ func (u *UseCase) Register(ctx context.Context, req *schema.AuthRegisterRequest)
 		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate uuid", nil)
 	}
 
+	// the email is optional, a missing one is stored as null
+	var email *string
+	if normalized := strings.ToLower(strings.TrimSpace(req.Email)); normalized != "" {
+		taken, err := u.Repository.EmailTaken(ctx, normalized, "")
+		if err != nil {
+			l.Error("failed to check email", zap.Error(err))
+			return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to register", nil)
+		}
+		if taken {
+			return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Email is already in use", nil)
+		}
+		email = &normalized
+	}
+
 	now := time.Now()
 	model := &model.User{
 		ID:        id.String(),
 		Username:  req.Username,
 		Password:  hash,
+		Email:     email,
 		CreatedAt: now,
 	}
-	user, err := u.Repository.Register(ctx, model)
+	user, err := u.Repository.Register(ctx, model, middleware.RoleMember)
 	if err != nil {
 		l.Error("failed to register", zap.Error(err))
 		return wrapper.ResponseFailed(http.StatusBadRequest, contract.CreateStatusCode("00001"), "failed to register", nil)
 	}
+	l.Info("user registered", zap.String("userId", user.ID))
 
-	dataClaims := make(authentication.JWTClaims)
-
-	dataClaims["userId"] = user.ID
-	token, err := u.Jwt.GenerateToken(dataClaims)
+	// the account works without a verified email, only the routes of the policy require one
+	if user.Email != nil {
+		if err := u.sendVerification(ctx, user); err != nil {
+			l.Error("failed to send verification email", zap.Error(err))
+		}
+	}
 
+	token, refreshToken, err := u.createTokens(ctx, user, req.UserAgent, req.IP)
 	if err != nil {
 		l.Error("failed to generate token", zap.Error(err))
 		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to generate token", nil)
 	}
 
-	refreshToken, err := u.Jwt.RefreshToken(token)
+	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthRegisterResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token of the same
+// family. Each refresh token can be used once, presenting one that was already rotated revokes the
+// whole family so a stolen token stops working for both the thief and the user.
+func (u *UseCase) Refresh(ctx context.Context, req *schema.AuthRefreshRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Refresh")
+
+	claims, err := u.Jwt.ParseToken(req.RefreshToken)
+	if err != nil || claims.String(authentication.ClaimType) != authentication.TokenTypeRefresh {
+		l.Error("invalid refresh token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid refresh token", nil)
+	}
+	family := claims.String(authentication.ClaimFamily)
+	tokenID := claims.String(authentication.ClaimTokenID)
+	userID := claims.String("userId")
+	l = l.With(zap.String("family", family), zap.String("userId", userID))
+
+	revoked, err := u.Revocation.IsRevoked(ctx, *claims)
+	if err != nil && !u.Config.JwtRevocationFailOpen {
+		l.Error("failed to check token revocation", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusServiceUnavailable, contract.StatusCodeInternalServerError, "failed to refresh token", nil)
+	}
+	if revoked {
+		l.Info("refresh token revoked")
+		_ = u.Repository.RevokeTokenFamily(ctx, family)
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid refresh token", nil)
+	}
+
+	current, found, err := u.Repository.GetTokenFamily(ctx, family)
 	if err != nil {
-		l.Error("failed to refresh token", zap.Error(err))
-		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCode("00000"), "failed to refresh token", nil)
+		l.Error("failed to get token family", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to refresh token", nil)
+	}
+	if !found {
+		l.Info("refresh token of a revoked or expired family")
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid refresh token", nil)
+	}
+	if current != tokenID {
+		return u.revokeTokenFamily(ctx, l, userID, family)
 	}
 
-	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthRegisterResponse{
+	users, err := u.Repository.GetByIDs(ctx, []string{userID})
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to refresh token", nil)
+	}
+	if len(users) == 0 {
+		l.Info("user of the refresh token no longer exists")
+		_ = u.Repository.RevokeTokenFamily(ctx, family)
+		return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid refresh token", nil)
+	}
+
+	nextTokenID := uuid.NewString()
+	token, refreshToken, err := u.signTokens(ctx, &users[0], family, nextTokenID)
+	if err != nil {
+		l.Error("failed to generate token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to generate token", nil)
+	}
+
+	rotated, err := u.Repository.RotateTokenFamily(ctx, family, tokenID, nextTokenID, u.refreshTTL())
+	if err != nil {
+		l.Error("failed to rotate token family", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to refresh token", nil)
+	}
+	if !rotated {
+		// another request rotated the same token first
+		return u.revokeTokenFamily(ctx, l, userID, family)
+	}
+	if err := u.Repository.ExtendSession(ctx, family, time.Now().Add(u.refreshTTL())); err != nil {
+		l.Error("failed to extend session", zap.Error(err))
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.AuthRefreshResponse{
 		Token:        token,
 		RefreshToken: refreshToken,
 	})
 }
 
-func (u *UseCase) Profile(_ context.Context, req *schema.ProfileRequest) wrapper.JSONResult {
+// Logout revokes the access token of the request until it expires, and ends its session with the
+// tokens issued with it.
+func (u *UseCase) Logout(ctx context.Context, req *schema.AuthLogoutRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Logout")
+
+	if err := u.Revocation.RevokeToken(ctx, req.AuthUserData.TokenID, req.AuthUserData.ExpiresAt); err != nil {
+		l.Error("failed to revoke token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to logout", nil)
+	}
+
+	if req.AuthUserData.Family != "" {
+		if err := u.revokeSessionTokens(ctx, []string{req.AuthUserData.Family}); err != nil {
+			l.Error("failed to revoke token family", zap.Error(err))
+			return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to logout", nil)
+		}
+		if _, err := u.Repository.RevokeSession(ctx, req.AuthUserData.UserID, req.AuthUserData.Family); err != nil {
+			l.Error("failed to revoke session", zap.Error(err))
+		}
+	}
+
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.AuthLogoutResponse{})
+}
+
+// RevokeUserTokens revokes every access and refresh token issued to the user so far, the user has
+// to log in again.
+func (u *UseCase) RevokeUserTokens(ctx context.Context, req *schema.RequestRevokeUserTokens) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "RevokeUserTokens")
+
+	users, err := u.Repository.GetByIDs(ctx, []string{req.ID})
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to revoke tokens", nil)
+	}
+	if len(users) == 0 {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	if err := u.revokeUser(ctx, l, req.ID); err != nil {
+		l.Error("failed to revoke tokens", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to revoke tokens", nil)
+	}
+
+	l.Info("user tokens revoked", zap.String("userId", req.ID), zap.String("revokedBy", req.AuthUserData.UserID))
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.ResponseRevokeUserTokens{})
+}
+
+// ListRoles returns the roles and the permissions they grant.
+func (u *UseCase) ListRoles(ctx context.Context, req *schema.RoleListRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ListRoles")
+
+	roles, grants, err := u.Repository.ListRoles(ctx)
+	if err != nil {
+		l.Error("failed to list roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to list roles", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, toRoles(roles, grants))
+}
+
+// SetUserRoles replaces the roles of a user. Tokens carry the roles, when a role is removed every
+// token of the user is revoked so the permissions it granted stop working at once, added roles
+// apply from the next login or refresh.
+func (u *UseCase) SetUserRoles(ctx context.Context, req *schema.UserRolesRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "SetUserRoles")
+
+	known, _, err := u.Repository.ListRoles(ctx)
+	if err != nil {
+		l.Error("failed to list roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to set roles", nil)
+	}
+	names := make([]string, 0, len(known))
+	for _, role := range known {
+		names = append(names, role.Name)
+	}
+
+	roles := make([]string, 0, len(req.Roles))
+	for _, role := range req.Roles {
+		if !utils.AnyInSlice(names, role) {
+			return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, fmt.Sprintf("Unknown role %q", role), nil)
+		}
+		if !utils.AnyInSlice(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	user, err := u.Repository.GetByID(ctx, req.ID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to set roles", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	current, _, err := u.Repository.GetRoles(ctx, req.ID)
+	if err != nil {
+		l.Error("failed to get roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to set roles", nil)
+	}
+
+	if err := u.Repository.SetUserRoles(ctx, req.ID, roles); err != nil {
+		l.Error("failed to set roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to set roles", nil)
+	}
+
+	for _, role := range current {
+		if utils.AnyInSlice(roles, role) {
+			continue
+		}
+		if err := u.revokeUser(ctx, l, req.ID); err != nil {
+			l.Error("failed to revoke tokens", zap.Error(err))
+			return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to revoke tokens", nil)
+		}
+		break
+	}
+
+	l.Info("user roles set", zap.String("userId", req.ID), zap.Strings("roles", roles), zap.String("setBy", req.AuthUserData.UserID))
+	return wrapper.ResponseSuccess(http.StatusOK, schema.UserRolesResponse{
+		UserID: req.ID,
+		Role:   middleware.PrimaryRole(roles),
+		Roles:  roles,
+	})
+}
+
+func toRoles(roles []model.Role, grants []model.RolePermission) []schema.RoleResponse {
+	permissions := make(map[string][]string, len(roles))
+	for _, grant := range grants {
+		permissions[grant.Role] = append(permissions[grant.Role], grant.Permission)
+	}
+
+	items := make([]schema.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, schema.RoleResponse{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: append([]string{}, permissions[role.Name]...),
+		})
+	}
+	return items
+}
+
+// revokeTokenFamily handles the reuse of a refresh token that was already rotated, the session is
+// ended as its tokens may be stolen.
+func (u *UseCase) revokeTokenFamily(ctx context.Context, l *zap.Logger, userID string, family string) wrapper.JSONResult {
+	l.Warn("refresh token reuse detected, revoking token family")
+	if err := u.revokeSessionTokens(ctx, []string{family}); err != nil {
+		l.Error("failed to revoke token family", zap.Error(err))
+	}
+	if _, err := u.Repository.RevokeSession(ctx, userID, family); err != nil {
+		l.Error("failed to revoke session", zap.Error(err))
+	}
+	return wrapper.ResponseFailed(http.StatusUnauthorized, contract.StatusCodeUnauthorized, "invalid refresh token", nil)
+}
+
+// createTokens signs the tokens of a new login and starts their token family, with the session of
+// the device.
+func (u *UseCase) createTokens(ctx context.Context, user *model.User, userAgent string, ip string) (string, string, error) {
+	family := uuid.NewString()
+	tokenID := uuid.NewString()
+
+	token, refreshToken, err := u.signTokens(ctx, user, family, tokenID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := u.Repository.CreateTokenFamily(ctx, family, tokenID, u.refreshTTL()); err != nil {
+		return "", "", err
+	}
+	if err := u.createSession(ctx, user, family, userAgent, ip); err != nil {
+		return "", "", err
+	}
+	return token, refreshToken, nil
+}
+
+// signTokens signs an access token and a refresh token with the jti, both of the family. The
+// roles and permissions are read at every signature, changes apply from the next refresh.
+func (u *UseCase) signTokens(ctx context.Context, user *model.User, family string, tokenID string) (string, string, error) {
+	roles, permissions, err := u.Repository.GetRoles(ctx, user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	dataClaims := make(authentication.JWTClaims)
+
+	dataClaims["userId"] = user.ID
+	dataClaims[authentication.ClaimRoles] = roles
+	dataClaims[authentication.ClaimPermissions] = permissions
+	dataClaims[authentication.ClaimFamily] = family
+	dataClaims[authentication.ClaimEmailVerified] = user.EmailVerifiedAt != nil
+	token, err := u.Jwt.GenerateToken(dataClaims)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := u.Jwt.GenerateRefreshToken(authentication.JWTClaims{
+		"userId":                    user.ID,
+		authentication.ClaimFamily:  family,
+		authentication.ClaimTokenID: tokenID,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return token, refreshToken, nil
+}
+
+// refreshTTL is the lifetime of a refresh token, a family expires with its latest refresh token.
+func (u *UseCase) refreshTTL() time.Duration {
+	return time.Duration(u.Config.JwtRefreshTime) * time.Minute
+}
+
+// Profile returns the profile of the authenticated user.
+func (u *UseCase) Profile(ctx context.Context, req *schema.ProfileRequest) wrapper.JSONResult {
 	l := logger.WithID(u.Logger, ContextName, "Profile")
-	l.Info("payload request", zap.Any("request", req))
-	return wrapper.ResponseSuccess(http.StatusOK, req)
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to get profile", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	roles, _, err := u.Repository.GetRoles(ctx, user.ID)
+	if err != nil {
+		l.Error("failed to get roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to get profile", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, toProfile(user, roles))
+}
+
+// UpdateProfile changes the profile of the authenticated user and returns it.
+func (u *UseCase) UpdateProfile(ctx context.Context, req *schema.ProfileUpdateRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "UpdateProfile")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to update profile", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	setField := func(field *string, value *string) {
+		if value != nil {
+			*field = strings.TrimSpace(*value)
+		} else if req.Replace {
+			*field = ""
+		}
+	}
+	setField(&user.DisplayName, req.DisplayName)
+	setField(&user.Bio, req.Bio)
+	setField(&user.AvatarURL, req.AvatarURL)
+
+	previousEmail := user.Email
+	if req.Email != nil || req.Replace {
+		var email *string
+		if req.Email != nil && strings.TrimSpace(*req.Email) != "" {
+			normalized := strings.ToLower(strings.TrimSpace(*req.Email))
+			email = &normalized
+
+			taken, err := u.Repository.EmailTaken(ctx, normalized, user.ID)
+			if err != nil {
+				l.Error("failed to check email", zap.Error(err))
+				return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to update profile", nil)
+			}
+			if taken {
+				return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Email is already in use", nil)
+			}
+		}
+		user.Email = email
+	}
+
+	emailChanged := !equalEmail(previousEmail, user.Email)
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
+
+	roles, _, err := u.Repository.GetRoles(ctx, user.ID)
+	if err != nil {
+		l.Error("failed to get roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to update profile", nil)
+	}
+
+	user.UpdatedAt = time.Now()
+	if err := u.Repository.UpdateProfile(ctx, user); err != nil {
+		l.Error("failed to update profile", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to update profile", nil)
+	}
+
+	if emailChanged && user.Email != nil {
+		if err := u.sendVerification(ctx, user); err != nil {
+			l.Error("failed to send verification email", zap.Error(err))
+		}
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, toProfile(user, roles))
+}
+
+// ChangePassword replaces the password of the authenticated user, the current password must be
+// given.
+func (u *UseCase) ChangePassword(ctx context.Context, req *schema.PasswordChangeRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ChangePassword")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to change password", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+
+	if !authentication.VerifyPassword(req.CurrentPassword, user.Password) {
+		l.Info("current password invalid", zap.String("userId", user.ID))
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeUserOrPasswordInvalid, "current password invalid", nil)
+	}
+
+	if err := u.setPassword(ctx, user.ID, req.NewPassword); err != nil {
+		l.Error("failed to change password", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to change password", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.PasswordChangeResponse{})
+}
+
+// ForgotPassword queues a single use reset token for the user. The response is the same whether the
+// user exists or not, failures after the user is found are only logged. Requests are throttled per
+// username and per IP, throttling fails open like the login one.
+func (u *UseCase) ForgotPassword(ctx context.Context, req *schema.PasswordForgotRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ForgotPassword")
+	accepted := wrapper.ResponseSuccess(http.StatusAccepted, schema.PasswordForgotResponse{})
+
+	window := time.Duration(u.Config.PasswordResetWindow) * time.Second
+	retryAfter, err := u.Repository.ThrottlePasswordReset(ctx, req.Username, req.IP, window, u.Config.PasswordResetMaxPerUser, u.Config.PasswordResetMaxPerIP)
+	if err != nil {
+		l.Error("failed to throttle password reset", zap.Error(err))
+	}
+	if retryAfter > 0 {
+		return loginThrottled("too many password reset requests", retryAfter)
+	}
+
+	user, err := u.Repository.GetByUsername(ctx, req.Username)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to reset password", nil)
+	}
+	if user == nil {
+		l.Info("password reset of an unknown user")
+		return accepted
+	}
+
+	// the token is sent by RunPasswordResets, so the response takes as long whether the user exists
+	// or not, a full queue drops the request rather than holding it
+	select {
+	case u.passwordResets <- user:
+	default:
+		l.Warn("password reset queue is full, request dropped", zap.String("userId", user.ID))
+	}
+
+	return accepted
+}
+
+// RunPasswordResets sends the queued reset tokens until the context is done.
+func (u *UseCase) RunPasswordResets(ctx context.Context) error {
+	l := logger.WithID(u.Logger, ContextName, "RunPasswordResets")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case user := <-u.passwordResets:
+			u.sendPasswordReset(ctx, l.With(zap.String("userId", user.ID)), user)
+		}
+	}
+}
+
+// sendPasswordReset stores a new reset token of the user and sends it to them.
+func (u *UseCase) sendPasswordReset(ctx context.Context, l *zap.Logger, user *model.User) {
+	token, hash, err := authentication.GenerateOpaqueToken()
+	if err != nil {
+		l.Error("failed to generate reset token", zap.Error(err))
+		return
+	}
+
+	ttl := time.Duration(u.Config.PasswordResetTTL) * time.Minute
+	if err := u.Repository.CreatePasswordReset(ctx, user.ID, hash, ttl); err != nil {
+		l.Error("failed to store reset token", zap.Error(err))
+		return
+	}
+
+	msg := notifier.Message{
+		Kind:    notifier.KindPasswordReset,
+		UserID:  user.ID,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Use this token to reset your password, it expires in %d minutes: %s", u.Config.PasswordResetTTL, token),
+	}
+	if user.Email != nil {
+		msg.To = *user.Email
+	}
+	if u.Config.PasswordResetURL != "" {
+		msg.Body = fmt.Sprintf("Open this link to reset your password, it expires in %d minutes: %s%s", u.Config.PasswordResetTTL, u.Config.PasswordResetURL, token)
+	}
+	if err := u.Notifier.Notify(ctx, msg); err != nil {
+		l.Error("failed to send reset token", zap.Error(err))
+	}
+}
+
+// ResetPassword replaces the password of the user of a reset token and revokes every token of the
+// user, the reset token can be used once.
+func (u *UseCase) ResetPassword(ctx context.Context, req *schema.PasswordResetRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ResetPassword")
+
+	userID, found, err := u.Repository.ConsumePasswordReset(ctx, authentication.HashOpaqueToken(req.Token))
+	if err != nil {
+		l.Error("failed to get reset token", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to reset password", nil)
+	}
+	if !found {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeInvalidToken, "invalid or expired reset token", nil)
+	}
+	l = l.With(zap.String("userId", userID))
+
+	// revoke first, a failure then leaves the old password working rather than the old sessions
+	if err := u.revokeUser(ctx, l, userID); err != nil {
+		l.Error("failed to revoke tokens", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to reset password", nil)
+	}
+
+	if err := u.setPassword(ctx, userID, req.NewPassword); err != nil {
+		l.Error("failed to reset password", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to reset password", nil)
+	}
+
+	l.Info("password reset")
+	return wrapper.ResponseSuccess(http.StatusNoContent, schema.PasswordResetResponse{})
+}
+
+// VerifyEmail marks the email of a verification token as verified. The token is signed and
+// expires, it stops working once the user changes their email. The claim of the tokens issued
+// before is updated on their next refresh.
+func (u *UseCase) VerifyEmail(ctx context.Context, req *schema.EmailVerifyRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "VerifyEmail")
+	invalid := wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeInvalidToken, "invalid or expired verification token", nil)
+
+	payload, err := authentication.VerifySignedToken(u.Config.EmailVerificationSecret, req.Token, time.Now())
+	if err != nil {
+		l.Info("invalid verification token", zap.Error(err))
+		return invalid
+	}
+	userID, email, _ := strings.Cut(payload, "\n")
+	l = l.With(zap.String("userId", userID))
+
+	verified, err := u.Repository.MarkEmailVerified(ctx, userID, email)
+	if err != nil {
+		l.Error("failed to verify email", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to verify email", nil)
+	}
+	if verified {
+		l.Info("email verified")
+		return wrapper.ResponseSuccess(http.StatusNoContent, schema.EmailVerifyResponse{})
+	}
+
+	// opening the link twice is fine, a link of a previous email is not
+	user, err := u.Repository.GetByID(ctx, userID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to verify email", nil)
+	}
+	if user != nil && user.EmailVerifiedAt != nil && equalEmail(user.Email, &email) {
+		return wrapper.ResponseSuccess(http.StatusNoContent, schema.EmailVerifyResponse{})
+	}
+	return invalid
+}
+
+// ResendVerification sends a new verification email to the authenticated user, at most one per
+// resend interval.
+func (u *UseCase) ResendVerification(ctx context.Context, req *schema.EmailResendRequest) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ResendVerification")
+
+	user, err := u.Repository.GetByID(ctx, req.AuthUserData.UserID)
+	if err != nil {
+		l.Error("failed to get user", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to send verification email", nil)
+	}
+	if user == nil {
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "User not found", nil)
+	}
+	if user.Email == nil {
+		return wrapper.ResponseFailed(http.StatusBadRequest, contract.StatusCodeValidationFailed, "User has no email address", nil)
+	}
+	if user.EmailVerifiedAt != nil {
+		return wrapper.ResponseFailed(http.StatusConflict, contract.StatusCodeConflict, "Email address is already verified", nil)
+	}
+
+	interval := time.Duration(u.Config.EmailVerificationResendInterval) * time.Second
+	allowed, err := u.Repository.ThrottleEmailVerification(ctx, user.ID, interval)
+	if err != nil {
+		l.Error("failed to throttle verification email", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to send verification email", nil)
+	}
+	if !allowed {
+		return wrapper.ResponseFailed(http.StatusTooManyRequests, contract.StatusCodeTooManyRequests, "Verification email was sent recently, try again later", nil)
+	}
+
+	if err := u.sendVerification(ctx, user); err != nil {
+		l.Error("failed to send verification email", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to send verification email", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusAccepted, schema.EmailResendResponse{})
+}
+
+// sendVerification sends a verification link for the current email of the user.
+func (u *UseCase) sendVerification(ctx context.Context, user *model.User) error {
+	ttl := time.Duration(u.Config.EmailVerificationTTL) * time.Minute
+	token := authentication.SignToken(u.Config.EmailVerificationSecret, user.ID+"\n"+*user.Email, time.Now().Add(ttl))
+
+	msg := notifier.Message{
+		Kind:    notifier.KindEmailVerification,
+		UserID:  user.ID,
+		To:      *user.Email,
+		Subject: "Verify your email address",
+		Body:    fmt.Sprintf("Use this token to verify your email address, it expires in %d minutes: %s", u.Config.EmailVerificationTTL, token),
+	}
+	if u.Config.EmailVerificationURL != "" {
+		msg.Body = fmt.Sprintf("Open this link to verify your email address, it expires in %d minutes: %s%s", u.Config.EmailVerificationTTL, u.Config.EmailVerificationURL, token)
+	}
+	return u.Notifier.Notify(ctx, msg)
+}
+
+func equalEmail(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (u *UseCase) setPassword(ctx context.Context, userID string, password string) error {
+	hash, err := authentication.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return u.Repository.UpdatePassword(ctx, userID, hash)
+}
+
+func toProfile(user *model.User, roles []string) schema.ProfileResponse {
+	profile := schema.ProfileResponse{
+		ID:            user.ID,
+		Username:      user.Username,
+		Role:          middleware.PrimaryRole(roles),
+		DisplayName:   user.DisplayName,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Bio:           user.Bio,
+		AvatarURL:     user.AvatarURL,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+	if user.Email != nil {
+		profile.Email = *user.Email
+	}
+	return profile
+}
+
+// GetUsers returns the public view of the users found among the IDs, in no particular order.
+func (u *UseCase) GetUsers(ctx context.Context, req *schema.RequestUserBatch) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "GetUsers")
+
+	users, err := u.Repository.GetByIDs(ctx, req.IDs)
+	if err != nil {
+		l.Error("failed to get users", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to get users", nil)
+	}
+	roles, err := u.Repository.GetUserRoles(ctx, req.IDs)
+	if err != nil {
+		l.Error("failed to get roles", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "failed to get users", nil)
+	}
+
+	response := make([]schema.ResponseUser, len(users))
+	for i, user := range users {
+		response[i] = schema.ResponseUser{
+			ID:        user.ID,
+			Username:  user.Username,
+			Role:      middleware.PrimaryRole(roles[user.ID]),
+			CreatedAt: user.CreatedAt,
+		}
+	}
+
+	l.Debug("users loaded", zap.Int("requested", len(req.IDs)), zap.Int("found", len(users)))
+	return wrapper.ResponseSuccess(http.StatusOK, response)
 }
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/webhook/repository"
+	"github.com/Alwanly/go-codebase/internal/webhook/schema"
+	"github.com/Alwanly/go-codebase/internal/webhook/usecase"
+	"github.com/Alwanly/go-codebase/pkg/binding"
+	"github.com/Alwanly/go-codebase/pkg/deps"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/Alwanly/go-codebase/pkg/webhook"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.Webhook.Handler"
+
+type (
+	Handler struct {
+		Logger    *zap.Logger
+		Validator validator.IValidatorService
+		UseCase   usecase.IUseCase
+	}
+)
+
+// NewHandler registers the webhook routes and the dispatcher, which runs as a worker and is exposed
+// to the app as its webhook dispatcher.
+func NewHandler(d *deps.App) *Handler {
+	repository := repository.NewRepository(repository.Repository{
+		DB:    d.DB,
+		Redis: d.Redis,
+	})
+	dispatcher := usecase.NewDispatcher(usecase.Dispatcher{
+		Logger:     d.Logger,
+		Repository: repository,
+		Client: webhook.NewClient(&webhook.Opts{
+			MaxAttempts: d.Config.WebhookMaxAttempts,
+			BaseDelay:   time.Duration(d.Config.WebhookRetryBaseDelay) * time.Second,
+			MaxDelay:    time.Duration(d.Config.WebhookRetryMaxDelay) * time.Second,
+		}),
+		Workers:      d.Config.WebhookWorkers,
+		PollInterval: time.Duration(d.Config.WebhookPollInterval) * time.Millisecond,
+		Lease:        time.Duration(d.Config.WebhookDeliveryLease) * time.Second,
+	})
+	d.Webhooks = dispatcher
+	d.Workers = append(d.Workers, dispatcher.Run)
+
+	usecase := usecase.NewUseCase(usecase.UseCase{
+		Config:     d.Config,
+		Logger:     d.Logger,
+		Repository: repository,
+		Dispatcher: dispatcher,
+	})
+	handler := &Handler{
+		Logger:    d.Logger,
+		Validator: d.Validator,
+		UseCase:   usecase,
+	}
+
+	e := d.Fiber.Group("/webhooks/v1", d.Auth.JwtAuth(), middleware.RequirePermission(middleware.PermissionWebhookManage))
+	e.Post("/", handler.Create)
+	e.Get("/", handler.List)
+	e.Get("/:id", handler.Get)
+	e.Put("/:id", handler.Update)
+	e.Delete("/:id", handler.Delete)
+	e.Get("/:id/deliveries", handler.ListDeliveries)
+	e.Post("/:id/deliveries/:deliveryId/redeliver", handler.Redeliver)
+	return handler
+}
+
+// Create registers a webhook subscription.
+func (h *Handler) Create(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Create")
+
+	// bind model
+	model := &schema.RequestSubscriptionCreate{}
+	if err := binding.BindModel(l, c, model, binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// create subscription
+	response := h.UseCase.Create(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// List returns a list of webhook subscriptions.
+func (h *Handler) List(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "List")
+
+	// bind model
+	model := &schema.RequestSubscriptionList{
+		Page:     1,
+		PageSize: 10,
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get list of subscriptions
+	response := h.UseCase.List(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Get returns a webhook subscription by ID.
+func (h *Handler) Get(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Get")
+
+	// bind model
+	model := &schema.RequestSubscriptionGet{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get subscription by ID
+	response := h.UseCase.Get(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Update updates a webhook subscription by ID.
+func (h *Handler) Update(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Update")
+
+	// bind model
+	model := &schema.RequestSubscriptionUpdate{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromBody()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// update subscription
+	response := h.UseCase.Update(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Delete deletes a webhook subscription by ID.
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Delete")
+
+	// bind model
+	model := &schema.RequestSubscriptionDelete{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// delete subscription
+	response := h.UseCase.Delete(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// ListDeliveries returns the delivery log of a webhook subscription.
+func (h *Handler) ListDeliveries(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "ListDeliveries")
+
+	// bind model
+	model := &schema.RequestDeliveryList{
+		Page:     1,
+		PageSize: 10,
+	}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams(), binding.BindFromQuery()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// get delivery log
+	response := h.UseCase.ListDeliveries(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
+
+// Redeliver sends a logged delivery again.
+func (h *Handler) Redeliver(c *fiber.Ctx) error {
+	l := logger.WithID(h.Logger, ContextName, "Redeliver")
+
+	// bind model
+	model := &schema.RequestRedeliver{}
+	if err := binding.BindModel(l, c, model, binding.BindFromParams()); err != nil {
+		perr := err.(*binding.ModelBindingError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// validate model
+	if err := validator.ValidateModel(l, h.Validator, model); err != nil {
+		perr := err.(*validator.ModelValidationError)
+		return c.Status(perr.Code).JSON(perr.ResponseBody)
+	}
+
+	// log redelivery
+	response := h.UseCase.Redeliver(c.UserContext(), model)
+	return c.Status(response.Code).JSON(response)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/webhook/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const ContextName = "Internal.Webhook.Repository"
+
+type (
+	Repository struct {
+		DB    database.IDBService
+		Redis redis.IRedisService
+	}
+
+	IRepository interface {
+		CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+		GetSubscription(ctx context.Context, id string) *model.WebhookSubscription
+		UpdateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+		DeleteSubscription(ctx context.Context, id string) error
+		ListSubscriptions(ctx context.Context, page, pageSize int) ([]model.WebhookSubscription, int64)
+		ListActiveSubscriptions(ctx context.Context, eventType string) ([]model.WebhookSubscription, error)
+		CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+		UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+		ClaimDelivery(ctx context.Context, lease time.Duration) (*model.WebhookDelivery, error)
+		GetDelivery(ctx context.Context, subscriptionID, id string) *model.WebhookDelivery
+		ListDeliveries(ctx context.Context, req schema.RequestDeliveryList) ([]model.WebhookDelivery, int64)
+	}
+)
+
+func NewRepository(r Repository) IRepository {
+	return &Repository{
+		DB:    r.DB,
+		Redis: r.Redis,
+	}
+}
+
+func (r *Repository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	return r.DB.GetTransaction(ctx).Create(subscription).Error
+}
+
+func (r *Repository) GetSubscription(ctx context.Context, id string) *model.WebhookSubscription {
+	var subscription model.WebhookSubscription
+	if err := r.DB.GetTransaction(ctx).Where("id = ?", id).First(&subscription).Error; err != nil {
+		return nil
+	}
+	return &subscription
+}
+
+func (r *Repository) UpdateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	return r.DB.GetTransaction(ctx).Save(subscription).Error
+}
+
+// DeleteSubscription deletes the subscription together with its delivery log.
+func (r *Repository) DeleteSubscription(ctx context.Context, id string) error {
+	return r.DB.GetTransaction(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.WebhookSubscription{}).Error
+	})
+}
+
+func (r *Repository) ListSubscriptions(ctx context.Context, page, pageSize int) ([]model.WebhookSubscription, int64) {
+	var subscriptions []model.WebhookSubscription
+	var total int64
+
+	tx := r.DB.GetTransaction(ctx).Model(&model.WebhookSubscription{}).Session(&gorm.Session{})
+	tx.Count(&total)
+	tx.Offset(utils.CalculatePageSkip(page, pageSize)).
+		Limit(pageSize).
+		Order("created_at DESC").
+		Find(&subscriptions)
+
+	return subscriptions, total
+}
+
+// ListActiveSubscriptions returns the active subscriptions wanting events of the type.
+func (r *Repository) ListActiveSubscriptions(ctx context.Context, eventType string) ([]model.WebhookSubscription, error) {
+	var subscriptions []model.WebhookSubscription
+	if err := r.DB.GetTransaction(ctx).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	matching := []model.WebhookSubscription{}
+	for _, subscription := range subscriptions {
+		if schema.Subscribed(subscription, eventType) {
+			matching = append(matching, subscription)
+		}
+	}
+	return matching, nil
+}
+
+func (r *Repository) CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return r.DB.GetTransaction(ctx).Create(delivery).Error
+}
+
+// UpdateDelivery records the outcome of a delivery, a delivery deleted meanwhile stays deleted.
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return r.DB.GetTransaction(ctx).Model(delivery).
+		Select("status", "attempts", "response_code", "response_body", "error", "duration_ms", "delivered_at").
+		Updates(delivery).Error
+}
+
+// ClaimDelivery returns the oldest due pending delivery and pushes it back by the lease, so no other
+// worker takes it while it is sent. A delivery whose worker died is sent again once its lease ran out.
+// It returns nil when no delivery is due.
+func (r *Repository) ClaimDelivery(ctx context.Context, lease time.Duration) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := r.DB.GetTransaction(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: database.PostgresLockTypeForUpdate, Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", schema.DeliveryStatusPending, now).
+			Order("next_attempt_at").
+			First(&delivery).Error
+		if err != nil {
+			return err
+		}
+
+		delivery.NextAttemptAt = now.Add(lease)
+		return tx.Model(&delivery).Update("next_attempt_at", delivery.NextAttemptAt).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *Repository) GetDelivery(ctx context.Context, subscriptionID, id string) *model.WebhookDelivery {
+	var delivery model.WebhookDelivery
+	err := r.DB.GetTransaction(ctx).Where("id = ? AND subscription_id = ?", id, subscriptionID).First(&delivery).Error
+	if err != nil {
+		return nil
+	}
+	return &delivery
+}
+
+func (r *Repository) ListDeliveries(ctx context.Context, req schema.RequestDeliveryList) ([]model.WebhookDelivery, int64) {
+	var deliveries []model.WebhookDelivery
+	var total int64
+
+	tx := r.DB.GetTransaction(ctx).Model(&model.WebhookDelivery{}).Where("subscription_id = ?", req.ID)
+	if req.Status != "" {
+		tx = tx.Where("status = ?", req.Status)
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	tx.Count(&total)
+	tx.Offset(utils.CalculatePageSkip(req.Page, req.PageSize)).
+		Limit(req.PageSize).
+		Order("created_at DESC").
+		Find(&deliveries)
+
+	return deliveries, total
+}
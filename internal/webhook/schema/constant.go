@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+)
+
+// AllEvents subscribes to every event type.
+const AllEvents = "*"
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+type RequestSubscriptionCreate struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=* book.created book.updated book.deleted"`
+	Active *bool    `json:"active"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestSubscriptionUpdate struct {
+	ID     string   `params:"id" validate:"required"`
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=* book.created book.updated book.deleted"`
+	Active *bool    `json:"active"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestSubscriptionGet struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestSubscriptionDelete struct {
+	ID string `params:"id" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestSubscriptionList struct {
+	Page     int `query:"page" validate:"required,min=1"`
+	PageSize int `query:"page_size" validate:"required,min=1,max=100"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+// ResponseSubscription never carries the secret except right after it was generated.
+type ResponseSubscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type RequestDeliveryList struct {
+	ID       string `params:"id" validate:"required"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	PageSize int    `query:"page_size" validate:"required,min=1,max=100"`
+	Status   string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type RequestRedeliver struct {
+	ID         string `params:"id" validate:"required"`
+	DeliveryID string `params:"deliveryId" validate:"required"`
+
+	AuthUserData *middleware.AuthUserData
+}
+
+type ResponseDelivery struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscriptionId"`
+	EventID        string     `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseCode   int        `json:"responseCode"`
+	ResponseBody   string     `json:"responseBody,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	DurationMs     int64      `json:"durationMs"`
+	RedeliveryOf   *string    `json:"redeliveryOf,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+}
+
+// JoinEvents stores the event filter of a subscription as a comma separated list.
+func JoinEvents(events []string) string {
+	return strings.Join(events, ",")
+}
+
+// Subscribed reports whether the subscription wants events of the type.
+func Subscribed(subscription model.WebhookSubscription, eventType string) bool {
+	for _, e := range strings.Split(subscription.Events, ",") {
+		if e == AllEvents || e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func ToSubscriptionResponse(subscription *model.WebhookSubscription) ResponseSubscription {
+	return ResponseSubscription{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    strings.Split(subscription.Events, ","),
+		Active:    subscription.Active,
+		CreatedBy: subscription.CreatedBy,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func ToDeliveryResponse(delivery *model.WebhookDelivery) ResponseDelivery {
+	return ResponseDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseCode:   delivery.ResponseCode,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		DurationMs:     delivery.DurationMs,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
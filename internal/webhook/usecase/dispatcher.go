@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Alwanly/go-codebase/internal/webhook/repository"
+	"github.com/Alwanly/go-codebase/internal/webhook/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/webhook"
+	"go.uber.org/zap"
+)
+
+const DispatcherContextName = "Internal.Webhook.Dispatcher"
+
+type (
+	Dispatcher struct {
+		Logger     *zap.Logger
+		Repository repository.IRepository
+		Client     webhook.IClient
+		Workers    int
+		// PollInterval is the delay before a worker looks for due deliveries again when there are none
+		PollInterval time.Duration
+		// Lease is how long a claimed delivery is hidden from the other workers while it is sent, it
+		// must outlast every retry of the client
+		Lease time.Duration
+	}
+
+	IDispatcher interface {
+		event.IDispatcher
+
+		// Run sends the pending deliveries with the configured number of workers until the context is done.
+		//
+		// Parameters:
+		//   - ctx: context
+		//
+		// Returns:
+		//   - error: always nil
+		Run(ctx context.Context) error
+	}
+)
+
+func NewDispatcher(d Dispatcher) IDispatcher {
+	return &Dispatcher{
+		Logger:       d.Logger,
+		Repository:   d.Repository,
+		Client:       d.Client,
+		Workers:      max(d.Workers, 1),
+		PollInterval: utils.IfThenElse(d.PollInterval > 0, d.PollInterval, time.Second),
+		Lease:        utils.IfThenElse(d.Lease > 0, d.Lease, 10*time.Minute),
+	}
+}
+
+// Dispatch logs a pending delivery for every subscription wanting the event. It runs in the
+// transaction of the caller, so the deliveries exist if and only if the caller commits, and the
+// workers send them once they are committed.
+func (d *Dispatcher) Dispatch(ctx context.Context, evt event.Event) error {
+	l := logger.WithID(d.Logger, DispatcherContextName, "Dispatch")
+
+	subscriptions, err := d.Repository.ListActiveSubscriptions(ctx, evt.Type)
+	if err != nil {
+		l.Error("failed to list subscriptions", zap.Error(err), zap.String("eventId", evt.ID))
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := utils.JSONMarshal(evt)
+	if err != nil {
+		l.Error("failed to marshal event", zap.Error(err), zap.String("eventId", evt.ID))
+		return err
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		delivery := &model.WebhookDelivery{
+			ID:             utils.GenerateUUID(),
+			SubscriptionID: subscription.ID,
+			EventID:        evt.ID,
+			EventType:      evt.Type,
+			Payload:        string(payload),
+			Status:         schema.DeliveryStatusPending,
+			CreatedAt:      now,
+			NextAttemptAt:  now,
+		}
+		if err := d.Repository.CreateDelivery(ctx, delivery); err != nil {
+			l.Error("failed to log delivery", zap.Error(err), zap.String("subscriptionId", subscription.ID))
+			return err
+		}
+	}
+	return nil
+}
+
+// Run starts the workers, each one claims and sends due deliveries and waits for the poll interval
+// when there are none. Pending deliveries left by a previous run are sent too, so no delivery is
+// lost on a restart: delivery is at-least-once.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < d.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if d.work(ctx) {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(d.PollInterval):
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// work sends one due delivery, it returns false when there was none to send.
+func (d *Dispatcher) work(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	delivery, err := d.Repository.ClaimDelivery(ctx, d.Lease)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.WithID(d.Logger, DispatcherContextName, "Work").Error("failed to claim delivery", zap.Error(err))
+		}
+		return false
+	}
+	if delivery == nil {
+		return false
+	}
+
+	d.deliver(ctx, delivery)
+	return true
+}
+
+// deliver sends a logged delivery, looking up its subscription for the current URL and secret.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	subscription := d.Repository.GetSubscription(ctx, delivery.SubscriptionID)
+	if subscription == nil {
+		l := logger.WithID(d.Logger, DispatcherContextName, "Deliver")
+		l.Warn("subscription not found", zap.String("deliveryId", delivery.ID))
+
+		delivery.Status = schema.DeliveryStatusFailed
+		delivery.Error = "subscription not found"
+		if err := d.Repository.UpdateDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+			l.Error("failed to update delivery", zap.Error(err), zap.String("deliveryId", delivery.ID))
+		}
+		return
+	}
+	d.send(ctx, subscription, delivery)
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) {
+	l := logger.WithID(d.Logger, DispatcherContextName, "Send")
+
+	result := d.Client.Deliver(ctx, webhook.Request{
+		URL:     subscription.URL,
+		Secret:  subscription.Secret,
+		ID:      delivery.ID,
+		EventID: delivery.EventID,
+		Event:   delivery.EventType,
+		Payload: []byte(delivery.Payload),
+	})
+
+	delivery.Attempts = result.Attempts
+	delivery.ResponseCode = result.StatusCode
+	delivery.ResponseBody = result.ResponseBody
+	delivery.DurationMs = result.Duration.Milliseconds()
+	delivery.Status = schema.DeliveryStatusSucceeded
+	delivery.Error = ""
+	if !result.Succeeded() {
+		delivery.Status = schema.DeliveryStatusFailed
+		delivery.Error = result.Err.Error()
+	}
+	delivery.DeliveredAt = utils.ToPointer(time.Now())
+
+	// the worker context may be cancelled on shutdown, the outcome is still worth logging
+	if err := d.Repository.UpdateDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		l.Error("failed to update delivery", zap.Error(err), zap.String("deliveryId", delivery.ID))
+	}
+
+	l.Debug("webhook delivered",
+		zap.String("deliveryId", delivery.ID),
+		zap.String("status", delivery.Status),
+		zap.Int("attempts", delivery.Attempts),
+		zap.Int("responseCode", delivery.ResponseCode))
+}
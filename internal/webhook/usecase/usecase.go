@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/internal/webhook/repository"
+	"github.com/Alwanly/go-codebase/internal/webhook/schema"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Internal.Webhook.Usecase"
+
+type (
+	UseCase struct {
+		Config     *config.GlobalConfig
+		Logger     *zap.Logger
+		Repository repository.IRepository
+		Dispatcher IDispatcher
+	}
+
+	IUseCase interface {
+		Create(context.Context, *schema.RequestSubscriptionCreate) wrapper.JSONResult
+		Get(context.Context, *schema.RequestSubscriptionGet) wrapper.JSONResult
+		List(context.Context, *schema.RequestSubscriptionList) wrapper.JSONResult
+		Update(context.Context, *schema.RequestSubscriptionUpdate) wrapper.JSONResult
+		Delete(context.Context, *schema.RequestSubscriptionDelete) wrapper.JSONResult
+		ListDeliveries(context.Context, *schema.RequestDeliveryList) wrapper.JSONResult
+		Redeliver(context.Context, *schema.RequestRedeliver) wrapper.JSONResult
+	}
+)
+
+func NewUseCase(uc UseCase) IUseCase {
+	return &UseCase{
+		Config:     uc.Config,
+		Logger:     uc.Logger,
+		Repository: uc.Repository,
+		Dispatcher: uc.Dispatcher,
+	}
+}
+
+func (u *UseCase) Create(ctx context.Context, req *schema.RequestSubscriptionCreate) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Create")
+
+	// generate a secret when none is given, it is only returned once
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			l.Error("failed to generate secret", zap.Error(err))
+			return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to create a subscription", nil)
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	subscription := &model.WebhookSubscription{
+		ID:        utils.GenerateUUID(),
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    schema.JoinEvents(req.Events),
+		Active:    req.Active == nil || *req.Active,
+		CreatedBy: req.AuthUserData.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.Repository.CreateSubscription(ctx, subscription); err != nil {
+		l.Error("failed to create a subscription", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to create a subscription", nil)
+	}
+
+	l.Info("subscription created", zap.String("id", subscription.ID), zap.String("url", subscription.URL))
+
+	response := schema.ToSubscriptionResponse(subscription)
+	response.Secret = secret
+	return wrapper.ResponseSuccess(http.StatusCreated, response)
+}
+
+func (u *UseCase) Get(ctx context.Context, req *schema.RequestSubscriptionGet) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Get")
+
+	subscription := u.Repository.GetSubscription(ctx, req.ID)
+	if subscription == nil {
+		l.Error("subscription not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Subscription not found", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ToSubscriptionResponse(subscription))
+}
+
+func (u *UseCase) List(ctx context.Context, req *schema.RequestSubscriptionList) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "List")
+
+	subscriptions, total := u.Repository.ListSubscriptions(ctx, req.Page, req.PageSize)
+	response := make([]schema.ResponseSubscription, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = schema.ToSubscriptionResponse(&subscriptions[i])
+	}
+
+	l.Debug("subscriptions listed", zap.Int64("total", total))
+	return wrapper.ResponsePagination(req.Page, req.PageSize, len(subscriptions), int(total), response, nil)
+}
+
+func (u *UseCase) Update(ctx context.Context, req *schema.RequestSubscriptionUpdate) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Update")
+
+	subscription := u.Repository.GetSubscription(ctx, req.ID)
+	if subscription == nil {
+		l.Error("subscription not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Subscription not found", nil)
+	}
+
+	subscription.URL = req.URL
+	subscription.Events = schema.JoinEvents(req.Events)
+	subscription.UpdatedAt = time.Now()
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := u.Repository.UpdateSubscription(ctx, subscription); err != nil {
+		l.Error("failed to update a subscription", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to update a subscription", nil)
+	}
+
+	return wrapper.ResponseSuccess(http.StatusOK, schema.ToSubscriptionResponse(subscription))
+}
+
+func (u *UseCase) Delete(ctx context.Context, req *schema.RequestSubscriptionDelete) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Delete")
+
+	if u.Repository.GetSubscription(ctx, req.ID) == nil {
+		l.Error("subscription not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Subscription not found", nil)
+	}
+
+	if err := u.Repository.DeleteSubscription(ctx, req.ID); err != nil {
+		l.Error("failed to delete a subscription", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to delete a subscription", nil)
+	}
+
+	l.Info("subscription deleted", zap.String("id", req.ID))
+	return wrapper.ResponseSuccess(http.StatusNoContent, nil)
+}
+
+func (u *UseCase) ListDeliveries(ctx context.Context, req *schema.RequestDeliveryList) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "ListDeliveries")
+
+	if u.Repository.GetSubscription(ctx, req.ID) == nil {
+		l.Error("subscription not found", zap.String("id", req.ID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Subscription not found", nil)
+	}
+
+	deliveries, total := u.Repository.ListDeliveries(ctx, *req)
+	response := make([]schema.ResponseDelivery, len(deliveries))
+	for i := range deliveries {
+		response[i] = schema.ToDeliveryResponse(&deliveries[i])
+	}
+
+	return wrapper.ResponsePagination(req.Page, req.PageSize, len(deliveries), int(total), response, nil)
+}
+
+// Redeliver logs a new pending delivery with the payload of the original one, the dispatcher sends it.
+func (u *UseCase) Redeliver(ctx context.Context, req *schema.RequestRedeliver) wrapper.JSONResult {
+	l := logger.WithID(u.Logger, ContextName, "Redeliver")
+
+	original := u.Repository.GetDelivery(ctx, req.ID, req.DeliveryID)
+	if original == nil {
+		l.Error("delivery not found", zap.String("id", req.DeliveryID))
+		return wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Delivery not found", nil)
+	}
+
+	now := time.Now()
+	delivery := &model.WebhookDelivery{
+		ID:             utils.GenerateUUID(),
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         schema.DeliveryStatusPending,
+		RedeliveryOf:   utils.ToPointer(original.ID),
+		CreatedAt:      now,
+		NextAttemptAt:  now,
+	}
+	if err := u.Repository.CreateDelivery(ctx, delivery); err != nil {
+		l.Error("failed to log delivery", zap.Error(err))
+		return wrapper.ResponseFailed(http.StatusInternalServerError, contract.StatusCodeInternalServerError, "Failed to redeliver", nil)
+	}
+
+	l.Info("redelivery queued", zap.String("deliveryId", delivery.ID), zap.String("original", original.ID))
+	return wrapper.ResponseSuccess(http.StatusAccepted, schema.ToDeliveryResponse(delivery))
+}
+
+// generateSecret returns 32 random bytes hex encoded.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
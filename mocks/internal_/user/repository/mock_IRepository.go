@@ -5,8 +5,13 @@ package repository
 import (
 	context "context"
 
-	model "github.com/Alwanly/go-codebase/model"
+	time "time"
+
+	authentication "github.com/Alwanly/go-codebase/pkg/authentication"
+
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/Alwanly/go-codebase/model"
 )
 
 // MockIRepository is an autogenerated mock type for the IRepository type
@@ -22,29 +27,142 @@ func (_m *MockIRepository) EXPECT() *MockIRepository_Expecter {
 	return &MockIRepository_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: ctx, username
-func (_m *MockIRepository) Login(ctx context.Context, username string) (*model.User, error) {
+// AddLoginFailure provides a mock function with given fields: ctx, username, ip, window
+func (_m *MockIRepository) AddLoginFailure(ctx context.Context, username string, ip string, window time.Duration) (int64, int64, error) {
+	ret := _m.Called(ctx, username, ip, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLoginFailure")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (int64, int64, error)); ok {
+		return rf(ctx, username, ip, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) int64); ok {
+		r0 = rf(ctx, username, ip, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) int64); ok {
+		r1 = rf(ctx, username, ip, window)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, time.Duration) error); ok {
+		r2 = rf(ctx, username, ip, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_AddLoginFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLoginFailure'
+type MockIRepository_AddLoginFailure_Call struct {
+	*mock.Call
+}
+
+// AddLoginFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - ip string
+//   - window time.Duration
+func (_e *MockIRepository_Expecter) AddLoginFailure(ctx interface{}, username interface{}, ip interface{}, window interface{}) *MockIRepository_AddLoginFailure_Call {
+	return &MockIRepository_AddLoginFailure_Call{Call: _e.mock.On("AddLoginFailure", ctx, username, ip, window)}
+}
+
+func (_c *MockIRepository_AddLoginFailure_Call) Run(run func(ctx context.Context, username string, ip string, window time.Duration)) *MockIRepository_AddLoginFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_AddLoginFailure_Call) Return(_a0 int64, _a1 int64, _a2 error) *MockIRepository_AddLoginFailure_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_AddLoginFailure_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (int64, int64, error)) *MockIRepository_AddLoginFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearLoginFailures provides a mock function with given fields: ctx, username
+func (_m *MockIRepository) ClearLoginFailures(ctx context.Context, username string) error {
 	ret := _m.Called(ctx, username)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for ClearLoginFailures")
 	}
 
-	var r0 *model.User
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_ClearLoginFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearLoginFailures'
+type MockIRepository_ClearLoginFailures_Call struct {
+	*mock.Call
+}
+
+// ClearLoginFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockIRepository_Expecter) ClearLoginFailures(ctx interface{}, username interface{}) *MockIRepository_ClearLoginFailures_Call {
+	return &MockIRepository_ClearLoginFailures_Call{Call: _e.mock.On("ClearLoginFailures", ctx, username)}
+}
+
+func (_c *MockIRepository_ClearLoginFailures_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_ClearLoginFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ClearLoginFailures_Call) Return(_a0 error) *MockIRepository_ClearLoginFailures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_ClearLoginFailures_Call) RunAndReturn(run func(context.Context, string) error) *MockIRepository_ClearLoginFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeOIDCRequest provides a mock function with given fields: ctx, state
+func (_m *MockIRepository) ConsumeOIDCRequest(ctx context.Context, state string) (*authentication.OIDCAuthRequest, error) {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeOIDCRequest")
+	}
+
+	var r0 *authentication.OIDCAuthRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
-		return rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*authentication.OIDCAuthRequest, error)); ok {
+		return rf(ctx, state)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *authentication.OIDCAuthRequest); ok {
+		r0 = rf(ctx, state)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.User)
+			r0 = ret.Get(0).(*authentication.OIDCAuthRequest)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, username)
+		r1 = rf(ctx, state)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,90 +170,2472 @@ func (_m *MockIRepository) Login(ctx context.Context, username string) (*model.U
 	return r0, r1
 }
 
-// MockIRepository_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type MockIRepository_Login_Call struct {
+// MockIRepository_ConsumeOIDCRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeOIDCRequest'
+type MockIRepository_ConsumeOIDCRequest_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// ConsumeOIDCRequest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-func (_e *MockIRepository_Expecter) Login(ctx interface{}, username interface{}) *MockIRepository_Login_Call {
-	return &MockIRepository_Login_Call{Call: _e.mock.On("Login", ctx, username)}
+//   - state string
+func (_e *MockIRepository_Expecter) ConsumeOIDCRequest(ctx interface{}, state interface{}) *MockIRepository_ConsumeOIDCRequest_Call {
+	return &MockIRepository_ConsumeOIDCRequest_Call{Call: _e.mock.On("ConsumeOIDCRequest", ctx, state)}
 }
 
-func (_c *MockIRepository_Login_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_Login_Call {
+func (_c *MockIRepository_ConsumeOIDCRequest_Call) Run(run func(ctx context.Context, state string)) *MockIRepository_ConsumeOIDCRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockIRepository_Login_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_Login_Call {
+func (_c *MockIRepository_ConsumeOIDCRequest_Call) Return(_a0 *authentication.OIDCAuthRequest, _a1 error) *MockIRepository_ConsumeOIDCRequest_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockIRepository_Login_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockIRepository_Login_Call {
+func (_c *MockIRepository_ConsumeOIDCRequest_Call) RunAndReturn(run func(context.Context, string) (*authentication.OIDCAuthRequest, error)) *MockIRepository_ConsumeOIDCRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function with given fields: ctx, _a1
-func (_m *MockIRepository) Register(ctx context.Context, _a1 *model.User) (*model.User, error) {
-	ret := _m.Called(ctx, _a1)
+// ConsumePasswordReset provides a mock function with given fields: ctx, tokenHash
+func (_m *MockIRepository) ConsumePasswordReset(ctx context.Context, tokenHash string) (string, bool, error) {
+	ret := _m.Called(ctx, tokenHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Register")
+		panic("no return value specified for ConsumePasswordReset")
 	}
 
-	var r0 *model.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.User) (*model.User, error)); ok {
-		return rf(ctx, _a1)
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, bool, error)); ok {
+		return rf(ctx, tokenHash)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *model.User) *model.User); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, tokenHash)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.User)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *model.User) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, tokenHash)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tokenHash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// MockIRepository_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type MockIRepository_Register_Call struct {
+// MockIRepository_ConsumePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumePasswordReset'
+type MockIRepository_ConsumePasswordReset_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
+// ConsumePasswordReset is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 *model.User
-func (_e *MockIRepository_Expecter) Register(ctx interface{}, _a1 interface{}) *MockIRepository_Register_Call {
-	return &MockIRepository_Register_Call{Call: _e.mock.On("Register", ctx, _a1)}
+//   - tokenHash string
+func (_e *MockIRepository_Expecter) ConsumePasswordReset(ctx interface{}, tokenHash interface{}) *MockIRepository_ConsumePasswordReset_Call {
+	return &MockIRepository_ConsumePasswordReset_Call{Call: _e.mock.On("ConsumePasswordReset", ctx, tokenHash)}
 }
 
-func (_c *MockIRepository_Register_Call) Run(run func(ctx context.Context, _a1 *model.User)) *MockIRepository_Register_Call {
+func (_c *MockIRepository_ConsumePasswordReset_Call) Run(run func(ctx context.Context, tokenHash string)) *MockIRepository_ConsumePasswordReset_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.User))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockIRepository_Register_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_Register_Call {
+func (_c *MockIRepository_ConsumePasswordReset_Call) Return(_a0 string, _a1 bool, _a2 error) *MockIRepository_ConsumePasswordReset_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_ConsumePasswordReset_Call) RunAndReturn(run func(context.Context, string) (string, bool, error)) *MockIRepository_ConsumePasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountIPLoginFailures provides a mock function with given fields: ctx, ip, window
+func (_m *MockIRepository) CountIPLoginFailures(ctx context.Context, ip string, window time.Duration) (int64, time.Duration, error) {
+	ret := _m.Called(ctx, ip, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountIPLoginFailures")
+	}
+
+	var r0 int64
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, time.Duration, error)); ok {
+		return rf(ctx, ip, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, ip, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) time.Duration); ok {
+		r1 = rf(ctx, ip, window)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, ip, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_CountIPLoginFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountIPLoginFailures'
+type MockIRepository_CountIPLoginFailures_Call struct {
+	*mock.Call
+}
+
+// CountIPLoginFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ip string
+//   - window time.Duration
+func (_e *MockIRepository_Expecter) CountIPLoginFailures(ctx interface{}, ip interface{}, window interface{}) *MockIRepository_CountIPLoginFailures_Call {
+	return &MockIRepository_CountIPLoginFailures_Call{Call: _e.mock.On("CountIPLoginFailures", ctx, ip, window)}
+}
+
+func (_c *MockIRepository_CountIPLoginFailures_Call) Run(run func(ctx context.Context, ip string, window time.Duration)) *MockIRepository_CountIPLoginFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_CountIPLoginFailures_Call) Return(_a0 int64, _a1 time.Duration, _a2 error) *MockIRepository_CountIPLoginFailures_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_CountIPLoginFailures_Call) RunAndReturn(run func(context.Context, string, time.Duration) (int64, time.Duration, error)) *MockIRepository_CountIPLoginFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuditEvent provides a mock function with given fields: ctx, event
+func (_m *MockIRepository) CreateAuditEvent(ctx context.Context, event *model.AuthAuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthAuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_CreateAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditEvent'
+type MockIRepository_CreateAuditEvent_Call struct {
+	*mock.Call
+}
+
+// CreateAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *model.AuthAuditEvent
+func (_e *MockIRepository_Expecter) CreateAuditEvent(ctx interface{}, event interface{}) *MockIRepository_CreateAuditEvent_Call {
+	return &MockIRepository_CreateAuditEvent_Call{Call: _e.mock.On("CreateAuditEvent", ctx, event)}
+}
+
+func (_c *MockIRepository_CreateAuditEvent_Call) Run(run func(ctx context.Context, event *model.AuthAuditEvent)) *MockIRepository_CreateAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.AuthAuditEvent))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_CreateAuditEvent_Call) Return(_a0 error) *MockIRepository_CreateAuditEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_CreateAuditEvent_Call) RunAndReturn(run func(context.Context, *model.AuthAuditEvent) error) *MockIRepository_CreateAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePasswordReset provides a mock function with given fields: ctx, userID, tokenHash, ttl
+func (_m *MockIRepository) CreatePasswordReset(ctx context.Context, userID string, tokenHash string, ttl time.Duration) error {
+	ret := _m.Called(ctx, userID, tokenHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, tokenHash, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_CreatePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordReset'
+type MockIRepository_CreatePasswordReset_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenHash string
+//   - ttl time.Duration
+func (_e *MockIRepository_Expecter) CreatePasswordReset(ctx interface{}, userID interface{}, tokenHash interface{}, ttl interface{}) *MockIRepository_CreatePasswordReset_Call {
+	return &MockIRepository_CreatePasswordReset_Call{Call: _e.mock.On("CreatePasswordReset", ctx, userID, tokenHash, ttl)}
+}
+
+func (_c *MockIRepository_CreatePasswordReset_Call) Run(run func(ctx context.Context, userID string, tokenHash string, ttl time.Duration)) *MockIRepository_CreatePasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_CreatePasswordReset_Call) Return(_a0 error) *MockIRepository_CreatePasswordReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_CreatePasswordReset_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) error) *MockIRepository_CreatePasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *MockIRepository) CreateSession(ctx context.Context, session *model.UserSession) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserSession) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type MockIRepository_CreateSession_Call struct {
+	*mock.Call
+}
+
+// CreateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *model.UserSession
+func (_e *MockIRepository_Expecter) CreateSession(ctx interface{}, session interface{}) *MockIRepository_CreateSession_Call {
+	return &MockIRepository_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, session)}
+}
+
+func (_c *MockIRepository_CreateSession_Call) Run(run func(ctx context.Context, session *model.UserSession)) *MockIRepository_CreateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserSession))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_CreateSession_Call) Return(_a0 error) *MockIRepository_CreateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_CreateSession_Call) RunAndReturn(run func(context.Context, *model.UserSession) error) *MockIRepository_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTokenFamily provides a mock function with given fields: ctx, family, tokenID, ttl
+func (_m *MockIRepository) CreateTokenFamily(ctx context.Context, family string, tokenID string, ttl time.Duration) error {
+	ret := _m.Called(ctx, family, tokenID, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, family, tokenID, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_CreateTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTokenFamily'
+type MockIRepository_CreateTokenFamily_Call struct {
+	*mock.Call
+}
+
+// CreateTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - family string
+//   - tokenID string
+//   - ttl time.Duration
+func (_e *MockIRepository_Expecter) CreateTokenFamily(ctx interface{}, family interface{}, tokenID interface{}, ttl interface{}) *MockIRepository_CreateTokenFamily_Call {
+	return &MockIRepository_CreateTokenFamily_Call{Call: _e.mock.On("CreateTokenFamily", ctx, family, tokenID, ttl)}
+}
+
+func (_c *MockIRepository_CreateTokenFamily_Call) Run(run func(ctx context.Context, family string, tokenID string, ttl time.Duration)) *MockIRepository_CreateTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_CreateTokenFamily_Call) Return(_a0 error) *MockIRepository_CreateTokenFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_CreateTokenFamily_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) error) *MockIRepository_CreateTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DelayLogin provides a mock function with given fields: ctx, username, delay
+func (_m *MockIRepository) DelayLogin(ctx context.Context, username string, delay time.Duration) error {
+	ret := _m.Called(ctx, username, delay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelayLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, username, delay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_DelayLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DelayLogin'
+type MockIRepository_DelayLogin_Call struct {
+	*mock.Call
+}
+
+// DelayLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - delay time.Duration
+func (_e *MockIRepository_Expecter) DelayLogin(ctx interface{}, username interface{}, delay interface{}) *MockIRepository_DelayLogin_Call {
+	return &MockIRepository_DelayLogin_Call{Call: _e.mock.On("DelayLogin", ctx, username, delay)}
+}
+
+func (_c *MockIRepository_DelayLogin_Call) Run(run func(ctx context.Context, username string, delay time.Duration)) *MockIRepository_DelayLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_DelayLogin_Call) Return(_a0 error) *MockIRepository_DelayLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_DelayLogin_Call) RunAndReturn(run func(context.Context, string, time.Duration) error) *MockIRepository_DelayLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, userID
+func (_m *MockIRepository) DisableTOTP(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type MockIRepository_DisableTOTP_Call struct {
+	*mock.Call
+}
+
+// DisableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockIRepository_Expecter) DisableTOTP(ctx interface{}, userID interface{}) *MockIRepository_DisableTOTP_Call {
+	return &MockIRepository_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, userID)}
+}
+
+func (_c *MockIRepository_DisableTOTP_Call) Run(run func(ctx context.Context, userID string)) *MockIRepository_DisableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_DisableTOTP_Call) Return(_a0 error) *MockIRepository_DisableTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_DisableTOTP_Call) RunAndReturn(run func(context.Context, string) error) *MockIRepository_DisableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EmailTaken provides a mock function with given fields: ctx, email, exceptID
+func (_m *MockIRepository) EmailTaken(ctx context.Context, email string, exceptID string) (bool, error) {
+	ret := _m.Called(ctx, email, exceptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailTaken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, email, exceptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, email, exceptID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, exceptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_EmailTaken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmailTaken'
+type MockIRepository_EmailTaken_Call struct {
+	*mock.Call
+}
+
+// EmailTaken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - exceptID string
+func (_e *MockIRepository_Expecter) EmailTaken(ctx interface{}, email interface{}, exceptID interface{}) *MockIRepository_EmailTaken_Call {
+	return &MockIRepository_EmailTaken_Call{Call: _e.mock.On("EmailTaken", ctx, email, exceptID)}
+}
+
+func (_c *MockIRepository_EmailTaken_Call) Run(run func(ctx context.Context, email string, exceptID string)) *MockIRepository_EmailTaken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_EmailTaken_Call) Return(_a0 bool, _a1 error) *MockIRepository_EmailTaken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_EmailTaken_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockIRepository_EmailTaken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableTOTP provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *MockIRepository) EnableTOTP(ctx context.Context, userID string, codeHashes []string) (bool, error) {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableTOTP")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (bool, error)); ok {
+		return rf(ctx, userID, codeHashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) bool); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, codeHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_EnableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableTOTP'
+type MockIRepository_EnableTOTP_Call struct {
+	*mock.Call
+}
+
+// EnableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - codeHashes []string
+func (_e *MockIRepository_Expecter) EnableTOTP(ctx interface{}, userID interface{}, codeHashes interface{}) *MockIRepository_EnableTOTP_Call {
+	return &MockIRepository_EnableTOTP_Call{Call: _e.mock.On("EnableTOTP", ctx, userID, codeHashes)}
+}
+
+func (_c *MockIRepository_EnableTOTP_Call) Run(run func(ctx context.Context, userID string, codeHashes []string)) *MockIRepository_EnableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_EnableTOTP_Call) Return(_a0 bool, _a1 error) *MockIRepository_EnableTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_EnableTOTP_Call) RunAndReturn(run func(context.Context, string, []string) (bool, error)) *MockIRepository_EnableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExtendSession provides a mock function with given fields: ctx, id, expiresAt
+func (_m *MockIRepository) ExtendSession(ctx context.Context, id string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, id, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_ExtendSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendSession'
+type MockIRepository_ExtendSession_Call struct {
+	*mock.Call
+}
+
+// ExtendSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - expiresAt time.Time
+func (_e *MockIRepository_Expecter) ExtendSession(ctx interface{}, id interface{}, expiresAt interface{}) *MockIRepository_ExtendSession_Call {
+	return &MockIRepository_ExtendSession_Call{Call: _e.mock.On("ExtendSession", ctx, id, expiresAt)}
+}
+
+func (_c *MockIRepository_ExtendSession_Call) Run(run func(ctx context.Context, id string, expiresAt time.Time)) *MockIRepository_ExtendSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ExtendSession_Call) Return(_a0 error) *MockIRepository_ExtendSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_ExtendSession_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *MockIRepository_ExtendSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *MockIRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type MockIRepository_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockIRepository_Expecter) GetByEmail(ctx interface{}, email interface{}) *MockIRepository_GetByEmail_Call {
+	return &MockIRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+}
+
+func (_c *MockIRepository_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *MockIRepository_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetByEmail_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_GetByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetByEmail_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockIRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockIRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockIRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockIRepository_GetByID_Call {
+	return &MockIRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockIRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockIRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetByID_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockIRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockIRepository) GetByIDs(ctx context.Context, ids []string) ([]model.User, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.User, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.User); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockIRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockIRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockIRepository_GetByIDs_Call {
+	return &MockIRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *MockIRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockIRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetByIDs_Call) Return(_a0 []model.User, _a1 error) *MockIRepository_GetByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetByIDs_Call) RunAndReturn(run func(context.Context, []string) ([]model.User, error)) *MockIRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *MockIRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockIRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockIRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockIRepository_GetByUsername_Call {
+	return &MockIRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *MockIRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetByUsername_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_GetByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetByUsername_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockIRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *MockIRepository) GetIdentity(ctx context.Context, issuer string, subject string) (*model.UserIdentity, error) {
+	ret := _m.Called(ctx, issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdentity")
+	}
+
+	var r0 *model.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.UserIdentity, error)); ok {
+		return rf(ctx, issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.UserIdentity); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdentity'
+type MockIRepository_GetIdentity_Call struct {
+	*mock.Call
+}
+
+// GetIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+//   - subject string
+func (_e *MockIRepository_Expecter) GetIdentity(ctx interface{}, issuer interface{}, subject interface{}) *MockIRepository_GetIdentity_Call {
+	return &MockIRepository_GetIdentity_Call{Call: _e.mock.On("GetIdentity", ctx, issuer, subject)}
+}
+
+func (_c *MockIRepository_GetIdentity_Call) Run(run func(ctx context.Context, issuer string, subject string)) *MockIRepository_GetIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetIdentity_Call) Return(_a0 *model.UserIdentity, _a1 error) *MockIRepository_GetIdentity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetIdentity_Call) RunAndReturn(run func(context.Context, string, string) (*model.UserIdentity, error)) *MockIRepository_GetIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoles provides a mock function with given fields: ctx, userID
+func (_m *MockIRepository) GetRoles(ctx context.Context, userID string) ([]string, []string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []string
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, []string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) []string); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_GetRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoles'
+type MockIRepository_GetRoles_Call struct {
+	*mock.Call
+}
+
+// GetRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockIRepository_Expecter) GetRoles(ctx interface{}, userID interface{}) *MockIRepository_GetRoles_Call {
+	return &MockIRepository_GetRoles_Call{Call: _e.mock.On("GetRoles", ctx, userID)}
+}
+
+func (_c *MockIRepository_GetRoles_Call) Run(run func(ctx context.Context, userID string)) *MockIRepository_GetRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetRoles_Call) Return(_a0 []string, _a1 []string, _a2 error) *MockIRepository_GetRoles_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_GetRoles_Call) RunAndReturn(run func(context.Context, string) ([]string, []string, error)) *MockIRepository_GetRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenFamily provides a mock function with given fields: ctx, family
+func (_m *MockIRepository) GetTokenFamily(ctx context.Context, family string) (string, bool, error) {
+	ret := _m.Called(ctx, family)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenFamily")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, bool, error)); ok {
+		return rf(ctx, family)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, family)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, family)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, family)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_GetTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenFamily'
+type MockIRepository_GetTokenFamily_Call struct {
+	*mock.Call
+}
+
+// GetTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - family string
+func (_e *MockIRepository_Expecter) GetTokenFamily(ctx interface{}, family interface{}) *MockIRepository_GetTokenFamily_Call {
+	return &MockIRepository_GetTokenFamily_Call{Call: _e.mock.On("GetTokenFamily", ctx, family)}
+}
+
+func (_c *MockIRepository_GetTokenFamily_Call) Run(run func(ctx context.Context, family string)) *MockIRepository_GetTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetTokenFamily_Call) Return(_a0 string, _a1 bool, _a2 error) *MockIRepository_GetTokenFamily_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_GetTokenFamily_Call) RunAndReturn(run func(context.Context, string) (string, bool, error)) *MockIRepository_GetTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, userIDs
+func (_m *MockIRepository) GetUserRoles(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string][]string, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string][]string); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type MockIRepository_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockIRepository_Expecter) GetUserRoles(ctx interface{}, userIDs interface{}) *MockIRepository_GetUserRoles_Call {
+	return &MockIRepository_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", ctx, userIDs)}
+}
+
+func (_c *MockIRepository_GetUserRoles_Call) Run(run func(ctx context.Context, userIDs []string)) *MockIRepository_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_GetUserRoles_Call) Return(_a0 map[string][]string, _a1 error) *MockIRepository_GetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_GetUserRoles_Call) RunAndReturn(run func(context.Context, []string) (map[string][]string, error)) *MockIRepository_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkIdentity provides a mock function with given fields: ctx, identity
+func (_m *MockIRepository) LinkIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_LinkIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkIdentity'
+type MockIRepository_LinkIdentity_Call struct {
+	*mock.Call
+}
+
+// LinkIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *model.UserIdentity
+func (_e *MockIRepository_Expecter) LinkIdentity(ctx interface{}, identity interface{}) *MockIRepository_LinkIdentity_Call {
+	return &MockIRepository_LinkIdentity_Call{Call: _e.mock.On("LinkIdentity", ctx, identity)}
+}
+
+func (_c *MockIRepository_LinkIdentity_Call) Run(run func(ctx context.Context, identity *model.UserIdentity)) *MockIRepository_LinkIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserIdentity))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_LinkIdentity_Call) Return(_a0 error) *MockIRepository_LinkIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_LinkIdentity_Call) RunAndReturn(run func(context.Context, *model.UserIdentity) error) *MockIRepository_LinkIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *MockIRepository) ListRoles(ctx context.Context) ([]model.Role, []model.RolePermission, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []model.Role
+	var r1 []model.RolePermission
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Role, []model.RolePermission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) []model.RolePermission); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.RolePermission)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockIRepository_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIRepository_Expecter) ListRoles(ctx interface{}) *MockIRepository_ListRoles_Call {
+	return &MockIRepository_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *MockIRepository_ListRoles_Call) Run(run func(ctx context.Context)) *MockIRepository_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ListRoles_Call) Return(_a0 []model.Role, _a1 []model.RolePermission, _a2 error) *MockIRepository_ListRoles_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_ListRoles_Call) RunAndReturn(run func(context.Context) ([]model.Role, []model.RolePermission, error)) *MockIRepository_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: ctx, userID
+func (_m *MockIRepository) ListSessions(ctx context.Context, userID string) ([]model.UserSession, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []model.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.UserSession, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.UserSession); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockIRepository_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockIRepository_Expecter) ListSessions(ctx interface{}, userID interface{}) *MockIRepository_ListSessions_Call {
+	return &MockIRepository_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, userID)}
+}
+
+func (_c *MockIRepository_ListSessions_Call) Run(run func(ctx context.Context, userID string)) *MockIRepository_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ListSessions_Call) Return(_a0 []model.UserSession, _a1 error) *MockIRepository_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_ListSessions_Call) RunAndReturn(run func(context.Context, string) ([]model.UserSession, error)) *MockIRepository_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockLogin provides a mock function with given fields: ctx, username, duration
+func (_m *MockIRepository) LockLogin(ctx context.Context, username string, duration time.Duration) error {
+	ret := _m.Called(ctx, username, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, username, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_LockLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockLogin'
+type MockIRepository_LockLogin_Call struct {
+	*mock.Call
+}
+
+// LockLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - duration time.Duration
+func (_e *MockIRepository_Expecter) LockLogin(ctx interface{}, username interface{}, duration interface{}) *MockIRepository_LockLogin_Call {
+	return &MockIRepository_LockLogin_Call{Call: _e.mock.On("LockLogin", ctx, username, duration)}
+}
+
+func (_c *MockIRepository_LockLogin_Call) Run(run func(ctx context.Context, username string, duration time.Duration)) *MockIRepository_LockLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_LockLogin_Call) Return(_a0 error) *MockIRepository_LockLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_LockLogin_Call) RunAndReturn(run func(context.Context, string, time.Duration) error) *MockIRepository_LockLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, username
+func (_m *MockIRepository) Login(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockIRepository_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockIRepository_Expecter) Login(ctx interface{}, username interface{}) *MockIRepository_Login_Call {
+	return &MockIRepository_Login_Call{Call: _e.mock.On("Login", ctx, username)}
+}
+
+func (_c *MockIRepository_Login_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_Login_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_Login_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockIRepository_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginBlockedFor provides a mock function with given fields: ctx, username
+func (_m *MockIRepository) LoginBlockedFor(ctx context.Context, username string) (time.Duration, bool, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginBlockedFor")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, bool, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, username)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRepository_LoginBlockedFor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginBlockedFor'
+type MockIRepository_LoginBlockedFor_Call struct {
+	*mock.Call
+}
+
+// LoginBlockedFor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockIRepository_Expecter) LoginBlockedFor(ctx interface{}, username interface{}) *MockIRepository_LoginBlockedFor_Call {
+	return &MockIRepository_LoginBlockedFor_Call{Call: _e.mock.On("LoginBlockedFor", ctx, username)}
+}
+
+func (_c *MockIRepository_LoginBlockedFor_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_LoginBlockedFor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_LoginBlockedFor_Call) Return(_a0 time.Duration, _a1 bool, _a2 error) *MockIRepository_LoginBlockedFor_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRepository_LoginBlockedFor_Call) RunAndReturn(run func(context.Context, string) (time.Duration, bool, error)) *MockIRepository_LoginBlockedFor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEmailVerified provides a mock function with given fields: ctx, id, email
+func (_m *MockIRepository) MarkEmailVerified(ctx context.Context, id string, email string) (bool, error) {
+	ret := _m.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEmailVerified")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, id, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_MarkEmailVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailVerified'
+type MockIRepository_MarkEmailVerified_Call struct {
+	*mock.Call
+}
+
+// MarkEmailVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - email string
+func (_e *MockIRepository_Expecter) MarkEmailVerified(ctx interface{}, id interface{}, email interface{}) *MockIRepository_MarkEmailVerified_Call {
+	return &MockIRepository_MarkEmailVerified_Call{Call: _e.mock.On("MarkEmailVerified", ctx, id, email)}
+}
+
+func (_c *MockIRepository_MarkEmailVerified_Call) Run(run func(ctx context.Context, id string, email string)) *MockIRepository_MarkEmailVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_MarkEmailVerified_Call) Return(_a0 bool, _a1 error) *MockIRepository_MarkEmailVerified_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_MarkEmailVerified_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockIRepository_MarkEmailVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProvisionUser provides a mock function with given fields: ctx, user, role, identity
+func (_m *MockIRepository) ProvisionUser(ctx context.Context, user *model.User, role string, identity *model.UserIdentity) (*model.User, error) {
+	ret := _m.Called(ctx, user, role, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProvisionUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string, *model.UserIdentity) (*model.User, error)); ok {
+		return rf(ctx, user, role, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string, *model.UserIdentity) *model.User); ok {
+		r0 = rf(ctx, user, role, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string, *model.UserIdentity) error); ok {
+		r1 = rf(ctx, user, role, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_ProvisionUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProvisionUser'
+type MockIRepository_ProvisionUser_Call struct {
+	*mock.Call
+}
+
+// ProvisionUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+//   - role string
+//   - identity *model.UserIdentity
+func (_e *MockIRepository_Expecter) ProvisionUser(ctx interface{}, user interface{}, role interface{}, identity interface{}) *MockIRepository_ProvisionUser_Call {
+	return &MockIRepository_ProvisionUser_Call{Call: _e.mock.On("ProvisionUser", ctx, user, role, identity)}
+}
+
+func (_c *MockIRepository_ProvisionUser_Call) Run(run func(ctx context.Context, user *model.User, role string, identity *model.UserIdentity)) *MockIRepository_ProvisionUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(string), args[3].(*model.UserIdentity))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ProvisionUser_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_ProvisionUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_ProvisionUser_Call) RunAndReturn(run func(context.Context, *model.User, string, *model.UserIdentity) (*model.User, error)) *MockIRepository_ProvisionUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, user, role
+func (_m *MockIRepository) Register(ctx context.Context, user *model.User, role string) (*model.User, error) {
+	ret := _m.Called(ctx, user, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) (*model.User, error)); ok {
+		return rf(ctx, user, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) *model.User); ok {
+		r0 = rf(ctx, user, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
+		r1 = rf(ctx, user, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockIRepository_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+//   - role string
+func (_e *MockIRepository_Expecter) Register(ctx interface{}, user interface{}, role interface{}) *MockIRepository_Register_Call {
+	return &MockIRepository_Register_Call{Call: _e.mock.On("Register", ctx, user, role)}
+}
+
+func (_c *MockIRepository_Register_Call) Run(run func(ctx context.Context, user *model.User, role string)) *MockIRepository_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_Register_Call) Return(_a0 *model.User, _a1 error) *MockIRepository_Register_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_Register_Call) RunAndReturn(run func(context.Context, *model.User, string) (*model.User, error)) *MockIRepository_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *MockIRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_ReplaceRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceRecoveryCodes'
+type MockIRepository_ReplaceRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// ReplaceRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - codeHashes []string
+func (_e *MockIRepository_Expecter) ReplaceRecoveryCodes(ctx interface{}, userID interface{}, codeHashes interface{}) *MockIRepository_ReplaceRecoveryCodes_Call {
+	return &MockIRepository_ReplaceRecoveryCodes_Call{Call: _e.mock.On("ReplaceRecoveryCodes", ctx, userID, codeHashes)}
+}
+
+func (_c *MockIRepository_ReplaceRecoveryCodes_Call) Run(run func(ctx context.Context, userID string, codeHashes []string)) *MockIRepository_ReplaceRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ReplaceRecoveryCodes_Call) Return(_a0 error) *MockIRepository_ReplaceRecoveryCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_ReplaceRecoveryCodes_Call) RunAndReturn(run func(context.Context, string, []string) error) *MockIRepository_ReplaceRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, id
+func (_m *MockIRepository) RevokeSession(ctx context.Context, userID string, id string) (bool, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockIRepository_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+func (_e *MockIRepository_Expecter) RevokeSession(ctx interface{}, userID interface{}, id interface{}) *MockIRepository_RevokeSession_Call {
+	return &MockIRepository_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, id)}
+}
+
+func (_c *MockIRepository_RevokeSession_Call) Run(run func(ctx context.Context, userID string, id string)) *MockIRepository_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_RevokeSession_Call) Return(_a0 bool, _a1 error) *MockIRepository_RevokeSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_RevokeSession_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockIRepository_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, userID, exceptID
+func (_m *MockIRepository) RevokeSessions(ctx context.Context, userID string, exceptID string) error {
+	ret := _m.Called(ctx, userID, exceptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, exceptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_RevokeSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessions'
+type MockIRepository_RevokeSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - exceptID string
+func (_e *MockIRepository_Expecter) RevokeSessions(ctx interface{}, userID interface{}, exceptID interface{}) *MockIRepository_RevokeSessions_Call {
+	return &MockIRepository_RevokeSessions_Call{Call: _e.mock.On("RevokeSessions", ctx, userID, exceptID)}
+}
+
+func (_c *MockIRepository_RevokeSessions_Call) Run(run func(ctx context.Context, userID string, exceptID string)) *MockIRepository_RevokeSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_RevokeSessions_Call) Return(_a0 error) *MockIRepository_RevokeSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_RevokeSessions_Call) RunAndReturn(run func(context.Context, string, string) error) *MockIRepository_RevokeSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeTokenFamily provides a mock function with given fields: ctx, family
+func (_m *MockIRepository) RevokeTokenFamily(ctx context.Context, family string) error {
+	ret := _m.Called(ctx, family)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, family)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_RevokeTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeTokenFamily'
+type MockIRepository_RevokeTokenFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - family string
+func (_e *MockIRepository_Expecter) RevokeTokenFamily(ctx interface{}, family interface{}) *MockIRepository_RevokeTokenFamily_Call {
+	return &MockIRepository_RevokeTokenFamily_Call{Call: _e.mock.On("RevokeTokenFamily", ctx, family)}
+}
+
+func (_c *MockIRepository_RevokeTokenFamily_Call) Run(run func(ctx context.Context, family string)) *MockIRepository_RevokeTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_RevokeTokenFamily_Call) Return(_a0 error) *MockIRepository_RevokeTokenFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_RevokeTokenFamily_Call) RunAndReturn(run func(context.Context, string) error) *MockIRepository_RevokeTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateTokenFamily provides a mock function with given fields: ctx, family, tokenID, nextTokenID, ttl
+func (_m *MockIRepository) RotateTokenFamily(ctx context.Context, family string, tokenID string, nextTokenID string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, family, tokenID, nextTokenID, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateTokenFamily")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, family, tokenID, nextTokenID, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, family, tokenID, nextTokenID, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, family, tokenID, nextTokenID, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_RotateTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateTokenFamily'
+type MockIRepository_RotateTokenFamily_Call struct {
+	*mock.Call
+}
+
+// RotateTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - family string
+//   - tokenID string
+//   - nextTokenID string
+//   - ttl time.Duration
+func (_e *MockIRepository_Expecter) RotateTokenFamily(ctx interface{}, family interface{}, tokenID interface{}, nextTokenID interface{}, ttl interface{}) *MockIRepository_RotateTokenFamily_Call {
+	return &MockIRepository_RotateTokenFamily_Call{Call: _e.mock.On("RotateTokenFamily", ctx, family, tokenID, nextTokenID, ttl)}
+}
+
+func (_c *MockIRepository_RotateTokenFamily_Call) Run(run func(ctx context.Context, family string, tokenID string, nextTokenID string, ttl time.Duration)) *MockIRepository_RotateTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_RotateTokenFamily_Call) Return(_a0 bool, _a1 error) *MockIRepository_RotateTokenFamily_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_RotateTokenFamily_Call) RunAndReturn(run func(context.Context, string, string, string, time.Duration) (bool, error)) *MockIRepository_RotateTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveOIDCRequest provides a mock function with given fields: ctx, req, ttl
+func (_m *MockIRepository) SaveOIDCRequest(ctx context.Context, req *authentication.OIDCAuthRequest, ttl time.Duration) error {
+	ret := _m.Called(ctx, req, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOIDCRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authentication.OIDCAuthRequest, time.Duration) error); ok {
+		r0 = rf(ctx, req, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_SaveOIDCRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveOIDCRequest'
+type MockIRepository_SaveOIDCRequest_Call struct {
+	*mock.Call
+}
+
+// SaveOIDCRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *authentication.OIDCAuthRequest
+//   - ttl time.Duration
+func (_e *MockIRepository_Expecter) SaveOIDCRequest(ctx interface{}, req interface{}, ttl interface{}) *MockIRepository_SaveOIDCRequest_Call {
+	return &MockIRepository_SaveOIDCRequest_Call{Call: _e.mock.On("SaveOIDCRequest", ctx, req, ttl)}
+}
+
+func (_c *MockIRepository_SaveOIDCRequest_Call) Run(run func(ctx context.Context, req *authentication.OIDCAuthRequest, ttl time.Duration)) *MockIRepository_SaveOIDCRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*authentication.OIDCAuthRequest), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_SaveOIDCRequest_Call) Return(_a0 error) *MockIRepository_SaveOIDCRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_SaveOIDCRequest_Call) RunAndReturn(run func(context.Context, *authentication.OIDCAuthRequest, time.Duration) error) *MockIRepository_SaveOIDCRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTOTPSecret provides a mock function with given fields: ctx, userID, secret
+func (_m *MockIRepository) SetTOTPSecret(ctx context.Context, userID string, secret string) (bool, error) {
+	ret := _m.Called(ctx, userID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTOTPSecret")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_SetTOTPSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTOTPSecret'
+type MockIRepository_SetTOTPSecret_Call struct {
+	*mock.Call
+}
+
+// SetTOTPSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - secret string
+func (_e *MockIRepository_Expecter) SetTOTPSecret(ctx interface{}, userID interface{}, secret interface{}) *MockIRepository_SetTOTPSecret_Call {
+	return &MockIRepository_SetTOTPSecret_Call{Call: _e.mock.On("SetTOTPSecret", ctx, userID, secret)}
+}
+
+func (_c *MockIRepository_SetTOTPSecret_Call) Run(run func(ctx context.Context, userID string, secret string)) *MockIRepository_SetTOTPSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_SetTOTPSecret_Call) Return(_a0 bool, _a1 error) *MockIRepository_SetTOTPSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_SetTOTPSecret_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockIRepository_SetTOTPSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserRoles provides a mock function with given fields: ctx, userID, roles
+func (_m *MockIRepository) SetUserRoles(ctx context.Context, userID string, roles []string) error {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_SetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRoles'
+type MockIRepository_SetUserRoles_Call struct {
+	*mock.Call
+}
+
+// SetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - roles []string
+func (_e *MockIRepository_Expecter) SetUserRoles(ctx interface{}, userID interface{}, roles interface{}) *MockIRepository_SetUserRoles_Call {
+	return &MockIRepository_SetUserRoles_Call{Call: _e.mock.On("SetUserRoles", ctx, userID, roles)}
+}
+
+func (_c *MockIRepository_SetUserRoles_Call) Run(run func(ctx context.Context, userID string, roles []string)) *MockIRepository_SetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_SetUserRoles_Call) Return(_a0 error) *MockIRepository_SetUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_SetUserRoles_Call) RunAndReturn(run func(context.Context, string, []string) error) *MockIRepository_SetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ThrottleEmailVerification provides a mock function with given fields: ctx, userID, interval
+func (_m *MockIRepository) ThrottleEmailVerification(ctx context.Context, userID string, interval time.Duration) (bool, error) {
+	ret := _m.Called(ctx, userID, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThrottleEmailVerification")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, userID, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, userID, interval)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, userID, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_ThrottleEmailVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ThrottleEmailVerification'
+type MockIRepository_ThrottleEmailVerification_Call struct {
+	*mock.Call
+}
+
+// ThrottleEmailVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - interval time.Duration
+func (_e *MockIRepository_Expecter) ThrottleEmailVerification(ctx interface{}, userID interface{}, interval interface{}) *MockIRepository_ThrottleEmailVerification_Call {
+	return &MockIRepository_ThrottleEmailVerification_Call{Call: _e.mock.On("ThrottleEmailVerification", ctx, userID, interval)}
+}
+
+func (_c *MockIRepository_ThrottleEmailVerification_Call) Run(run func(ctx context.Context, userID string, interval time.Duration)) *MockIRepository_ThrottleEmailVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ThrottleEmailVerification_Call) Return(_a0 bool, _a1 error) *MockIRepository_ThrottleEmailVerification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_ThrottleEmailVerification_Call) RunAndReturn(run func(context.Context, string, time.Duration) (bool, error)) *MockIRepository_ThrottleEmailVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ThrottlePasswordReset provides a mock function with given fields: ctx, username, ip, window, maxPerUser, maxPerIP
+func (_m *MockIRepository) ThrottlePasswordReset(ctx context.Context, username string, ip string, window time.Duration, maxPerUser int, maxPerIP int) (time.Duration, error) {
+	ret := _m.Called(ctx, username, ip, window, maxPerUser, maxPerIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThrottlePasswordReset")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int, int) (time.Duration, error)); ok {
+		return rf(ctx, username, ip, window, maxPerUser, maxPerIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int, int) time.Duration); ok {
+		r0 = rf(ctx, username, ip, window, maxPerUser, maxPerIP)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int, int) error); ok {
+		r1 = rf(ctx, username, ip, window, maxPerUser, maxPerIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_ThrottlePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ThrottlePasswordReset'
+type MockIRepository_ThrottlePasswordReset_Call struct {
+	*mock.Call
+}
+
+// ThrottlePasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - ip string
+//   - window time.Duration
+//   - maxPerUser int
+//   - maxPerIP int
+func (_e *MockIRepository_Expecter) ThrottlePasswordReset(ctx interface{}, username interface{}, ip interface{}, window interface{}, maxPerUser interface{}, maxPerIP interface{}) *MockIRepository_ThrottlePasswordReset_Call {
+	return &MockIRepository_ThrottlePasswordReset_Call{Call: _e.mock.On("ThrottlePasswordReset", ctx, username, ip, window, maxPerUser, maxPerIP)}
+}
+
+func (_c *MockIRepository_ThrottlePasswordReset_Call) Run(run func(ctx context.Context, username string, ip string, window time.Duration, maxPerUser int, maxPerIP int)) *MockIRepository_ThrottlePasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int), args[5].(int))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_ThrottlePasswordReset_Call) Return(_a0 time.Duration, _a1 error) *MockIRepository_ThrottlePasswordReset_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_ThrottlePasswordReset_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int, int) (time.Duration, error)) *MockIRepository_ThrottlePasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *MockIRepository) TouchIdentity(ctx context.Context, issuer string, subject string) error {
+	ret := _m.Called(ctx, issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_TouchIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchIdentity'
+type MockIRepository_TouchIdentity_Call struct {
+	*mock.Call
+}
+
+// TouchIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+//   - subject string
+func (_e *MockIRepository_Expecter) TouchIdentity(ctx interface{}, issuer interface{}, subject interface{}) *MockIRepository_TouchIdentity_Call {
+	return &MockIRepository_TouchIdentity_Call{Call: _e.mock.On("TouchIdentity", ctx, issuer, subject)}
+}
+
+func (_c *MockIRepository_TouchIdentity_Call) Run(run func(ctx context.Context, issuer string, subject string)) *MockIRepository_TouchIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_TouchIdentity_Call) Return(_a0 error) *MockIRepository_TouchIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_TouchIdentity_Call) RunAndReturn(run func(context.Context, string, string) error) *MockIRepository_TouchIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockLogin provides a mock function with given fields: ctx, username
+func (_m *MockIRepository) UnlockLogin(ctx context.Context, username string) (bool, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockLogin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_UnlockLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockLogin'
+type MockIRepository_UnlockLogin_Call struct {
+	*mock.Call
+}
+
+// UnlockLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockIRepository_Expecter) UnlockLogin(ctx interface{}, username interface{}) *MockIRepository_UnlockLogin_Call {
+	return &MockIRepository_UnlockLogin_Call{Call: _e.mock.On("UnlockLogin", ctx, username)}
+}
+
+func (_c *MockIRepository_UnlockLogin_Call) Run(run func(ctx context.Context, username string)) *MockIRepository_UnlockLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_UnlockLogin_Call) Return(_a0 bool, _a1 error) *MockIRepository_UnlockLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_UnlockLogin_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockIRepository_UnlockLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, hash
+func (_m *MockIRepository) UpdatePassword(ctx context.Context, id string, hash string) error {
+	ret := _m.Called(ctx, id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockIRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - hash string
+func (_e *MockIRepository_Expecter) UpdatePassword(ctx interface{}, id interface{}, hash interface{}) *MockIRepository_UpdatePassword_Call {
+	return &MockIRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, hash)}
+}
+
+func (_c *MockIRepository_UpdatePassword_Call) Run(run func(ctx context.Context, id string, hash string)) *MockIRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_UpdatePassword_Call) Return(_a0 error) *MockIRepository_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_UpdatePassword_Call) RunAndReturn(run func(context.Context, string, string) error) *MockIRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, user
+func (_m *MockIRepository) UpdateProfile(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRepository_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockIRepository_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+func (_e *MockIRepository_Expecter) UpdateProfile(ctx interface{}, user interface{}) *MockIRepository_UpdateProfile_Call {
+	return &MockIRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, user)}
+}
+
+func (_c *MockIRepository_UpdateProfile_Call) Run(run func(ctx context.Context, user *model.User)) *MockIRepository_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_UpdateProfile_Call) Return(_a0 error) *MockIRepository_UpdateProfile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRepository_UpdateProfile_Call) RunAndReturn(run func(context.Context, *model.User) error) *MockIRepository_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *MockIRepository) UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockIRepository_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - codeHash string
+func (_e *MockIRepository_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *MockIRepository_UseRecoveryCode_Call {
+	return &MockIRepository_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, codeHash)}
+}
+
+func (_c *MockIRepository_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID string, codeHash string)) *MockIRepository_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_UseRecoveryCode_Call) Return(_a0 bool, _a1 error) *MockIRepository_UseRecoveryCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRepository_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockIRepository_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTOTPCode provides a mock function with given fields: ctx, userID, counter, ttl
+func (_m *MockIRepository) UseTOTPCode(ctx context.Context, userID string, counter int64, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, userID, counter, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (bool, error)); ok {
+		return rf(ctx, userID, counter, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) bool); ok {
+		r0 = rf(ctx, userID, counter, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, userID, counter, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRepository_UseTOTPCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPCode'
+type MockIRepository_UseTOTPCode_Call struct {
+	*mock.Call
+}
+
+// UseTOTPCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - counter int64
+//   - ttl time.Duration
+func (_e *MockIRepository_Expecter) UseTOTPCode(ctx interface{}, userID interface{}, counter interface{}, ttl interface{}) *MockIRepository_UseTOTPCode_Call {
+	return &MockIRepository_UseTOTPCode_Call{Call: _e.mock.On("UseTOTPCode", ctx, userID, counter, ttl)}
+}
+
+func (_c *MockIRepository_UseTOTPCode_Call) Run(run func(ctx context.Context, userID string, counter int64, ttl time.Duration)) *MockIRepository_UseTOTPCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRepository_UseTOTPCode_Call) Return(_a0 bool, _a1 error) *MockIRepository_UseTOTPCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockIRepository_Register_Call) RunAndReturn(run func(context.Context, *model.User) (*model.User, error)) *MockIRepository_Register_Call {
+func (_c *MockIRepository_UseTOTPCode_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (bool, error)) *MockIRepository_UseTOTPCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
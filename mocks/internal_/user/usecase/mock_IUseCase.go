@@ -5,9 +5,10 @@ package usecase
 import (
 	context "context"
 
-	schema "github.com/Alwanly/go-codebase/internal/user/schema"
 	mock "github.com/stretchr/testify/mock"
 
+	schema "github.com/Alwanly/go-codebase/internal/user/schema"
+
 	wrapper "github.com/Alwanly/go-codebase/pkg/wrapper"
 )
 
@@ -71,16 +72,16 @@ func (_c *MockIUseCase_Auth_Call) RunAndReturn(run func(context.Context, *schema
 	return _c
 }
 
-// Profile provides a mock function with given fields: _a0, _a1
-func (_m *MockIUseCase) Profile(_a0 context.Context, _a1 *schema.ProfileRequest) wrapper.JSONResult {
+// ChangePassword provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ChangePassword(_a0 context.Context, _a1 *schema.PasswordChangeRequest) wrapper.JSONResult {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Profile")
+		panic("no return value specified for ChangePassword")
 	}
 
 	var r0 wrapper.JSONResult
-	if rf, ok := ret.Get(0).(func(context.Context, *schema.ProfileRequest) wrapper.JSONResult); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.PasswordChangeRequest) wrapper.JSONResult); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(wrapper.JSONResult)
@@ -89,46 +90,46 @@ func (_m *MockIUseCase) Profile(_a0 context.Context, _a1 *schema.ProfileRequest)
 	return r0
 }
 
-// MockIUseCase_Profile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Profile'
-type MockIUseCase_Profile_Call struct {
+// MockIUseCase_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockIUseCase_ChangePassword_Call struct {
 	*mock.Call
 }
 
-// Profile is a helper method to define mock.On call
+// ChangePassword is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *schema.ProfileRequest
-func (_e *MockIUseCase_Expecter) Profile(_a0 interface{}, _a1 interface{}) *MockIUseCase_Profile_Call {
-	return &MockIUseCase_Profile_Call{Call: _e.mock.On("Profile", _a0, _a1)}
+//   - _a1 *schema.PasswordChangeRequest
+func (_e *MockIUseCase_Expecter) ChangePassword(_a0 interface{}, _a1 interface{}) *MockIUseCase_ChangePassword_Call {
+	return &MockIUseCase_ChangePassword_Call{Call: _e.mock.On("ChangePassword", _a0, _a1)}
 }
 
-func (_c *MockIUseCase_Profile_Call) Run(run func(_a0 context.Context, _a1 *schema.ProfileRequest)) *MockIUseCase_Profile_Call {
+func (_c *MockIUseCase_ChangePassword_Call) Run(run func(_a0 context.Context, _a1 *schema.PasswordChangeRequest)) *MockIUseCase_ChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*schema.ProfileRequest))
+		run(args[0].(context.Context), args[1].(*schema.PasswordChangeRequest))
 	})
 	return _c
 }
 
-func (_c *MockIUseCase_Profile_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Profile_Call {
+func (_c *MockIUseCase_ChangePassword_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ChangePassword_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockIUseCase_Profile_Call) RunAndReturn(run func(context.Context, *schema.ProfileRequest) wrapper.JSONResult) *MockIUseCase_Profile_Call {
+func (_c *MockIUseCase_ChangePassword_Call) RunAndReturn(run func(context.Context, *schema.PasswordChangeRequest) wrapper.JSONResult) *MockIUseCase_ChangePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function with given fields: ctx, req
-func (_m *MockIUseCase) Register(ctx context.Context, req *schema.AuthRegisterRequest) wrapper.JSONResult {
-	ret := _m.Called(ctx, req)
+// ConfirmTwoFactor provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ConfirmTwoFactor(_a0 context.Context, _a1 *schema.TwoFactorConfirmRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Register")
+		panic("no return value specified for ConfirmTwoFactor")
 	}
 
 	var r0 wrapper.JSONResult
-	if rf, ok := ret.Get(0).(func(context.Context, *schema.AuthRegisterRequest) wrapper.JSONResult); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TwoFactorConfirmRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(wrapper.JSONResult)
 	}
@@ -136,31 +137,1158 @@ func (_m *MockIUseCase) Register(ctx context.Context, req *schema.AuthRegisterRe
 	return r0
 }
 
-// MockIUseCase_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type MockIUseCase_Register_Call struct {
+// MockIUseCase_ConfirmTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTwoFactor'
+type MockIUseCase_ConfirmTwoFactor_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
-//   - ctx context.Context
-//   - req *schema.AuthRegisterRequest
-func (_e *MockIUseCase_Expecter) Register(ctx interface{}, req interface{}) *MockIUseCase_Register_Call {
-	return &MockIUseCase_Register_Call{Call: _e.mock.On("Register", ctx, req)}
+// ConfirmTwoFactor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.TwoFactorConfirmRequest
+func (_e *MockIUseCase_Expecter) ConfirmTwoFactor(_a0 interface{}, _a1 interface{}) *MockIUseCase_ConfirmTwoFactor_Call {
+	return &MockIUseCase_ConfirmTwoFactor_Call{Call: _e.mock.On("ConfirmTwoFactor", _a0, _a1)}
 }
 
-func (_c *MockIUseCase_Register_Call) Run(run func(ctx context.Context, req *schema.AuthRegisterRequest)) *MockIUseCase_Register_Call {
+func (_c *MockIUseCase_ConfirmTwoFactor_Call) Run(run func(_a0 context.Context, _a1 *schema.TwoFactorConfirmRequest)) *MockIUseCase_ConfirmTwoFactor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*schema.AuthRegisterRequest))
+		run(args[0].(context.Context), args[1].(*schema.TwoFactorConfirmRequest))
 	})
 	return _c
 }
 
-func (_c *MockIUseCase_Register_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Register_Call {
+func (_c *MockIUseCase_ConfirmTwoFactor_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ConfirmTwoFactor_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockIUseCase_Register_Call) RunAndReturn(run func(context.Context, *schema.AuthRegisterRequest) wrapper.JSONResult) *MockIUseCase_Register_Call {
+func (_c *MockIUseCase_ConfirmTwoFactor_Call) RunAndReturn(run func(context.Context, *schema.TwoFactorConfirmRequest) wrapper.JSONResult) *MockIUseCase_ConfirmTwoFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableTwoFactor provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) DisableTwoFactor(_a0 context.Context, _a1 *schema.TwoFactorDisableRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTwoFactor")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TwoFactorDisableRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_DisableTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTwoFactor'
+type MockIUseCase_DisableTwoFactor_Call struct {
+	*mock.Call
+}
+
+// DisableTwoFactor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.TwoFactorDisableRequest
+func (_e *MockIUseCase_Expecter) DisableTwoFactor(_a0 interface{}, _a1 interface{}) *MockIUseCase_DisableTwoFactor_Call {
+	return &MockIUseCase_DisableTwoFactor_Call{Call: _e.mock.On("DisableTwoFactor", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_DisableTwoFactor_Call) Run(run func(_a0 context.Context, _a1 *schema.TwoFactorDisableRequest)) *MockIUseCase_DisableTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.TwoFactorDisableRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_DisableTwoFactor_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_DisableTwoFactor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_DisableTwoFactor_Call) RunAndReturn(run func(context.Context, *schema.TwoFactorDisableRequest) wrapper.JSONResult) *MockIUseCase_DisableTwoFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTwoFactor provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) EnrollTwoFactor(_a0 context.Context, _a1 *schema.TwoFactorEnrollRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTwoFactor")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TwoFactorEnrollRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_EnrollTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTwoFactor'
+type MockIUseCase_EnrollTwoFactor_Call struct {
+	*mock.Call
+}
+
+// EnrollTwoFactor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.TwoFactorEnrollRequest
+func (_e *MockIUseCase_Expecter) EnrollTwoFactor(_a0 interface{}, _a1 interface{}) *MockIUseCase_EnrollTwoFactor_Call {
+	return &MockIUseCase_EnrollTwoFactor_Call{Call: _e.mock.On("EnrollTwoFactor", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_EnrollTwoFactor_Call) Run(run func(_a0 context.Context, _a1 *schema.TwoFactorEnrollRequest)) *MockIUseCase_EnrollTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.TwoFactorEnrollRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_EnrollTwoFactor_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_EnrollTwoFactor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_EnrollTwoFactor_Call) RunAndReturn(run func(context.Context, *schema.TwoFactorEnrollRequest) wrapper.JSONResult) *MockIUseCase_EnrollTwoFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgotPassword provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ForgotPassword(_a0 context.Context, _a1 *schema.PasswordForgotRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.PasswordForgotRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type MockIUseCase_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.PasswordForgotRequest
+func (_e *MockIUseCase_Expecter) ForgotPassword(_a0 interface{}, _a1 interface{}) *MockIUseCase_ForgotPassword_Call {
+	return &MockIUseCase_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_ForgotPassword_Call) Run(run func(_a0 context.Context, _a1 *schema.PasswordForgotRequest)) *MockIUseCase_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.PasswordForgotRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_ForgotPassword_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_ForgotPassword_Call) RunAndReturn(run func(context.Context, *schema.PasswordForgotRequest) wrapper.JSONResult) *MockIUseCase_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) GetUsers(_a0 context.Context, _a1 *schema.RequestUserBatch) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.RequestUserBatch) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockIUseCase_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.RequestUserBatch
+func (_e *MockIUseCase_Expecter) GetUsers(_a0 interface{}, _a1 interface{}) *MockIUseCase_GetUsers_Call {
+	return &MockIUseCase_GetUsers_Call{Call: _e.mock.On("GetUsers", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_GetUsers_Call) Run(run func(_a0 context.Context, _a1 *schema.RequestUserBatch)) *MockIUseCase_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.RequestUserBatch))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_GetUsers_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_GetUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_GetUsers_Call) RunAndReturn(run func(context.Context, *schema.RequestUserBatch) wrapper.JSONResult) *MockIUseCase_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ListRoles(_a0 context.Context, _a1 *schema.RoleListRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.RoleListRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockIUseCase_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.RoleListRequest
+func (_e *MockIUseCase_Expecter) ListRoles(_a0 interface{}, _a1 interface{}) *MockIUseCase_ListRoles_Call {
+	return &MockIUseCase_ListRoles_Call{Call: _e.mock.On("ListRoles", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_ListRoles_Call) Run(run func(_a0 context.Context, _a1 *schema.RoleListRequest)) *MockIUseCase_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.RoleListRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_ListRoles_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ListRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_ListRoles_Call) RunAndReturn(run func(context.Context, *schema.RoleListRequest) wrapper.JSONResult) *MockIUseCase_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ListSessions(_a0 context.Context, _a1 *schema.SessionListRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.SessionListRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockIUseCase_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.SessionListRequest
+func (_e *MockIUseCase_Expecter) ListSessions(_a0 interface{}, _a1 interface{}) *MockIUseCase_ListSessions_Call {
+	return &MockIUseCase_ListSessions_Call{Call: _e.mock.On("ListSessions", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_ListSessions_Call) Run(run func(_a0 context.Context, _a1 *schema.SessionListRequest)) *MockIUseCase_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.SessionListRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_ListSessions_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ListSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_ListSessions_Call) RunAndReturn(run func(context.Context, *schema.SessionListRequest) wrapper.JSONResult) *MockIUseCase_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: ctx, req
+func (_m *MockIUseCase) Logout(ctx context.Context, req *schema.AuthLogoutRequest) wrapper.JSONResult {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.AuthLogoutRequest) wrapper.JSONResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockIUseCase_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *schema.AuthLogoutRequest
+func (_e *MockIUseCase_Expecter) Logout(ctx interface{}, req interface{}) *MockIUseCase_Logout_Call {
+	return &MockIUseCase_Logout_Call{Call: _e.mock.On("Logout", ctx, req)}
+}
+
+func (_c *MockIUseCase_Logout_Call) Run(run func(ctx context.Context, req *schema.AuthLogoutRequest)) *MockIUseCase_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.AuthLogoutRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_Logout_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_Logout_Call) RunAndReturn(run func(context.Context, *schema.AuthLogoutRequest) wrapper.JSONResult) *MockIUseCase_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OIDCCallback provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) OIDCCallback(_a0 context.Context, _a1 *schema.OIDCCallbackRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OIDCCallback")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.OIDCCallbackRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_OIDCCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OIDCCallback'
+type MockIUseCase_OIDCCallback_Call struct {
+	*mock.Call
+}
+
+// OIDCCallback is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.OIDCCallbackRequest
+func (_e *MockIUseCase_Expecter) OIDCCallback(_a0 interface{}, _a1 interface{}) *MockIUseCase_OIDCCallback_Call {
+	return &MockIUseCase_OIDCCallback_Call{Call: _e.mock.On("OIDCCallback", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_OIDCCallback_Call) Run(run func(_a0 context.Context, _a1 *schema.OIDCCallbackRequest)) *MockIUseCase_OIDCCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.OIDCCallbackRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_OIDCCallback_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_OIDCCallback_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_OIDCCallback_Call) RunAndReturn(run func(context.Context, *schema.OIDCCallbackRequest) wrapper.JSONResult) *MockIUseCase_OIDCCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OIDCLogin provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) OIDCLogin(_a0 context.Context, _a1 *schema.OIDCLoginRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OIDCLogin")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.OIDCLoginRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_OIDCLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OIDCLogin'
+type MockIUseCase_OIDCLogin_Call struct {
+	*mock.Call
+}
+
+// OIDCLogin is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.OIDCLoginRequest
+func (_e *MockIUseCase_Expecter) OIDCLogin(_a0 interface{}, _a1 interface{}) *MockIUseCase_OIDCLogin_Call {
+	return &MockIUseCase_OIDCLogin_Call{Call: _e.mock.On("OIDCLogin", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_OIDCLogin_Call) Run(run func(_a0 context.Context, _a1 *schema.OIDCLoginRequest)) *MockIUseCase_OIDCLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.OIDCLoginRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_OIDCLogin_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_OIDCLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_OIDCLogin_Call) RunAndReturn(run func(context.Context, *schema.OIDCLoginRequest) wrapper.JSONResult) *MockIUseCase_OIDCLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Profile provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) Profile(_a0 context.Context, _a1 *schema.ProfileRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Profile")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.ProfileRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_Profile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Profile'
+type MockIUseCase_Profile_Call struct {
+	*mock.Call
+}
+
+// Profile is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.ProfileRequest
+func (_e *MockIUseCase_Expecter) Profile(_a0 interface{}, _a1 interface{}) *MockIUseCase_Profile_Call {
+	return &MockIUseCase_Profile_Call{Call: _e.mock.On("Profile", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_Profile_Call) Run(run func(_a0 context.Context, _a1 *schema.ProfileRequest)) *MockIUseCase_Profile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.ProfileRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_Profile_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Profile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_Profile_Call) RunAndReturn(run func(context.Context, *schema.ProfileRequest) wrapper.JSONResult) *MockIUseCase_Profile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, req
+func (_m *MockIUseCase) Refresh(ctx context.Context, req *schema.AuthRefreshRequest) wrapper.JSONResult {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.AuthRefreshRequest) wrapper.JSONResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockIUseCase_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *schema.AuthRefreshRequest
+func (_e *MockIUseCase_Expecter) Refresh(ctx interface{}, req interface{}) *MockIUseCase_Refresh_Call {
+	return &MockIUseCase_Refresh_Call{Call: _e.mock.On("Refresh", ctx, req)}
+}
+
+func (_c *MockIUseCase_Refresh_Call) Run(run func(ctx context.Context, req *schema.AuthRefreshRequest)) *MockIUseCase_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.AuthRefreshRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_Refresh_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_Refresh_Call) RunAndReturn(run func(context.Context, *schema.AuthRefreshRequest) wrapper.JSONResult) *MockIUseCase_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) RegenerateRecoveryCodes(_a0 context.Context, _a1 *schema.TwoFactorRecoveryCodesRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TwoFactorRecoveryCodesRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_RegenerateRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateRecoveryCodes'
+type MockIUseCase_RegenerateRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// RegenerateRecoveryCodes is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.TwoFactorRecoveryCodesRequest
+func (_e *MockIUseCase_Expecter) RegenerateRecoveryCodes(_a0 interface{}, _a1 interface{}) *MockIUseCase_RegenerateRecoveryCodes_Call {
+	return &MockIUseCase_RegenerateRecoveryCodes_Call{Call: _e.mock.On("RegenerateRecoveryCodes", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_RegenerateRecoveryCodes_Call) Run(run func(_a0 context.Context, _a1 *schema.TwoFactorRecoveryCodesRequest)) *MockIUseCase_RegenerateRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.TwoFactorRecoveryCodesRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_RegenerateRecoveryCodes_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_RegenerateRecoveryCodes_Call) RunAndReturn(run func(context.Context, *schema.TwoFactorRecoveryCodesRequest) wrapper.JSONResult) *MockIUseCase_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, req
+func (_m *MockIUseCase) Register(ctx context.Context, req *schema.AuthRegisterRequest) wrapper.JSONResult {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.AuthRegisterRequest) wrapper.JSONResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockIUseCase_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *schema.AuthRegisterRequest
+func (_e *MockIUseCase_Expecter) Register(ctx interface{}, req interface{}) *MockIUseCase_Register_Call {
+	return &MockIUseCase_Register_Call{Call: _e.mock.On("Register", ctx, req)}
+}
+
+func (_c *MockIUseCase_Register_Call) Run(run func(ctx context.Context, req *schema.AuthRegisterRequest)) *MockIUseCase_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.AuthRegisterRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_Register_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_Register_Call) RunAndReturn(run func(context.Context, *schema.AuthRegisterRequest) wrapper.JSONResult) *MockIUseCase_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ResendVerification(_a0 context.Context, _a1 *schema.EmailResendRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.EmailResendRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type MockIUseCase_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.EmailResendRequest
+func (_e *MockIUseCase_Expecter) ResendVerification(_a0 interface{}, _a1 interface{}) *MockIUseCase_ResendVerification_Call {
+	return &MockIUseCase_ResendVerification_Call{Call: _e.mock.On("ResendVerification", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_ResendVerification_Call) Run(run func(_a0 context.Context, _a1 *schema.EmailResendRequest)) *MockIUseCase_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.EmailResendRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_ResendVerification_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_ResendVerification_Call) RunAndReturn(run func(context.Context, *schema.EmailResendRequest) wrapper.JSONResult) *MockIUseCase_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) ResetPassword(_a0 context.Context, _a1 *schema.PasswordResetRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.PasswordResetRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockIUseCase_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.PasswordResetRequest
+func (_e *MockIUseCase_Expecter) ResetPassword(_a0 interface{}, _a1 interface{}) *MockIUseCase_ResetPassword_Call {
+	return &MockIUseCase_ResetPassword_Call{Call: _e.mock.On("ResetPassword", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_ResetPassword_Call) Run(run func(_a0 context.Context, _a1 *schema.PasswordResetRequest)) *MockIUseCase_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.PasswordResetRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_ResetPassword_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_ResetPassword_Call) RunAndReturn(run func(context.Context, *schema.PasswordResetRequest) wrapper.JSONResult) *MockIUseCase_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOtherSessions provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) RevokeOtherSessions(_a0 context.Context, _a1 *schema.SessionRevokeOthersRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.SessionRevokeOthersRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_RevokeOtherSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOtherSessions'
+type MockIUseCase_RevokeOtherSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeOtherSessions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.SessionRevokeOthersRequest
+func (_e *MockIUseCase_Expecter) RevokeOtherSessions(_a0 interface{}, _a1 interface{}) *MockIUseCase_RevokeOtherSessions_Call {
+	return &MockIUseCase_RevokeOtherSessions_Call{Call: _e.mock.On("RevokeOtherSessions", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_RevokeOtherSessions_Call) Run(run func(_a0 context.Context, _a1 *schema.SessionRevokeOthersRequest)) *MockIUseCase_RevokeOtherSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.SessionRevokeOthersRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeOtherSessions_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_RevokeOtherSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeOtherSessions_Call) RunAndReturn(run func(context.Context, *schema.SessionRevokeOthersRequest) wrapper.JSONResult) *MockIUseCase_RevokeOtherSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) RevokeSession(_a0 context.Context, _a1 *schema.SessionRevokeRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.SessionRevokeRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockIUseCase_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.SessionRevokeRequest
+func (_e *MockIUseCase_Expecter) RevokeSession(_a0 interface{}, _a1 interface{}) *MockIUseCase_RevokeSession_Call {
+	return &MockIUseCase_RevokeSession_Call{Call: _e.mock.On("RevokeSession", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_RevokeSession_Call) Run(run func(_a0 context.Context, _a1 *schema.SessionRevokeRequest)) *MockIUseCase_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.SessionRevokeRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeSession_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeSession_Call) RunAndReturn(run func(context.Context, *schema.SessionRevokeRequest) wrapper.JSONResult) *MockIUseCase_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserTokens provides a mock function with given fields: ctx, req
+func (_m *MockIUseCase) RevokeUserTokens(ctx context.Context, req *schema.RequestRevokeUserTokens) wrapper.JSONResult {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserTokens")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.RequestRevokeUserTokens) wrapper.JSONResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_RevokeUserTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserTokens'
+type MockIUseCase_RevokeUserTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *schema.RequestRevokeUserTokens
+func (_e *MockIUseCase_Expecter) RevokeUserTokens(ctx interface{}, req interface{}) *MockIUseCase_RevokeUserTokens_Call {
+	return &MockIUseCase_RevokeUserTokens_Call{Call: _e.mock.On("RevokeUserTokens", ctx, req)}
+}
+
+func (_c *MockIUseCase_RevokeUserTokens_Call) Run(run func(ctx context.Context, req *schema.RequestRevokeUserTokens)) *MockIUseCase_RevokeUserTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.RequestRevokeUserTokens))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeUserTokens_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_RevokeUserTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_RevokeUserTokens_Call) RunAndReturn(run func(context.Context, *schema.RequestRevokeUserTokens) wrapper.JSONResult) *MockIUseCase_RevokeUserTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunPasswordResets provides a mock function with given fields: ctx
+func (_m *MockIUseCase) RunPasswordResets(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunPasswordResets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIUseCase_RunPasswordResets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunPasswordResets'
+type MockIUseCase_RunPasswordResets_Call struct {
+	*mock.Call
+}
+
+// RunPasswordResets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIUseCase_Expecter) RunPasswordResets(ctx interface{}) *MockIUseCase_RunPasswordResets_Call {
+	return &MockIUseCase_RunPasswordResets_Call{Call: _e.mock.On("RunPasswordResets", ctx)}
+}
+
+func (_c *MockIUseCase_RunPasswordResets_Call) Run(run func(ctx context.Context)) *MockIUseCase_RunPasswordResets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_RunPasswordResets_Call) Return(_a0 error) *MockIUseCase_RunPasswordResets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_RunPasswordResets_Call) RunAndReturn(run func(context.Context) error) *MockIUseCase_RunPasswordResets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserRoles provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) SetUserRoles(_a0 context.Context, _a1 *schema.UserRolesRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRoles")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.UserRolesRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_SetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRoles'
+type MockIUseCase_SetUserRoles_Call struct {
+	*mock.Call
+}
+
+// SetUserRoles is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.UserRolesRequest
+func (_e *MockIUseCase_Expecter) SetUserRoles(_a0 interface{}, _a1 interface{}) *MockIUseCase_SetUserRoles_Call {
+	return &MockIUseCase_SetUserRoles_Call{Call: _e.mock.On("SetUserRoles", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_SetUserRoles_Call) Run(run func(_a0 context.Context, _a1 *schema.UserRolesRequest)) *MockIUseCase_SetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.UserRolesRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_SetUserRoles_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_SetUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_SetUserRoles_Call) RunAndReturn(run func(context.Context, *schema.UserRolesRequest) wrapper.JSONResult) *MockIUseCase_SetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) UnlockUser(_a0 context.Context, _a1 *schema.RequestUnlockUser) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.RequestUnlockUser) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type MockIUseCase_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.RequestUnlockUser
+func (_e *MockIUseCase_Expecter) UnlockUser(_a0 interface{}, _a1 interface{}) *MockIUseCase_UnlockUser_Call {
+	return &MockIUseCase_UnlockUser_Call{Call: _e.mock.On("UnlockUser", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_UnlockUser_Call) Run(run func(_a0 context.Context, _a1 *schema.RequestUnlockUser)) *MockIUseCase_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.RequestUnlockUser))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_UnlockUser_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_UnlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_UnlockUser_Call) RunAndReturn(run func(context.Context, *schema.RequestUnlockUser) wrapper.JSONResult) *MockIUseCase_UnlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) UpdateProfile(_a0 context.Context, _a1 *schema.ProfileUpdateRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.ProfileUpdateRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockIUseCase_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.ProfileUpdateRequest
+func (_e *MockIUseCase_Expecter) UpdateProfile(_a0 interface{}, _a1 interface{}) *MockIUseCase_UpdateProfile_Call {
+	return &MockIUseCase_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_UpdateProfile_Call) Run(run func(_a0 context.Context, _a1 *schema.ProfileUpdateRequest)) *MockIUseCase_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.ProfileUpdateRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_UpdateProfile_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_UpdateProfile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_UpdateProfile_Call) RunAndReturn(run func(context.Context, *schema.ProfileUpdateRequest) wrapper.JSONResult) *MockIUseCase_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) VerifyEmail(_a0 context.Context, _a1 *schema.EmailVerifyRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.EmailVerifyRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockIUseCase_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.EmailVerifyRequest
+func (_e *MockIUseCase_Expecter) VerifyEmail(_a0 interface{}, _a1 interface{}) *MockIUseCase_VerifyEmail_Call {
+	return &MockIUseCase_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_VerifyEmail_Call) Run(run func(_a0 context.Context, _a1 *schema.EmailVerifyRequest)) *MockIUseCase_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.EmailVerifyRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_VerifyEmail_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_VerifyEmail_Call) RunAndReturn(run func(context.Context, *schema.EmailVerifyRequest) wrapper.JSONResult) *MockIUseCase_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyTwoFactor provides a mock function with given fields: _a0, _a1
+func (_m *MockIUseCase) VerifyTwoFactor(_a0 context.Context, _a1 *schema.TwoFactorVerifyRequest) wrapper.JSONResult {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTwoFactor")
+	}
+
+	var r0 wrapper.JSONResult
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TwoFactorVerifyRequest) wrapper.JSONResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(wrapper.JSONResult)
+	}
+
+	return r0
+}
+
+// MockIUseCase_VerifyTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyTwoFactor'
+type MockIUseCase_VerifyTwoFactor_Call struct {
+	*mock.Call
+}
+
+// VerifyTwoFactor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *schema.TwoFactorVerifyRequest
+func (_e *MockIUseCase_Expecter) VerifyTwoFactor(_a0 interface{}, _a1 interface{}) *MockIUseCase_VerifyTwoFactor_Call {
+	return &MockIUseCase_VerifyTwoFactor_Call{Call: _e.mock.On("VerifyTwoFactor", _a0, _a1)}
+}
+
+func (_c *MockIUseCase_VerifyTwoFactor_Call) Run(run func(_a0 context.Context, _a1 *schema.TwoFactorVerifyRequest)) *MockIUseCase_VerifyTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*schema.TwoFactorVerifyRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUseCase_VerifyTwoFactor_Call) Return(_a0 wrapper.JSONResult) *MockIUseCase_VerifyTwoFactor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUseCase_VerifyTwoFactor_Call) RunAndReturn(run func(context.Context, *schema.TwoFactorVerifyRequest) wrapper.JSONResult) *MockIUseCase_VerifyTwoFactor_Call {
 	_c.Call.Return(run)
 	return _c
 }
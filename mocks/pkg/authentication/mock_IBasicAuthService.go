@@ -17,6 +17,63 @@ func (_m *MockIBasicAuthService) EXPECT() *MockIBasicAuthService_Expecter {
 	return &MockIBasicAuthService_Expecter{mock: &_m.Mock}
 }
 
+// Authenticate provides a mock function with given fields: username, password
+func (_m *MockIBasicAuthService) Authenticate(username string, password string) (string, bool) {
+	ret := _m.Called(username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string, string) (string, bool)); ok {
+		return rf(username, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(username, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
+		r1 = rf(username, password)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockIBasicAuthService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockIBasicAuthService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - username string
+//   - password string
+func (_e *MockIBasicAuthService_Expecter) Authenticate(username interface{}, password interface{}) *MockIBasicAuthService_Authenticate_Call {
+	return &MockIBasicAuthService_Authenticate_Call{Call: _e.mock.On("Authenticate", username, password)}
+}
+
+func (_c *MockIBasicAuthService_Authenticate_Call) Run(run func(username string, password string)) *MockIBasicAuthService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIBasicAuthService_Authenticate_Call) Return(_a0 string, _a1 bool) *MockIBasicAuthService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIBasicAuthService_Authenticate_Call) RunAndReturn(run func(string, string) (string, bool)) *MockIBasicAuthService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DecodeFromHeader provides a mock function with given fields: auth
 func (_m *MockIBasicAuthService) DecodeFromHeader(auth string) (string, string) {
 	ret := _m.Called(auth)
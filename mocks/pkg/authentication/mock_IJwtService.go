@@ -3,7 +3,10 @@
 package authentication
 
 import (
+	time "time"
+
 	authentication "github.com/Alwanly/go-codebase/pkg/authentication"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -20,12 +23,69 @@ func (_m *MockIJwtService) EXPECT() *MockIJwtService_Expecter {
 	return &MockIJwtService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateToken provides a mock function with given fields: claims
-func (_m *MockIJwtService) GenerateToken(claims authentication.JWTClaims) (string, error) {
+// GenerateChallengeToken provides a mock function with given fields: claims, lifetime
+func (_m *MockIJwtService) GenerateChallengeToken(claims authentication.JWTClaims, lifetime time.Duration) (string, error) {
+	ret := _m.Called(claims, lifetime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateChallengeToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(authentication.JWTClaims, time.Duration) (string, error)); ok {
+		return rf(claims, lifetime)
+	}
+	if rf, ok := ret.Get(0).(func(authentication.JWTClaims, time.Duration) string); ok {
+		r0 = rf(claims, lifetime)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(authentication.JWTClaims, time.Duration) error); ok {
+		r1 = rf(claims, lifetime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIJwtService_GenerateChallengeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateChallengeToken'
+type MockIJwtService_GenerateChallengeToken_Call struct {
+	*mock.Call
+}
+
+// GenerateChallengeToken is a helper method to define mock.On call
+//   - claims authentication.JWTClaims
+//   - lifetime time.Duration
+func (_e *MockIJwtService_Expecter) GenerateChallengeToken(claims interface{}, lifetime interface{}) *MockIJwtService_GenerateChallengeToken_Call {
+	return &MockIJwtService_GenerateChallengeToken_Call{Call: _e.mock.On("GenerateChallengeToken", claims, lifetime)}
+}
+
+func (_c *MockIJwtService_GenerateChallengeToken_Call) Run(run func(claims authentication.JWTClaims, lifetime time.Duration)) *MockIJwtService_GenerateChallengeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(authentication.JWTClaims), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIJwtService_GenerateChallengeToken_Call) Return(_a0 string, _a1 error) *MockIJwtService_GenerateChallengeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIJwtService_GenerateChallengeToken_Call) RunAndReturn(run func(authentication.JWTClaims, time.Duration) (string, error)) *MockIJwtService_GenerateChallengeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateRefreshToken provides a mock function with given fields: claims
+func (_m *MockIJwtService) GenerateRefreshToken(claims authentication.JWTClaims) (string, error) {
 	ret := _m.Called(claims)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateToken")
+		panic("no return value specified for GenerateRefreshToken")
 	}
 
 	var r0 string
@@ -48,57 +108,55 @@ func (_m *MockIJwtService) GenerateToken(claims authentication.JWTClaims) (strin
 	return r0, r1
 }
 
-// MockIJwtService_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
-type MockIJwtService_GenerateToken_Call struct {
+// MockIJwtService_GenerateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateRefreshToken'
+type MockIJwtService_GenerateRefreshToken_Call struct {
 	*mock.Call
 }
 
-// GenerateToken is a helper method to define mock.On call
+// GenerateRefreshToken is a helper method to define mock.On call
 //   - claims authentication.JWTClaims
-func (_e *MockIJwtService_Expecter) GenerateToken(claims interface{}) *MockIJwtService_GenerateToken_Call {
-	return &MockIJwtService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", claims)}
+func (_e *MockIJwtService_Expecter) GenerateRefreshToken(claims interface{}) *MockIJwtService_GenerateRefreshToken_Call {
+	return &MockIJwtService_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", claims)}
 }
 
-func (_c *MockIJwtService_GenerateToken_Call) Run(run func(claims authentication.JWTClaims)) *MockIJwtService_GenerateToken_Call {
+func (_c *MockIJwtService_GenerateRefreshToken_Call) Run(run func(claims authentication.JWTClaims)) *MockIJwtService_GenerateRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(authentication.JWTClaims))
 	})
 	return _c
 }
 
-func (_c *MockIJwtService_GenerateToken_Call) Return(_a0 string, _a1 error) *MockIJwtService_GenerateToken_Call {
+func (_c *MockIJwtService_GenerateRefreshToken_Call) Return(_a0 string, _a1 error) *MockIJwtService_GenerateRefreshToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockIJwtService_GenerateToken_Call) RunAndReturn(run func(authentication.JWTClaims) (string, error)) *MockIJwtService_GenerateToken_Call {
+func (_c *MockIJwtService_GenerateRefreshToken_Call) RunAndReturn(run func(authentication.JWTClaims) (string, error)) *MockIJwtService_GenerateRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ParseToken provides a mock function with given fields: token
-func (_m *MockIJwtService) ParseToken(token string) (*authentication.JWTClaims, error) {
-	ret := _m.Called(token)
+// GenerateToken provides a mock function with given fields: claims
+func (_m *MockIJwtService) GenerateToken(claims authentication.JWTClaims) (string, error) {
+	ret := _m.Called(claims)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ParseToken")
+		panic("no return value specified for GenerateToken")
 	}
 
-	var r0 *authentication.JWTClaims
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*authentication.JWTClaims, error)); ok {
-		return rf(token)
+	if rf, ok := ret.Get(0).(func(authentication.JWTClaims) (string, error)); ok {
+		return rf(claims)
 	}
-	if rf, ok := ret.Get(0).(func(string) *authentication.JWTClaims); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(authentication.JWTClaims) string); ok {
+		r0 = rf(claims)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*authentication.JWTClaims)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(authentication.JWTClaims) error); ok {
+		r1 = rf(claims)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -106,51 +164,53 @@ func (_m *MockIJwtService) ParseToken(token string) (*authentication.JWTClaims,
 	return r0, r1
 }
 
-// MockIJwtService_ParseToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseToken'
-type MockIJwtService_ParseToken_Call struct {
+// MockIJwtService_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
+type MockIJwtService_GenerateToken_Call struct {
 	*mock.Call
 }
 
-// ParseToken is a helper method to define mock.On call
-//   - token string
-func (_e *MockIJwtService_Expecter) ParseToken(token interface{}) *MockIJwtService_ParseToken_Call {
-	return &MockIJwtService_ParseToken_Call{Call: _e.mock.On("ParseToken", token)}
+// GenerateToken is a helper method to define mock.On call
+//   - claims authentication.JWTClaims
+func (_e *MockIJwtService_Expecter) GenerateToken(claims interface{}) *MockIJwtService_GenerateToken_Call {
+	return &MockIJwtService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", claims)}
 }
 
-func (_c *MockIJwtService_ParseToken_Call) Run(run func(token string)) *MockIJwtService_ParseToken_Call {
+func (_c *MockIJwtService_GenerateToken_Call) Run(run func(claims authentication.JWTClaims)) *MockIJwtService_GenerateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(authentication.JWTClaims))
 	})
 	return _c
 }
 
-func (_c *MockIJwtService_ParseToken_Call) Return(_a0 *authentication.JWTClaims, _a1 error) *MockIJwtService_ParseToken_Call {
+func (_c *MockIJwtService_GenerateToken_Call) Return(_a0 string, _a1 error) *MockIJwtService_GenerateToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockIJwtService_ParseToken_Call) RunAndReturn(run func(string) (*authentication.JWTClaims, error)) *MockIJwtService_ParseToken_Call {
+func (_c *MockIJwtService_GenerateToken_Call) RunAndReturn(run func(authentication.JWTClaims) (string, error)) *MockIJwtService_GenerateToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RefreshToken provides a mock function with given fields: token
-func (_m *MockIJwtService) RefreshToken(token string) (string, error) {
+// ParseToken provides a mock function with given fields: token
+func (_m *MockIJwtService) ParseToken(token string) (*authentication.JWTClaims, error) {
 	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RefreshToken")
+		panic("no return value specified for ParseToken")
 	}
 
-	var r0 string
+	var r0 *authentication.JWTClaims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*authentication.JWTClaims, error)); ok {
 		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) *authentication.JWTClaims); ok {
 		r0 = rf(token)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authentication.JWTClaims)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -162,30 +222,30 @@ func (_m *MockIJwtService) RefreshToken(token string) (string, error) {
 	return r0, r1
 }
 
-// MockIJwtService_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
-type MockIJwtService_RefreshToken_Call struct {
+// MockIJwtService_ParseToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseToken'
+type MockIJwtService_ParseToken_Call struct {
 	*mock.Call
 }
 
-// RefreshToken is a helper method to define mock.On call
+// ParseToken is a helper method to define mock.On call
 //   - token string
-func (_e *MockIJwtService_Expecter) RefreshToken(token interface{}) *MockIJwtService_RefreshToken_Call {
-	return &MockIJwtService_RefreshToken_Call{Call: _e.mock.On("RefreshToken", token)}
+func (_e *MockIJwtService_Expecter) ParseToken(token interface{}) *MockIJwtService_ParseToken_Call {
+	return &MockIJwtService_ParseToken_Call{Call: _e.mock.On("ParseToken", token)}
 }
 
-func (_c *MockIJwtService_RefreshToken_Call) Run(run func(token string)) *MockIJwtService_RefreshToken_Call {
+func (_c *MockIJwtService_ParseToken_Call) Run(run func(token string)) *MockIJwtService_ParseToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockIJwtService_RefreshToken_Call) Return(_a0 string, _a1 error) *MockIJwtService_RefreshToken_Call {
+func (_c *MockIJwtService_ParseToken_Call) Return(_a0 *authentication.JWTClaims, _a1 error) *MockIJwtService_ParseToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockIJwtService_RefreshToken_Call) RunAndReturn(run func(string) (string, error)) *MockIJwtService_RefreshToken_Call {
+func (_c *MockIJwtService_ParseToken_Call) RunAndReturn(run func(string) (*authentication.JWTClaims, error)) *MockIJwtService_ParseToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -398,6 +398,53 @@ func (_c *MockIDBService_RollbackTransaction_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// RunInTransaction provides a mock function with given fields: c, fn
+func (_m *MockIDBService) RunInTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	ret := _m.Called(c, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunInTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = rf(c, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIDBService_RunInTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTransaction'
+type MockIDBService_RunInTransaction_Call struct {
+	*mock.Call
+}
+
+// RunInTransaction is a helper method to define mock.On call
+//   - c context.Context
+//   - fn func(ctx context.Context) error
+func (_e *MockIDBService_Expecter) RunInTransaction(c interface{}, fn interface{}) *MockIDBService_RunInTransaction_Call {
+	return &MockIDBService_RunInTransaction_Call{Call: _e.mock.On("RunInTransaction", c, fn)}
+}
+
+func (_c *MockIDBService_RunInTransaction_Call) Run(run func(c context.Context, fn func(ctx context.Context) error)) *MockIDBService_RunInTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(ctx context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockIDBService_RunInTransaction_Call) Return(_a0 error) *MockIDBService_RunInTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIDBService_RunInTransaction_Call) RunAndReturn(run func(context.Context, func(ctx context.Context) error) error) *MockIDBService_RunInTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetShareLockType provides a mock function with given fields: c
 func (_m *MockIDBService) SetShareLockType(c context.Context) context.Context {
 	ret := _m.Called(c)
@@ -21,6 +21,67 @@ func (_m *MockIAuthMiddleware) EXPECT() *MockIAuthMiddleware_Expecter {
 	return &MockIAuthMiddleware_Expecter{mock: &_m.Mock}
 }
 
+// APIKeyAuth provides a mock function with given fields: scopes
+func (_m *MockIAuthMiddleware) APIKeyAuth(scopes ...string) func(*fiber.Ctx) error {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for APIKeyAuth")
+	}
+
+	var r0 func(*fiber.Ctx) error
+	if rf, ok := ret.Get(0).(func(...string) func(*fiber.Ctx) error); ok {
+		r0 = rf(scopes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(*fiber.Ctx) error)
+		}
+	}
+
+	return r0
+}
+
+// MockIAuthMiddleware_APIKeyAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKeyAuth'
+type MockIAuthMiddleware_APIKeyAuth_Call struct {
+	*mock.Call
+}
+
+// APIKeyAuth is a helper method to define mock.On call
+//   - scopes ...string
+func (_e *MockIAuthMiddleware_Expecter) APIKeyAuth(scopes ...interface{}) *MockIAuthMiddleware_APIKeyAuth_Call {
+	return &MockIAuthMiddleware_APIKeyAuth_Call{Call: _e.mock.On("APIKeyAuth",
+		append([]interface{}{}, scopes...)...)}
+}
+
+func (_c *MockIAuthMiddleware_APIKeyAuth_Call) Run(run func(scopes ...string)) *MockIAuthMiddleware_APIKeyAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockIAuthMiddleware_APIKeyAuth_Call) Return(_a0 func(*fiber.Ctx) error) *MockIAuthMiddleware_APIKeyAuth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIAuthMiddleware_APIKeyAuth_Call) RunAndReturn(run func(...string) func(*fiber.Ctx) error) *MockIAuthMiddleware_APIKeyAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BasicAuth provides a mock function with given fields:
 func (_m *MockIAuthMiddleware) BasicAuth() func(*fiber.Ctx) error {
 	ret := _m.Called()
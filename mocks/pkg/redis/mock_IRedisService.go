@@ -3,6 +3,10 @@
 package redis
 
 import (
+	context "context"
+
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 
 	v9 "github.com/go-redis/redis/v9"
@@ -66,6 +70,274 @@ func (_c *MockIRedisService_CloseRedis_Call) RunAndReturn(run func() error) *Moc
 	return _c
 }
 
+// CompareAndSet provides a mock function with given fields: ctx, key, expected, value, expiration
+func (_m *MockIRedisService) CompareAndSet(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, expected, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSet")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, expected, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, expected, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, expected, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_CompareAndSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSet'
+type MockIRedisService_CompareAndSet_Call struct {
+	*mock.Call
+}
+
+// CompareAndSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - expected string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockIRedisService_Expecter) CompareAndSet(ctx interface{}, key interface{}, expected interface{}, value interface{}, expiration interface{}) *MockIRedisService_CompareAndSet_Call {
+	return &MockIRedisService_CompareAndSet_Call{Call: _e.mock.On("CompareAndSet", ctx, key, expected, value, expiration)}
+}
+
+func (_c *MockIRedisService_CompareAndSet_Call) Run(run func(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration)) *MockIRedisService_CompareAndSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_CompareAndSet_Call) Return(_a0 bool, _a1 error) *MockIRedisService_CompareAndSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_CompareAndSet_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockIRedisService_CompareAndSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Del provides a mock function with given fields: ctx, key
+func (_m *MockIRedisService) Del(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Del")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRedisService_Del_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Del'
+type MockIRedisService_Del_Call struct {
+	*mock.Call
+}
+
+// Del is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIRedisService_Expecter) Del(ctx interface{}, key interface{}) *MockIRedisService_Del_Call {
+	return &MockIRedisService_Del_Call{Call: _e.mock.On("Del", ctx, key)}
+}
+
+func (_c *MockIRedisService_Del_Call) Run(run func(ctx context.Context, key string)) *MockIRedisService_Del_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_Del_Call) Return(_a0 error) *MockIRedisService_Del_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_Del_Call) RunAndReturn(run func(context.Context, string) error) *MockIRedisService_Del_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DelMany provides a mock function with given fields: ctx, keys
+func (_m *MockIRedisService) DelMany(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRedisService_DelMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DelMany'
+type MockIRedisService_DelMany_Call struct {
+	*mock.Call
+}
+
+// DelMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockIRedisService_Expecter) DelMany(ctx interface{}, keys interface{}) *MockIRedisService_DelMany_Call {
+	return &MockIRedisService_DelMany_Call{Call: _e.mock.On("DelMany", ctx, keys)}
+}
+
+func (_c *MockIRedisService_DelMany_Call) Run(run func(ctx context.Context, keys []string)) *MockIRedisService_DelMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_DelMany_Call) Return(_a0 error) *MockIRedisService_DelMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_DelMany_Call) RunAndReturn(run func(context.Context, []string) error) *MockIRedisService_DelMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockIRedisService) Get(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIRedisService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIRedisService_Expecter) Get(ctx interface{}, key interface{}) *MockIRedisService_Get_Call {
+	return &MockIRedisService_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockIRedisService_Get_Call) Run(run func(ctx context.Context, key string)) *MockIRedisService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_Get_Call) Return(_a0 string, _a1 error) *MockIRedisService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_Get_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockIRedisService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDel provides a mock function with given fields: ctx, key
+func (_m *MockIRedisService) GetDel(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDel")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_GetDel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDel'
+type MockIRedisService_GetDel_Call struct {
+	*mock.Call
+}
+
+// GetDel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIRedisService_Expecter) GetDel(ctx interface{}, key interface{}) *MockIRedisService_GetDel_Call {
+	return &MockIRedisService_GetDel_Call{Call: _e.mock.On("GetDel", ctx, key)}
+}
+
+func (_c *MockIRedisService_GetDel_Call) Run(run func(ctx context.Context, key string)) *MockIRedisService_GetDel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_GetDel_Call) Return(_a0 string, _a1 error) *MockIRedisService_GetDel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_GetDel_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockIRedisService_GetDel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransaction provides a mock function with given fields:
 func (_m *MockIRedisService) GetTransaction() (v9.Pipeliner, error) {
 	ret := _m.Called()
@@ -123,6 +395,113 @@ func (_c *MockIRedisService_GetTransaction_Call) RunAndReturn(run func() (v9.Pip
 	return _c
 }
 
+// MGet provides a mock function with given fields: ctx, keys
+func (_m *MockIRedisService) MGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MGet")
+	}
+
+	var r0 []interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]interface{}, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_MGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MGet'
+type MockIRedisService_MGet_Call struct {
+	*mock.Call
+}
+
+// MGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockIRedisService_Expecter) MGet(ctx interface{}, keys interface{}) *MockIRedisService_MGet_Call {
+	return &MockIRedisService_MGet_Call{Call: _e.mock.On("MGet", ctx, keys)}
+}
+
+func (_c *MockIRedisService_MGet_Call) Run(run func(ctx context.Context, keys []string)) *MockIRedisService_MGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_MGet_Call) Return(_a0 []interface{}, _a1 error) *MockIRedisService_MGet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_MGet_Call) RunAndReturn(run func(context.Context, []string) ([]interface{}, error)) *MockIRedisService_MGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MSet provides a mock function with given fields: ctx, values, expiration
+func (_m *MockIRedisService) MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, values, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, values, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRedisService_MSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MSet'
+type MockIRedisService_MSet_Call struct {
+	*mock.Call
+}
+
+// MSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+//   - expiration time.Duration
+func (_e *MockIRedisService_Expecter) MSet(ctx interface{}, values interface{}, expiration interface{}) *MockIRedisService_MSet_Call {
+	return &MockIRedisService_MSet_Call{Call: _e.mock.On("MSet", ctx, values, expiration)}
+}
+
+func (_c *MockIRedisService_MSet_Call) Run(run func(ctx context.Context, values map[string]interface{}, expiration time.Duration)) *MockIRedisService_MSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_MSet_Call) Return(_a0 error) *MockIRedisService_MSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_MSet_Call) RunAndReturn(run func(context.Context, map[string]interface{}, time.Duration) error) *MockIRedisService_MSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PingRedis provides a mock function with given fields:
 func (_m *MockIRedisService) PingRedis() bool {
 	ret := _m.Called()
@@ -223,6 +602,450 @@ func (_c *MockIRedisService_PingRedisWithError_Call) RunAndReturn(run func() (bo
 	return _c
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *MockIRedisService) Publish(ctx context.Context, channel string, message interface{}) error {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRedisService_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockIRedisService_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - message interface{}
+func (_e *MockIRedisService_Expecter) Publish(ctx interface{}, channel interface{}, message interface{}) *MockIRedisService_Publish_Call {
+	return &MockIRedisService_Publish_Call{Call: _e.mock.On("Publish", ctx, channel, message)}
+}
+
+func (_c *MockIRedisService_Publish_Call) Run(run func(ctx context.Context, channel string, message interface{})) *MockIRedisService_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_Publish_Call) Return(_a0 error) *MockIRedisService_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_Publish_Call) RunAndReturn(run func(context.Context, string, interface{}) error) *MockIRedisService_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockIRedisService) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIRedisService_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockIRedisService_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockIRedisService_Expecter) Set(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockIRedisService_Set_Call {
+	return &MockIRedisService_Set_Call{Call: _e.mock.On("Set", ctx, key, value, expiration)}
+}
+
+func (_c *MockIRedisService_Set_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *MockIRedisService_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_Set_Call) Return(_a0 error) *MockIRedisService_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_Set_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockIRedisService_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockIRedisService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_SetNX_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNX'
+type MockIRedisService_SetNX_Call struct {
+	*mock.Call
+}
+
+// SetNX is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockIRedisService_Expecter) SetNX(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockIRedisService_SetNX_Call {
+	return &MockIRedisService_SetNX_Call{Call: _e.mock.On("SetNX", ctx, key, value, expiration)}
+}
+
+func (_c *MockIRedisService_SetNX_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *MockIRedisService_SetNX_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_SetNX_Call) Return(_a0 bool, _a1 error) *MockIRedisService_SetNX_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_SetNX_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockIRedisService_SetNX_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, channel
+func (_m *MockIRedisService) Subscribe(ctx context.Context, channel string) *v9.PubSub {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *v9.PubSub
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v9.PubSub); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v9.PubSub)
+		}
+	}
+
+	return r0
+}
+
+// MockIRedisService_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockIRedisService_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+func (_e *MockIRedisService_Expecter) Subscribe(ctx interface{}, channel interface{}) *MockIRedisService_Subscribe_Call {
+	return &MockIRedisService_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, channel)}
+}
+
+func (_c *MockIRedisService_Subscribe_Call) Run(run func(ctx context.Context, channel string)) *MockIRedisService_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_Subscribe_Call) Return(_a0 *v9.PubSub) *MockIRedisService_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIRedisService_Subscribe_Call) RunAndReturn(run func(context.Context, string) *v9.PubSub) *MockIRedisService_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TTL provides a mock function with given fields: ctx, key
+func (_m *MockIRedisService) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type MockIRedisService_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIRedisService_Expecter) TTL(ctx interface{}, key interface{}) *MockIRedisService_TTL_Call {
+	return &MockIRedisService_TTL_Call{Call: _e.mock.On("TTL", ctx, key)}
+}
+
+func (_c *MockIRedisService_TTL_Call) Run(run func(ctx context.Context, key string)) *MockIRedisService_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_TTL_Call) Return(_a0 time.Duration, _a1 error) *MockIRedisService_TTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_TTL_Call) RunAndReturn(run func(context.Context, string) (time.Duration, error)) *MockIRedisService_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WindowAdd provides a mock function with given fields: ctx, key, window
+func (_m *MockIRedisService) WindowAdd(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WindowAdd")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_WindowAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WindowAdd'
+type MockIRedisService_WindowAdd_Call struct {
+	*mock.Call
+}
+
+// WindowAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - window time.Duration
+func (_e *MockIRedisService_Expecter) WindowAdd(ctx interface{}, key interface{}, window interface{}) *MockIRedisService_WindowAdd_Call {
+	return &MockIRedisService_WindowAdd_Call{Call: _e.mock.On("WindowAdd", ctx, key, window)}
+}
+
+func (_c *MockIRedisService_WindowAdd_Call) Run(run func(ctx context.Context, key string, window time.Duration)) *MockIRedisService_WindowAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_WindowAdd_Call) Return(_a0 int64, _a1 error) *MockIRedisService_WindowAdd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_WindowAdd_Call) RunAndReturn(run func(context.Context, string, time.Duration) (int64, error)) *MockIRedisService_WindowAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WindowCount provides a mock function with given fields: ctx, key, window
+func (_m *MockIRedisService) WindowCount(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	ret := _m.Called(ctx, key, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WindowCount")
+	}
+
+	var r0 int64
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, time.Duration, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) time.Duration); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIRedisService_WindowCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WindowCount'
+type MockIRedisService_WindowCount_Call struct {
+	*mock.Call
+}
+
+// WindowCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - window time.Duration
+func (_e *MockIRedisService_Expecter) WindowCount(ctx interface{}, key interface{}, window interface{}) *MockIRedisService_WindowCount_Call {
+	return &MockIRedisService_WindowCount_Call{Call: _e.mock.On("WindowCount", ctx, key, window)}
+}
+
+func (_c *MockIRedisService_WindowCount_Call) Run(run func(ctx context.Context, key string, window time.Duration)) *MockIRedisService_WindowCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_WindowCount_Call) Return(_a0 int64, _a1 time.Duration, _a2 error) *MockIRedisService_WindowCount_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIRedisService_WindowCount_Call) RunAndReturn(run func(context.Context, string, time.Duration) (int64, time.Duration, error)) *MockIRedisService_WindowCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XAdd provides a mock function with given fields: ctx, stream, maxLen, values
+func (_m *MockIRedisService) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	ret := _m.Called(ctx, stream, maxLen, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for XAdd")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, map[string]interface{}) (string, error)); ok {
+		return rf(ctx, stream, maxLen, values)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, map[string]interface{}) string); ok {
+		r0 = rf(ctx, stream, maxLen, values)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, map[string]interface{}) error); ok {
+		r1 = rf(ctx, stream, maxLen, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRedisService_XAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XAdd'
+type MockIRedisService_XAdd_Call struct {
+	*mock.Call
+}
+
+// XAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream string
+//   - maxLen int64
+//   - values map[string]interface{}
+func (_e *MockIRedisService_Expecter) XAdd(ctx interface{}, stream interface{}, maxLen interface{}, values interface{}) *MockIRedisService_XAdd_Call {
+	return &MockIRedisService_XAdd_Call{Call: _e.mock.On("XAdd", ctx, stream, maxLen, values)}
+}
+
+func (_c *MockIRedisService_XAdd_Call) Run(run func(ctx context.Context, stream string, maxLen int64, values map[string]interface{})) *MockIRedisService_XAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockIRedisService_XAdd_Call) Return(_a0 string, _a1 error) *MockIRedisService_XAdd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRedisService_XAdd_Call) RunAndReturn(run func(context.Context, string, int64, map[string]interface{}) (string, error)) *MockIRedisService_XAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIRedisService creates a new instance of MockIRedisService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIRedisService(t interface {
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// APIKey model authenticates a machine-to-machine client, only the hash of the key is stored
+type APIKey struct {
+	ID         string     `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	Name       string     `gorm:"column:name;type:varchar(255);not null" `
+	Prefix     string     `gorm:"column:prefix;type:varchar(16);not null" `
+	KeyHash    string     `gorm:"column:key_hash;type:varchar(64);not null;uniqueIndex:api_keys_key_hash_key" `
+	Scopes     string     `gorm:"column:scopes;type:text;not null;default:''" `
+	CreatedBy  string     `gorm:"column:created_by;type:varchar(36);not null" `
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	ExpiresAt  *time.Time `gorm:"column:expires_at;type:timestamptz" `
+	LastUsedAt *time.Time `gorm:"column:last_used_at;type:timestamptz" `
+	RevokedAt  *time.Time `gorm:"column:revoked_at;type:timestamptz" `
+}
+
+// TableName for APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// APIKeys model
+type APIKeys []APIKey
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// AuthAuditEvent model records a security relevant authentication event, such as a lockout
+type AuthAuditEvent struct {
+	ID        string    `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	Event     string    `gorm:"column:event;type:varchar(64);not null" `
+	UserID    string    `gorm:"column:user_id;type:varchar(36);not null;default:''" `
+	Username  string    `gorm:"column:username;type:varchar(255);not null;default:''" `
+	IP        string    `gorm:"column:ip;type:varchar(64);not null;default:''" `
+	ClientID  string    `gorm:"column:client_id;type:varchar(255);not null;default:''" `
+	ActorID   string    `gorm:"column:actor_id;type:varchar(36);not null;default:''" `
+	Detail    string    `gorm:"column:detail;type:text;not null;default:''" `
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null" `
+}
+
+// TableName for AuthAuditEvent model
+func (AuthAuditEvent) TableName() string {
+	return "auth_audit_events"
+}
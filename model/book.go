@@ -1,17 +1,26 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"gorm.io/gorm"
+)
 
 // book model
 
 type Book struct {
-	ID        string    `gorm:"primaryKey;column:id;type:varchar(255);not null" `
-	Title     string    `gorm:"column:title;type:varchar(255);not null" `
-	Author    string    `gorm:"column:author;type:varchar(255);not null" `
-	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null" `
-	CreatedBy string    `gorm:"column:created_by;type:varchar(255);not null" `
-	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
-	UpdatedBy string    `gorm:"column:updated_by;type:varchar(255);not null" `
+	ID               string     `gorm:"primaryKey;column:id;type:varchar(255);not null" `
+	Title            string     `gorm:"column:title;type:varchar(255);not null" `
+	Author           string     `gorm:"column:author;type:varchar(255);not null" `
+	NormalizedTitle  string     `gorm:"column:normalized_title;type:varchar(255);not null;default:''" `
+	NormalizedAuthor string     `gorm:"column:normalized_author;type:varchar(255);not null;default:''" `
+	Status           string     `gorm:"column:status;type:varchar(16);not null;default:published;index" `
+	PublishedAt      *time.Time `gorm:"column:published_at;type:timestamptz" `
+	CreatedAt        time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	CreatedBy        string     `gorm:"column:created_by;type:varchar(255);not null" `
+	UpdatedAt        time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
+	UpdatedBy        string     `gorm:"column:updated_by;type:varchar(255);not null" `
 }
 
 // TableName for Book model
@@ -19,5 +28,16 @@ func (Book) TableName() string {
 	return "books"
 }
 
+// BeforeSave keeps the normalized columns used for duplicate detection in sync
+func (b *Book) BeforeSave(_ *gorm.DB) error {
+	if b.Title != "" {
+		b.NormalizedTitle = utils.NormalizeTitle(b.Title)
+	}
+	if b.Author != "" {
+		b.NormalizedAuthor = utils.NormalizeAuthor(b.Author)
+	}
+	return nil
+}
+
 // Books model
 type Books []Book
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// BookMerge model records a duplicate book merged into a survivor
+type BookMerge struct {
+	ID           string    `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	SurvivorID   string    `gorm:"column:survivor_id;type:varchar(255);not null;index" `
+	MergedID     string    `gorm:"column:merged_id;type:varchar(255);not null" `
+	MergedTitle  string    `gorm:"column:merged_title;type:varchar(255);not null" `
+	MergedAuthor string    `gorm:"column:merged_author;type:varchar(255);not null" `
+	MergedBy     string    `gorm:"column:merged_by;type:varchar(255);not null" `
+	MergedAt     time.Time `gorm:"column:merged_at;type:timestamptz;not null" `
+}
+
+// TableName for BookMerge model
+func (BookMerge) TableName() string {
+	return "book_merges"
+}
+
+// BookMerges model
+type BookMerges []BookMerge
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// BookTransition model records a change of a book publication status
+type BookTransition struct {
+	ID         string    `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	BookID     string    `gorm:"column:book_id;type:varchar(255);not null;index" `
+	Action     string    `gorm:"column:action;type:varchar(16);not null" `
+	FromStatus string    `gorm:"column:from_status;type:varchar(16);not null" `
+	ToStatus   string    `gorm:"column:to_status;type:varchar(16);not null" `
+	Note       string    `gorm:"column:note;type:text;not null;default:''" `
+	ActorID    string    `gorm:"column:actor_id;type:varchar(255);not null" `
+	ActorRole  string    `gorm:"column:actor_role;type:varchar(32);not null" `
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null" `
+}
+
+// TableName for BookTransition model
+func (BookTransition) TableName() string {
+	return "book_transitions"
+}
+
+// BookTransitions model
+type BookTransitions []BookTransition
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// OutboxEvent model is a domain event waiting to be relayed, written in the transaction of the change
+type OutboxEvent struct {
+	ID            string     `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	AggregateType string     `gorm:"column:aggregate_type;type:varchar(64);not null" `
+	AggregateID   string     `gorm:"column:aggregate_id;type:varchar(255);not null" `
+	EventType     string     `gorm:"column:event_type;type:varchar(64);not null" `
+	Payload       string     `gorm:"column:payload;type:jsonb;not null" `
+	Attempts      int        `gorm:"column:attempts;type:integer;not null;default:0" `
+	LastError     string     `gorm:"column:last_error;type:text;not null;default:''" `
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;type:timestamptz;not null;index:idx_outbox_pending,where:published_at IS NULL" `
+	PublishedAt   *time.Time `gorm:"column:published_at;type:timestamptz" `
+}
+
+// TableName for OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+// OutboxEvents model
+type OutboxEvents []OutboxEvent
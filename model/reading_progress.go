@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ReadingProgress model tracks where a user is in a book
+type ReadingProgress struct {
+	ID          string     `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	UserID      string     `gorm:"column:user_id;type:varchar(255);not null;uniqueIndex:idx_reading_progress_user_book" `
+	BookID      string     `gorm:"column:book_id;type:varchar(255);not null;uniqueIndex:idx_reading_progress_user_book;index" `
+	Status      string     `gorm:"column:status;type:varchar(16);not null" `
+	CurrentPage int        `gorm:"column:current_page;type:integer;not null;default:0" `
+	TotalPages  int        `gorm:"column:total_pages;type:integer;not null;default:0" `
+	Percent     float64    `gorm:"column:percent;type:numeric(5,2);not null;default:0" `
+	StartedAt   *time.Time `gorm:"column:started_at;type:timestamptz" `
+	FinishedAt  *time.Time `gorm:"column:finished_at;type:timestamptz" `
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamptz;not null" `
+}
+
+// TableName for ReadingProgress model
+func (ReadingProgress) TableName() string {
+	return "reading_progress"
+}
+
+// ReadingProgresses model
+type ReadingProgresses []ReadingProgress
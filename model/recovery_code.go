@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// RecoveryCode model is a single-use code logging in a user in place of a TOTP code, only its hash
+// is stored
+type RecoveryCode struct {
+	UserID    string     `gorm:"primaryKey;column:user_id;type:varchar(36);not null" `
+	CodeHash  string     `gorm:"primaryKey;column:code_hash;type:varchar(64);not null" `
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	UsedAt    *time.Time `gorm:"column:used_at;type:timestamptz" `
+}
+
+// TableName for RecoveryCode model
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Role model groups permissions, users get permissions through their roles
+type Role struct {
+	Name        string `gorm:"primaryKey;column:name;type:varchar(32);not null" `
+	Description string `gorm:"column:description;type:varchar(255);not null;default:''" `
+}
+
+// TableName for Role model
+func (Role) TableName() string {
+	return "roles"
+}
+
+// Permission model is an action a role may perform, such as book:delete
+type Permission struct {
+	Name        string `gorm:"primaryKey;column:name;type:varchar(64);not null" `
+	Description string `gorm:"column:description;type:varchar(255);not null;default:''" `
+}
+
+// TableName for Permission model
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// RolePermission model grants a permission to a role
+type RolePermission struct {
+	Role       string `gorm:"primaryKey;column:role;type:varchar(32);not null" `
+	Permission string `gorm:"primaryKey;column:permission;type:varchar(64);not null" `
+}
+
+// TableName for RolePermission model
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// UserRole model assigns a role to a user
+type UserRole struct {
+	UserID    string    `gorm:"primaryKey;column:user_id;type:varchar(36);not null" `
+	Role      string    `gorm:"primaryKey;column:role;type:varchar(32);not null" `
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null" `
+}
+
+// TableName for UserRole model
+func (UserRole) TableName() string {
+	return "user_roles"
+}
@@ -9,6 +9,22 @@ type User struct {
 	Password  string    `gorm:"column:password;type:varchar(255);not null" `
 	CreatedAt time.Time `gorm:"column:created_at;type:timestampz;not null" `
 	UpdatedAt time.Time `gorm:"column:updated_at;type:timestampz;not null" `
+
+	// Profile, email is stored lower cased and unique when set
+	DisplayName     string     `gorm:"column:display_name;type:varchar(100);not null;default:''" `
+	Email           *string    `gorm:"column:email;type:varchar(255);uniqueIndex:users_email_key" `
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at;type:timestamptz" `
+	Bio             string     `gorm:"column:bio;type:varchar(500);not null;default:''" `
+	AvatarURL       string     `gorm:"column:avatar_url;type:varchar(2048);not null;default:''" `
+
+	// Two-factor authentication, the secret is set at enrollment and enabled once confirmed
+	TOTPSecret    *string    `gorm:"column:totp_secret;type:varchar(64)" `
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at;type:timestamptz" `
+}
+
+// TwoFactorEnabled tells whether the user has to give a second factor to log in.
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != nil
 }
 
 // TableName for User model
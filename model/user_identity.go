@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// UserIdentity model links a user to their account at an external OpenID Connect provider
+type UserIdentity struct {
+	Issuer      string     `gorm:"primaryKey;column:issuer;type:varchar(255);not null" `
+	Subject     string     `gorm:"primaryKey;column:subject;type:varchar(255);not null" `
+	UserID      string     `gorm:"column:user_id;type:varchar(36);not null;index:user_identities_user_id_idx" `
+	Email       string     `gorm:"column:email;type:varchar(255);not null;default:''" `
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	LastLoginAt *time.Time `gorm:"column:last_login_at;type:timestamptz" `
+}
+
+// TableName for UserIdentity model
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
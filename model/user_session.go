@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// UserSession model is a login of a user on a device, its ID is the token family of the login so
+// it lives as long as its refresh tokens
+type UserSession struct {
+	ID         string     `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	UserID     string     `gorm:"column:user_id;type:varchar(36);not null;index:user_sessions_user_id_idx" `
+	UserAgent  string     `gorm:"column:user_agent;type:varchar(512);not null;default:''" `
+	IP         string     `gorm:"column:ip;type:varchar(45);not null;default:''" `
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	LastSeenAt time.Time  `gorm:"column:last_seen_at;type:timestamptz;not null" `
+	ExpiresAt  time.Time  `gorm:"column:expires_at;type:timestamptz;not null" `
+	RevokedAt  *time.Time `gorm:"column:revoked_at;type:timestamptz" `
+}
+
+// TableName for UserSession model
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
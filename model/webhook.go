@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// WebhookSubscription model is an endpoint receiving the events it subscribed to
+type WebhookSubscription struct {
+	ID        string    `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	URL       string    `gorm:"column:url;type:varchar(2048);not null" `
+	Secret    string    `gorm:"column:secret;type:varchar(255);not null" `
+	Events    string    `gorm:"column:events;type:text;not null" `
+	Active    bool      `gorm:"column:active;type:boolean;not null;default:true" `
+	CreatedBy string    `gorm:"column:created_by;type:varchar(255);not null" `
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null" `
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null" `
+}
+
+// TableName for WebhookSubscription model
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookSubscriptions model
+type WebhookSubscriptions []WebhookSubscription
+
+// WebhookDelivery model logs one delivery of an event to a subscription
+type WebhookDelivery struct {
+	ID             string     `gorm:"primaryKey;column:id;type:varchar(36);not null" `
+	SubscriptionID string     `gorm:"column:subscription_id;type:varchar(36);not null;index" `
+	EventID        string     `gorm:"column:event_id;type:varchar(36);not null;index" `
+	EventType      string     `gorm:"column:event_type;type:varchar(64);not null" `
+	Payload        string     `gorm:"column:payload;type:jsonb;not null" `
+	Status         string     `gorm:"column:status;type:varchar(16);not null" `
+	Attempts       int        `gorm:"column:attempts;type:integer;not null;default:0" `
+	ResponseCode   int        `gorm:"column:response_code;type:integer;not null;default:0" `
+	ResponseBody   string     `gorm:"column:response_body;type:text;not null;default:''" `
+	Error          string     `gorm:"column:error;type:text;not null;default:''" `
+	DurationMs     int64      `gorm:"column:duration_ms;type:bigint;not null;default:0" `
+	RedeliveryOf   *string    `gorm:"column:redelivery_of;type:varchar(36)" `
+	CreatedAt      time.Time  `gorm:"column:created_at;type:timestamptz;not null" `
+	DeliveredAt    *time.Time `gorm:"column:delivered_at;type:timestamptz" `
+	// NextAttemptAt is when a pending delivery is due, a worker sending it pushes it back by its lease
+	NextAttemptAt time.Time `gorm:"column:next_attempt_at;type:timestamptz;not null;default:now();index:idx_webhook_deliveries_pending,where:status = 'pending'" `
+}
+
+// TableName for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveries model
+type WebhookDeliveries []WebhookDelivery
@@ -0,0 +1,109 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"gorm.io/gorm"
+)
+
+// APIKeyPrefix starts every API key, so keys are easy to recognize in logs and by secret scanners.
+const APIKeyPrefix = "ak_"
+
+// ErrInvalidAPIKey is returned by Authenticate for unknown, expired and revoked keys.
+var ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+
+type IAPIKeyService interface {
+	// Authenticate returns the API key, ErrInvalidAPIKey when it is unknown, expired or revoked.
+	// The last use of the key is recorded.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: API key
+	//
+	// Returns:
+	//   - *model.APIKey: API key
+	//   - error: error
+	Authenticate(ctx context.Context, key string) (*model.APIKey, error)
+}
+
+type APIKeyConfig struct {
+	// DB storing the API keys
+	DB database.IDBService
+
+	// LastUsedInterval is the resolution of the last use of a key, it is written at most once
+	// per interval so a busy client does not write on every request
+	LastUsedInterval time.Duration
+}
+
+type apiKeys struct {
+	db               database.IDBService
+	lastUsedInterval time.Duration
+}
+
+func NewAPIKeyService(config *APIKeyConfig) IAPIKeyService {
+	return &apiKeys{
+		db:               config.DB,
+		lastUsedInterval: config.LastUsedInterval,
+	}
+}
+
+// GenerateAPIKey generates an API key. Only the hash is meant to be stored, the prefix identifies
+// the key to its owner without revealing it.
+//
+// Returns:
+//   - string: API key in the form ak_<prefix>_<secret>
+//   - string: prefix of the key
+//   - string: hash of the key
+//   - error: error
+func GenerateAPIKey() (string, string, string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	secret, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	prefix := APIKeyPrefix + hex.EncodeToString(b)
+	key := prefix + "_" + secret
+	return key, prefix, HashOpaqueToken(key), nil
+}
+
+func (a *apiKeys) Authenticate(ctx context.Context, key string) (*model.APIKey, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	var apiKey model.APIKey
+	err := a.db.GetTransaction(ctx).
+		Where("key_hash = ? AND revoked_at IS NULL", HashOpaqueToken(key)).
+		First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= a.lastUsedInterval {
+		err := a.db.GetTransaction(ctx).Model(&apiKey).UpdateColumn("last_used_at", now).Error
+		if err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+	return &apiKey, nil
+}
@@ -1,8 +1,16 @@
 package authentication
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"fmt"
+	"os"
 	"strings"
+	"sync"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type IBasicAuthService interface {
@@ -16,6 +24,17 @@ type IBasicAuthService interface {
 	//   - bool: true if the user is authenticated, false otherwise
 	Validate(username, password string) bool
 
+	// Authenticate authenticates a client of the registry using basic auth.
+	//
+	// Parameters:
+	//   - username: client ID
+	//   - password: client secret
+	//
+	// Returns:
+	//   - string: client ID
+	//   - bool: true if the client is authenticated and enabled, false otherwise
+	Authenticate(username, password string) (string, bool)
+
 	// DecodeBasicAuth decodes the basic auth header.
 	//
 	// Parameters:
@@ -27,28 +46,115 @@ type IBasicAuthService interface {
 	DecodeFromHeader(auth string) (string, string)
 }
 
+// BasicAuthClient is a client of the registry, its secret is stored as a bcrypt hash.
+type BasicAuthClient struct {
+	ID         string `json:"id"`
+	SecretHash string `json:"secretHash"`
+	Disabled   bool   `json:"disabled"`
+}
+
 type BasicAuthTConfig struct {
-	// Username
+	// Username and Password are a single client with a plaintext secret, kept for deployments
+	// without a registry, the client ID is the username
 	Username string
-
-	// Password
 	Password string
+
+	// Clients of the registry
+	Clients []BasicAuthClient
 }
 
 type basicAuth struct {
 	username string
 	password string
+	clients  map[string]BasicAuthClient
+
+	// verified caches the clients whose secret matched its hash, keyed by the SHA-256 of the
+	// credentials, so bcrypt runs once per client instead of on every request
+	verified sync.Map
 }
 
+// dummySecretHash is compared against for unknown clients, so they take as long as known ones.
+var (
+	dummySecretHash     []byte
+	dummySecretHashOnce sync.Once
+)
+
 func NewBasicAuthService(config *BasicAuthTConfig) IBasicAuthService {
+	clients := make(map[string]BasicAuthClient, len(config.Clients))
+	for _, client := range config.Clients {
+		clients[client.ID] = client
+	}
 	return &basicAuth{
 		username: config.Username,
 		password: config.Password,
+		clients:  clients,
+	}
+}
+
+// LoadBasicAuthClients reads the clients of the registry from a JSON file holding an array of
+// BasicAuthClient.
+//
+// Parameters:
+//   - path: path of the file
+//
+// Returns:
+//   - []BasicAuthClient: clients
+//   - error: error if the file cannot be read or a client is invalid
+func LoadBasicAuthClients(path string) ([]BasicAuthClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients []BasicAuthClient
+	if err := utils.JSONUnMarshal(data, &clients); err != nil {
+		return nil, fmt.Errorf("basic auth clients: %w", err)
+	}
+
+	seen := make(map[string]bool, len(clients))
+	for _, client := range clients {
+		if client.ID == "" || strings.Contains(client.ID, ":") {
+			return nil, fmt.Errorf("basic auth clients: invalid client ID %q", client.ID)
+		}
+		if seen[client.ID] {
+			return nil, fmt.Errorf("basic auth clients: duplicate client ID %q", client.ID)
+		}
+		if _, err := bcrypt.Cost([]byte(client.SecretHash)); err != nil {
+			return nil, fmt.Errorf("basic auth clients: secret of %q is not a bcrypt hash", client.ID)
+		}
+		seen[client.ID] = true
 	}
+	return clients, nil
 }
 
 func (b *basicAuth) Validate(username, password string) bool {
-	return b.username == username && b.password == password
+	_, ok := b.Authenticate(username, password)
+	return ok
+}
+
+func (b *basicAuth) Authenticate(username, password string) (string, bool) {
+	if b.username != "" && subtle.ConstantTimeCompare([]byte(username), []byte(b.username)) == 1 {
+		return username, subtle.ConstantTimeCompare([]byte(password), []byte(b.password)) == 1
+	}
+
+	client, ok := b.clients[username]
+	if !ok || client.Disabled {
+		dummySecretHashOnce.Do(func() {
+			dummySecretHash, _ = bcrypt.GenerateFromPassword([]byte("dummy"), bcrypt.DefaultCost)
+		})
+		_ = bcrypt.CompareHashAndPassword(dummySecretHash, []byte(password))
+		return "", false
+	}
+
+	key := sha256.Sum256([]byte(username + ":" + password))
+	if _, ok := b.verified.Load(key); ok {
+		return client.ID, true
+	}
+	if !VerifyPassword(password, client.SecretHash) {
+		return "", false
+	}
+	b.verified.Store(key, struct{}{})
+	return client.ID, true
 }
 
 func (b *basicAuth) DecodeFromHeader(auth string) (string, string) {
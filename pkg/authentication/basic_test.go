@@ -0,0 +1,79 @@
+package authentication_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashSecret(t *testing.T, secret string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
+func TestBasicAuth_Authenticate(t *testing.T) {
+	basic := authentication.NewBasicAuthService(&authentication.BasicAuthTConfig{
+		Username: "legacy",
+		Password: "legacy-secret",
+		Clients: []authentication.BasicAuthClient{
+			{ID: "partner-a", SecretHash: hashSecret(t, "secret-a")},
+			{ID: "partner-b", SecretHash: hashSecret(t, "secret-b"), Disabled: true},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		clientID string
+		ok       bool
+	}{
+		{name: "client", username: "partner-a", password: "secret-a", clientID: "partner-a", ok: true},
+		{name: "client again from cache", username: "partner-a", password: "secret-a", clientID: "partner-a", ok: true},
+		{name: "wrong secret", username: "partner-a", password: "secret-b", ok: false},
+		{name: "disabled client", username: "partner-b", password: "secret-b", ok: false},
+		{name: "unknown client", username: "partner-c", password: "secret-a", ok: false},
+		{name: "legacy credentials", username: "legacy", password: "legacy-secret", clientID: "legacy", ok: true},
+		{name: "legacy wrong secret", username: "legacy", password: "secret-a", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientID, ok := basic.Authenticate(tt.username, tt.password)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.clientID, clientID)
+			}
+		})
+	}
+}
+
+func TestLoadBasicAuthClients(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "clients.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	hash := hashSecret(t, "secret")
+
+	clients, err := authentication.LoadBasicAuthClients(write(`[{"id": "partner-a", "secretHash": "` + hash + `"}, {"id": "partner-b", "secretHash": "` + hash + `", "disabled": true}]`))
+	assert.NoError(t, err)
+	assert.Len(t, clients, 2)
+	assert.True(t, clients[1].Disabled)
+
+	_, err = authentication.LoadBasicAuthClients(write(`[{"id": "partner-a", "secretHash": "secret"}]`))
+	assert.Error(t, err)
+
+	_, err = authentication.LoadBasicAuthClients(write(`[{"id": "partner-a", "secretHash": "` + hash + `"}, {"id": "partner-a", "secretHash": "` + hash + `"}]`))
+	assert.Error(t, err)
+
+	_, err = authentication.LoadBasicAuthClients(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
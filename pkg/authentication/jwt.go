@@ -1,13 +1,13 @@
 package authentication
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
+	"encoding/json"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 type IJwtService interface {
@@ -30,15 +30,29 @@ type IJwtService interface {
 	//   - error: error
 	ParseToken(token string) (*JWTClaims, error)
 
-	// RefreshToken refreshes a JWT token.
+	// GenerateRefreshToken generates a new refresh token, it lives for the refresh time and is
+	// rejected where an access token is expected.
 	//
 	// Parameters:
-	//   - token: JWT token
+	//   - claims: JWT claims
+	//
+	// Returns:
+	//   - string: JWT token
+	//   - error: error
+	GenerateRefreshToken(claims JWTClaims) (string, error)
+
+	// GenerateChallengeToken generates a token proving the password of a user was verified, to be
+	// exchanged with a second factor for an access token. It is rejected where an access token is
+	// expected.
+	//
+	// Parameters:
+	//   - claims: JWT claims
+	//   - lifetime: lifetime of the token
 	//
 	// Returns:
 	//   - string: JWT token
 	//   - error: error
-	RefreshToken(token string) (string, error)
+	GenerateChallengeToken(claims JWTClaims, lifetime time.Duration) (string, error)
 
 	// ValidateToken validates a JWT token.
 	//
@@ -50,11 +64,77 @@ type IJwtService interface {
 	ValidateToken(token string) error
 }
 
+// Registered and private claims set on every token.
+const (
+	ClaimTokenID  = "jti"
+	ClaimIssuedAt = "iat"
+	ClaimType     = "typ"
+	// ClaimFamily links the access and refresh tokens issued from one login.
+	ClaimFamily = "fam"
+	// ClaimEmailVerified tells whether the email address of the user was verified when the token
+	// was issued.
+	ClaimEmailVerified = "emailVerified"
+	// ClaimRoles and ClaimPermissions list the roles of the user and the permissions they grant.
+	ClaimRoles       = "roles"
+	ClaimPermissions = "permissions"
+
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeChallenge is the type of the token returned by a login that requires a second
+	// factor.
+	TokenTypeChallenge = "mfa_challenge"
+)
+
 type JWTClaims map[string]interface{}
+
+// String returns the claim as a string, empty when it is missing or not a string.
+func (c JWTClaims) String(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Strings returns the claim as a list of strings, nil when it is missing or not a list. Items that
+// are not strings are skipped.
+func (c JWTClaims) Strings(key string) []string {
+	switch value := c[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// Time returns a numeric date claim such as exp or iat to the millisecond, zero when it is missing.
+func (c JWTClaims) Time(key string) time.Time {
+	switch value := c[key].(type) {
+	case float64:
+		return time.UnixMilli(int64(math.Round(value * 1000)))
+	case int64:
+		return time.Unix(value, 0)
+	case json.Number:
+		if seconds, err := value.Float64(); err == nil {
+			return time.UnixMilli(int64(math.Round(seconds * 1000)))
+		}
+	}
+	return time.Time{}
+}
+
 type JWTConfig struct {
-	// JWT secret
+	// JWT secret, the PEM encoded key pair signing the tokens when Keys is nil
 	PrivateKey string
 	PublicKey  string
+	// Algorithm of the key pair, RS256 when empty
+	Algorithm string
+
+	// Keys sign and verify the tokens, see NewFileKeyManager
+	Keys IKeyManager
 
 	// JWT expiration time
 	ExpirationTime int
@@ -70,8 +150,7 @@ type JWTConfig struct {
 }
 
 type jwtAuth struct {
-	privateKey     string
-	publicKey      string
+	keys           IKeyManager
 	issuer         string
 	audience       string
 	refreshTime    int
@@ -79,9 +158,14 @@ type jwtAuth struct {
 }
 
 func NewJWTService(opts *JWTConfig) IJwtService {
+	keys := opts.Keys
+	if keys == nil {
+		// an invalid key pair fails the tokens, as the key manager keeps the error
+		keys, _ = NewStaticKeyManager(opts.PrivateKey, opts.PublicKey, opts.Algorithm)
+	}
+
 	return &jwtAuth{
-		privateKey:     opts.PrivateKey,
-		publicKey:      opts.PublicKey,
+		keys:           keys,
 		expirationTime: opts.ExpirationTime,
 		refreshTime:    opts.RefreshTime,
 		issuer:         opts.Issuer,
@@ -90,41 +174,50 @@ func NewJWTService(opts *JWTConfig) IJwtService {
 }
 
 func (j *jwtAuth) GenerateToken(dataClaims JWTClaims) (string, error) {
-	var tokenString string
-	var privateKey *rsa.PrivateKey
+	return j.sign(dataClaims, TokenTypeAccess, time.Duration(j.expirationTime)*time.Minute)
+}
 
-	block, _ := pem.Decode([]byte(j.privateKey))
-	if block == nil {
-		return "", errors.New("invalid private rsa key")
-	}
+func (j *jwtAuth) GenerateRefreshToken(dataClaims JWTClaims) (string, error) {
+	return j.sign(dataClaims, TokenTypeRefresh, time.Duration(j.refreshTime)*time.Minute)
+}
+
+func (j *jwtAuth) GenerateChallengeToken(dataClaims JWTClaims, lifetime time.Duration) (string, error) {
+	return j.sign(dataClaims, TokenTypeChallenge, lifetime)
+}
 
-	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+// sign signs the claims as a token of the type with the active key, with a new jti unless the
+// claims carry one.
+func (j *jwtAuth) sign(dataClaims JWTClaims, tokenType string, lifetime time.Duration) (string, error) {
+	key, err := j.keys.SigningKey()
 	if err != nil {
 		return "", err
 	}
 
-	// Create the token
-	token := jwt.New(jwt.SigningMethodRS256)
+	// Create the token, kid tells verifiers which key to check it with
+	token := jwt.New(signingMethods[key.Algorithm])
+	token.Header["kid"] = key.ID
 
 	now := time.Now()
-	exp := now.Add(time.Duration(j.expirationTime) * time.Minute).Unix()
-	// Set claims
 
-	// Set claims
+	// Set claims, iat keeps the milliseconds so a revocation of the user spares the tokens issued
+	// right after it
 	claimsMap := jwt.MapClaims{
-		"iss": j.issuer,
-		"aud": j.audience,
-		"exp": exp,
+		"iss":         j.issuer,
+		"aud":         j.audience,
+		"exp":         now.Add(lifetime).Unix(),
+		ClaimIssuedAt: float64(now.UnixMilli()) / 1000,
+		ClaimTokenID:  uuid.NewString(),
 	}
 
 	for key, value := range dataClaims {
 		claimsMap[key] = value
 	}
+	claimsMap[ClaimType] = tokenType
 
 	token.Claims = claimsMap
 
 	// Sign the token with the private key
-	tokenString, err = token.SignedString(privateKey)
+	tokenString, err := token.SignedString(key.Key)
 	if err != nil {
 		return "", err
 	}
@@ -134,10 +227,16 @@ func (j *jwtAuth) GenerateToken(dataClaims JWTClaims) (string, error) {
 
 func (j *jwtAuth) ParseToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, err := j.keys.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		// a token is only accepted with the algorithm of its key
+		if token.Method.Alg() != key.Algorithm {
 			return nil, errors.New("unexpected signing method")
 		}
-		return jwt.ParseRSAPublicKeyFromPEM([]byte(j.publicKey))
+		return key.Key, nil
 	})
 
 	if err != nil {
@@ -155,46 +254,6 @@ func (j *jwtAuth) ParseToken(tokenString string) (*JWTClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
-func (j *jwtAuth) RefreshToken(tokenString string) (string, error) {
-	var privateKey *rsa.PrivateKey
-	block, _ := pem.Decode([]byte(j.privateKey))
-	if block == nil {
-		return "", errors.New("invalid private rsa key")
-	}
-
-	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-	if err != nil {
-		return "", err
-	}
-
-	claims, err := j.ParseToken(tokenString)
-	if err != nil {
-		return "", err
-	}
-
-	// Create a new claims map and copy existing claims
-	newClaims := jwt.MapClaims{
-		"iss": j.issuer,
-		"aud": j.audience,
-		"exp": time.Now().Add(time.Duration(j.refreshTime) * time.Minute).Unix(),
-	}
-
-	for key, value := range *claims {
-		if key != "exp" { // Avoid copying the old expiration time
-			newClaims[key] = value
-		}
-	}
-
-	newToken := jwt.NewWithClaims(jwt.SigningMethodRS256, newClaims)
-
-	newTokenString, err := newToken.SignedString(privateKey)
-	if err != nil {
-		return "", err
-	}
-
-	return newTokenString, nil
-}
-
 func (j *jwtAuth) ValidateToken(tokenString string) error {
 	_, err := j.ParseToken(tokenString)
 	return err
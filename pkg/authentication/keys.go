@@ -0,0 +1,411 @@
+package authentication
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+)
+
+// ErrUnknownKey is returned for a kid that is not in the key set or is retired.
+var ErrUnknownKey = errors.New("unknown or retired signing key")
+
+type IKeyManager interface {
+	// SigningKey returns the active key, tokens are signed with it.
+	//
+	// Returns:
+	//   - *SigningKey: active key
+	//   - error: error when there is no active key
+	SigningKey() (*SigningKey, error)
+
+	// VerificationKey returns the public key of a key that is not retired. Tokens without kid,
+	// issued before keys had one, are verified with the active key.
+	//
+	// Parameters:
+	//   - kid: key ID of the token
+	//
+	// Returns:
+	//   - *VerificationKey: public key and its algorithm
+	//   - error: ErrUnknownKey when the key is unknown or retired
+	VerificationKey(kid string) (*VerificationKey, error)
+
+	// JWKS returns the public keys that are not retired, for other services to verify our tokens.
+	//
+	// Returns:
+	//   - JWKSet: JSON Web Key Set
+	JWKS() JWKSet
+
+	// Reload reads the keys again, so they can be rotated without a restart. The keys in use are
+	// kept when they cannot be read.
+	//
+	// Returns:
+	//   - error: error
+	Reload() error
+}
+
+// SigningKey is a private key, its kid and the algorithm it signs with.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.Signer
+}
+
+// VerificationKey is a public key, its kid and the algorithm of the tokens it verifies.
+type VerificationKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// JWK is the public part of a key, as published in a JSON Web Key Set. RSA keys have N and E,
+// EC keys Crv, X and Y and OKP (Ed25519) keys Crv and X.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySetFile lists the keys of a key set file. Paths are relative to the file. A key with only a
+// public key verifies tokens without signing any, such as a key about to become active which is
+// published ahead so verifiers know it, or the previous active key while its tokens expire.
+// Retired keys verify nothing.
+type KeySetFile struct {
+	// Active is the kid of the key signing the tokens
+	Active string `json:"active"`
+
+	Keys []KeySetFileEntry `json:"keys"`
+}
+
+type KeySetFileEntry struct {
+	// ID is the kid of the key, the RFC 7638 thumbprint of the public key when empty
+	ID string `json:"kid"`
+	// Algorithm of the key, the default algorithm of the key manager when empty
+	Algorithm      string `json:"alg"`
+	PrivateKeyFile string `json:"privateKeyFile"`
+	PublicKeyFile  string `json:"publicKeyFile"`
+	Retired        bool   `json:"retired"`
+}
+
+// keyRing is a loaded key set, replaced as a whole on reload.
+type keyRing struct {
+	active *SigningKey
+	keys   map[string]*VerificationKey
+	// order of the keys in the set, the JWKS lists them in this order
+	order []string
+}
+
+type keyManager struct {
+	load func() (*keyRing, error)
+
+	mu   sync.RWMutex
+	ring *keyRing
+	err  error
+}
+
+// NewStaticKeyManager creates a key manager of a single PEM encoded key pair, its kid is the
+// thumbprint of the public key. It cannot be rotated. The key manager is returned with the error
+// of a key pair that cannot be used, it fails the tokens with it.
+//
+// Parameters:
+//   - privateKey: PEM encoded private key, see ParsePrivateKeyPEM
+//   - publicKey: PEM encoded public key, see ParsePublicKeyPEM, the public key of the private key
+//     when empty
+//   - algorithm: algorithm of the key, RS256 when empty
+//
+// Returns:
+//   - IKeyManager: key manager
+//   - error: error when a key cannot be parsed or the algorithm does not match it
+func NewStaticKeyManager(privateKey string, publicKey string, algorithm string) (IKeyManager, error) {
+	k := &keyManager{load: func() (*keyRing, error) {
+		return loadStaticKeys(privateKey, publicKey, utils.IfThenElse(algorithm == "", AlgorithmRS256, algorithm))
+	}}
+	if err := k.Reload(); err != nil {
+		return k, err
+	}
+	return k, nil
+}
+
+// NewFileKeyManager creates a key manager of the keys listed in a key set file, see KeySetFile.
+// Reload reads the file and the keys again.
+//
+// Parameters:
+//   - path: path of the key set file
+//   - algorithm: algorithm of the keys without one, RS256 when empty
+//
+// Returns:
+//   - IKeyManager: key manager
+//   - error: error when the keys cannot be read or an algorithm does not match its key
+func NewFileKeyManager(path string, algorithm string) (IKeyManager, error) {
+	k := &keyManager{load: func() (*keyRing, error) {
+		return loadKeySetFile(path, utils.IfThenElse(algorithm == "", AlgorithmRS256, algorithm))
+	}}
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (k *keyManager) Reload() error {
+	ring, err := k.load()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err != nil {
+		// keep the keys in use, the error is only kept while there are none
+		if k.ring == nil {
+			k.err = err
+		}
+		return err
+	}
+	k.ring, k.err = ring, nil
+	return nil
+}
+
+func (k *keyManager) current() (*keyRing, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.ring == nil {
+		return nil, k.err
+	}
+	return k.ring, nil
+}
+
+func (k *keyManager) SigningKey() (*SigningKey, error) {
+	ring, err := k.current()
+	if err != nil {
+		return nil, err
+	}
+	if ring.active == nil {
+		return nil, errors.New("no active signing key")
+	}
+	return ring.active, nil
+}
+
+func (k *keyManager) VerificationKey(kid string) (*VerificationKey, error) {
+	ring, err := k.current()
+	if err != nil {
+		return nil, err
+	}
+
+	if kid == "" {
+		if ring.active == nil {
+			return nil, ErrUnknownKey
+		}
+		kid = ring.active.ID
+	}
+	key, ok := ring.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (k *keyManager) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	ring, err := k.current()
+	if err != nil {
+		return set
+	}
+
+	for _, kid := range ring.order {
+		key := ring.keys[kid]
+		// the keys were checked on load
+		jwk, _ := publicJWK(key.Key)
+		jwk.Kid, jwk.Use, jwk.Alg = kid, "sig", key.Algorithm
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// KeyThumbprint returns the RFC 7638 thumbprint of a public key, a stable kid for it.
+//
+// Parameters:
+//   - key: RSA, ECDSA or Ed25519 public key
+//
+// Returns:
+//   - string: base64url encoded SHA-256 thumbprint
+//   - error: error when the key type is not supported
+func KeyThumbprint(key crypto.PublicKey) (string, error) {
+	jwk, err := publicJWK(key)
+	if err != nil {
+		return "", err
+	}
+
+	// the required members of the key type in lexicographic order, without whitespace
+	var members string
+	switch jwk.Kty {
+	case "RSA":
+		members = `{"e":"` + jwk.E + `","kty":"RSA","n":"` + jwk.N + `"}`
+	case "EC":
+		members = `{"crv":"` + jwk.Crv + `","kty":"EC","x":"` + jwk.X + `","y":"` + jwk.Y + `"}`
+	case "OKP":
+		members = `{"crv":"` + jwk.Crv + `","kty":"OKP","x":"` + jwk.X + `"}`
+	}
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// publicJWK returns the key type and parameters of the JWK of a public key.
+func publicJWK(key crypto.PublicKey) (JWK, error) {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{Kty: "RSA", N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes())}, nil
+	case *ecdsa.PublicKey:
+		// the uncompressed point, 0x04 then the coordinates padded to the size of the curve
+		ecdh, err := key.ECDH()
+		if err != nil {
+			return JWK{}, err
+		}
+		point := ecdh.Bytes()
+		size := (len(point) - 1) / 2
+		return JWK{Kty: "EC", Crv: key.Curve.Params().Name, X: encode(point[1 : 1+size]), Y: encode(point[1+size:])}, nil
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: encode(key)}, nil
+	}
+	return JWK{}, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// newKey pairs the keys with their kid and algorithm, after checking they can be used together.
+// The public key is the one of the private key when there is one.
+func newKey(kid string, algorithm string, private crypto.Signer, public crypto.PublicKey) (*SigningKey, *VerificationKey, error) {
+	if private != nil {
+		if public != nil && !private.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(public) {
+			return nil, nil, errors.New("public key does not match the private key")
+		}
+		public = private.Public()
+	}
+	if err := CheckKeyAlgorithm(algorithm, public); err != nil {
+		return nil, nil, err
+	}
+
+	if kid == "" {
+		var err error
+		if kid, err = KeyThumbprint(public); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	verification := &VerificationKey{ID: kid, Algorithm: algorithm, Key: public}
+	if private == nil {
+		return nil, verification, nil
+	}
+	return &SigningKey{ID: kid, Algorithm: algorithm, Key: private}, verification, nil
+}
+
+func loadStaticKeys(privateKey string, publicKey string, algorithm string) (*keyRing, error) {
+	private, err := ParsePrivateKeyPEM([]byte(privateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var public crypto.PublicKey
+	if publicKey != "" {
+		if public, err = ParsePublicKeyPEM([]byte(publicKey)); err != nil {
+			return nil, err
+		}
+	}
+
+	signing, verification, err := newKey("", algorithm, private, public)
+	if err != nil {
+		return nil, err
+	}
+	return &keyRing{
+		active: signing,
+		keys:   map[string]*VerificationKey{signing.ID: verification},
+		order:  []string{signing.ID},
+	}, nil
+}
+
+func loadKeySetFile(path string, algorithm string) (*keyRing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file KeySetFile
+	if err := utils.JSONUnMarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid key set file %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(name string) string {
+		if filepath.IsAbs(name) {
+			return name
+		}
+		return filepath.Join(dir, name)
+	}
+
+	ring := &keyRing{keys: map[string]*VerificationKey{}}
+	seen := map[string]bool{}
+	for i, entry := range file.Keys {
+		var private crypto.Signer
+		var public crypto.PublicKey
+		if entry.PrivateKeyFile == "" && entry.PublicKeyFile == "" {
+			return nil, fmt.Errorf("key %d: privateKeyFile or publicKeyFile is required", i)
+		}
+		if entry.PrivateKeyFile != "" {
+			pem, err := os.ReadFile(resolve(entry.PrivateKeyFile))
+			if err != nil {
+				return nil, err
+			}
+			if private, err = ParsePrivateKeyPEM(pem); err != nil {
+				return nil, fmt.Errorf("key %d: %w", i, err)
+			}
+		}
+		if entry.PublicKeyFile != "" {
+			pem, err := os.ReadFile(resolve(entry.PublicKeyFile))
+			if err != nil {
+				return nil, err
+			}
+			if public, err = ParsePublicKeyPEM(pem); err != nil {
+				return nil, fmt.Errorf("key %d: %w", i, err)
+			}
+		}
+
+		signing, verification, err := newKey(entry.ID, utils.IfThenElse(entry.Algorithm == "", algorithm, entry.Algorithm), private, public)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		kid := verification.ID
+		if seen[kid] {
+			return nil, fmt.Errorf("key %d: duplicate kid %q", i, kid)
+		}
+		seen[kid] = true
+
+		if kid == file.Active {
+			if entry.Retired || signing == nil {
+				return nil, fmt.Errorf("active key %q must have a private key and not be retired", kid)
+			}
+			ring.active = signing
+		}
+		if !entry.Retired {
+			ring.keys[kid] = verification
+			ring.order = append(ring.order, kid)
+		}
+	}
+	if ring.active == nil {
+		return nil, fmt.Errorf("active key %q is not in the key set", file.Active)
+	}
+	return ring, nil
+}
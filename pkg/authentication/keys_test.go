@@ -0,0 +1,135 @@
+package authentication_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir string, name string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600))
+	return key
+}
+
+func writeKeySet(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func tokenKid(t *testing.T, token string) string {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestFileKeyManagerRotation(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "a.pem")
+	writeKey(t, dir, "b.pem")
+	path := filepath.Join(dir, "keys.json")
+
+	writeKeySet(t, path, `{"active":"a","keys":[{"kid":"a","privateKeyFile":"a.pem"},{"kid":"b","privateKeyFile":"b.pem"}]}`)
+	keys, err := authentication.NewFileKeyManager(path, "")
+	require.NoError(t, err)
+	jwtService := authentication.NewJWTService(&authentication.JWTConfig{Keys: keys, ExpirationTime: 5})
+
+	tokenA, err := jwtService.GenerateToken(authentication.JWTClaims{"sub": "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "a", tokenKid(t, tokenA))
+	assert.Len(t, keys.JWKS().Keys, 2)
+
+	// b becomes active, tokens of a are still accepted
+	writeKeySet(t, path, `{"active":"b","keys":[{"kid":"a","privateKeyFile":"a.pem"},{"kid":"b","privateKeyFile":"b.pem"}]}`)
+	require.NoError(t, keys.Reload())
+	tokenB, err := jwtService.GenerateToken(authentication.JWTClaims{"sub": "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "b", tokenKid(t, tokenB))
+	assert.NoError(t, jwtService.ValidateToken(tokenA))
+	assert.NoError(t, jwtService.ValidateToken(tokenB))
+
+	// a is retired, its tokens are rejected and it is no longer published
+	writeKeySet(t, path, `{"active":"b","keys":[{"kid":"a","privateKeyFile":"a.pem","retired":true},{"kid":"b","privateKeyFile":"b.pem"}]}`)
+	require.NoError(t, keys.Reload())
+	assert.Error(t, jwtService.ValidateToken(tokenA))
+	assert.NoError(t, jwtService.ValidateToken(tokenB))
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "b", jwks.Keys[0].Kid)
+
+	// a broken key set keeps the keys in use
+	writeKeySet(t, path, `{"active":"c","keys":[]}`)
+	assert.Error(t, keys.Reload())
+	assert.NoError(t, jwtService.ValidateToken(tokenB))
+}
+
+func TestFileKeyManagerInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "a.pem")
+	path := filepath.Join(dir, "keys.json")
+
+	for _, content := range []string{
+		`{"active":"a","keys":[{"kid":"a","privateKeyFile":"a.pem","retired":true}]}`,
+		`{"active":"a","keys":[{"kid":"a","privateKeyFile":"a.pem"},{"kid":"a","privateKeyFile":"a.pem"}]}`,
+		`{"active":"a","keys":[{"kid":"a","privateKeyFile":"missing.pem"}]}`,
+		`{"active":"a","keys":[{"kid":"a"}]}`,
+		`not json`,
+	} {
+		writeKeySet(t, path, content)
+		_, err := authentication.NewFileKeyManager(path, "")
+		assert.Error(t, err, content)
+	}
+}
+
+func TestStaticKeyManager(t *testing.T) {
+	dir := t.TempDir()
+	key := writeKey(t, dir, "a.pem")
+	private, err := os.ReadFile(filepath.Join(dir, "a.pem"))
+	require.NoError(t, err)
+
+	keys, err := authentication.NewStaticKeyManager(string(private), "", "")
+	require.NoError(t, err)
+	signing, err := keys.SigningKey()
+	require.NoError(t, err)
+	kid, err := authentication.KeyThumbprint(&key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, kid, signing.ID)
+
+	// tokens issued before kid was set are verified with the active key
+	legacy := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user-1"})
+	signed, err := legacy.SignedString(key)
+	require.NoError(t, err)
+	jwtService := authentication.NewJWTService(&authentication.JWTConfig{Keys: keys})
+	assert.NoError(t, jwtService.ValidateToken(signed))
+
+	// an invalid key fails the tokens
+	_, err = authentication.NewStaticKeyManager("", "", "")
+	assert.Error(t, err)
+	_, err = authentication.NewJWTService(&authentication.JWTConfig{}).GenerateToken(authentication.JWTClaims{})
+	assert.Error(t, err)
+}
+
+func TestKeyThumbprint(t *testing.T) {
+	// RFC 7638 section 3.1
+	n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	nBytes, err := jwt.DecodeSegment(n)
+	require.NoError(t, err)
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: 65537}
+
+	kid, err := authentication.KeyThumbprint(key)
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", kid)
+}
@@ -0,0 +1,186 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// ErrInvalidIDToken is returned by Exchange when the ID token is missing, forged, expired, issued
+// to another client or for another login.
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+type IOIDCService interface {
+	// AuthCodeURL returns the URL of the identity provider to redirect the user to, for the
+	// authorization code flow with PKCE.
+	//
+	// Parameters:
+	//   - req: state, nonce and code verifier of the login
+	//
+	// Returns:
+	//   - string: authorization URL
+	AuthCodeURL(req *OIDCAuthRequest) string
+
+	// Exchange exchanges the authorization code for the tokens of the identity provider and
+	// returns the identity of the user from the ID token. The ID token is validated against the
+	// keys of the provider, its issuer, audience, expiry and the nonce of the login.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - code: authorization code
+	//   - req: state, nonce and code verifier of the login
+	//
+	// Returns:
+	//   - *OIDCIdentity: identity of the user
+	//   - error: error, ErrInvalidIDToken when the ID token is not valid
+	Exchange(ctx context.Context, code string, req *OIDCAuthRequest) (*OIDCIdentity, error)
+}
+
+type OIDCConfig struct {
+	// IssuerURL of the identity provider, its configuration is discovered from
+	// <IssuerURL>/.well-known/openid-configuration
+	IssuerURL string
+
+	// ClientID and ClientSecret of this service at the identity provider
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the callback URL registered at the identity provider
+	RedirectURL string
+
+	// Scopes requested, openid is always requested
+	Scopes []string
+
+	// HTTPClient used to reach the identity provider, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// OIDCAuthRequest holds the secrets of a login in progress, kept by this service between the
+// redirect to the identity provider and the callback.
+type OIDCAuthRequest struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// OIDCIdentity is the user as known by the identity provider.
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string
+}
+
+type oidcService struct {
+	oauth2     oauth2.Config
+	verifier   *oidc.IDTokenVerifier
+	httpClient *http.Client
+}
+
+// NewOIDCService discovers the configuration of the identity provider.
+//
+// Parameters:
+//   - ctx: context of the discovery
+//   - config: OIDC config
+//
+// Returns:
+//   - IOIDCService: OIDC service
+//   - error: error when the provider cannot be discovered
+func NewOIDCService(ctx context.Context, config *OIDCConfig) (IOIDCService, error) {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, httpClient), config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("discover OIDC provider: %w", err)
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range config.Scopes {
+		if scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &oidcService{
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier:   provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		httpClient: httpClient,
+	}, nil
+}
+
+// NewOIDCAuthRequest generates the state, nonce and PKCE code verifier of a login.
+//
+// Returns:
+//   - *OIDCAuthRequest: secrets of the login
+//   - error: error
+func NewOIDCAuthRequest() (*OIDCAuthRequest, error) {
+	state, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthRequest{State: state, Nonce: nonce, Verifier: oauth2.GenerateVerifier()}, nil
+}
+
+func (o *oidcService) AuthCodeURL(req *OIDCAuthRequest) string {
+	return o.oauth2.AuthCodeURL(req.State, oidc.Nonce(req.Nonce), oauth2.S256ChallengeOption(req.Verifier))
+}
+
+func (o *oidcService) Exchange(ctx context.Context, code string, req *OIDCAuthRequest) (*OIDCIdentity, error) {
+	ctx = oidc.ClientContext(ctx, o.httpClient)
+
+	token, err := o.oauth2.Exchange(ctx, code, oauth2.VerifierOption(req.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, ErrInvalidIDToken
+	}
+	idToken, err := o.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidIDToken, err)
+	}
+	if idToken.Nonce != req.Nonce {
+		return nil, ErrInvalidIDToken
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     bool   `json:"email_verified"`
+		Name              string `json:"name"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.Join(ErrInvalidIDToken, err)
+	}
+
+	return &OIDCIdentity{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Username:      claims.PreferredUsername,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package authentication_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/authentication/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOIDCService(t *testing.T) (authentication.IOIDCService, *oidctest.Provider) {
+	provider, err := oidctest.NewProvider("client", "secret")
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	service, err := authentication.NewOIDCService(context.Background(), &authentication.OIDCConfig{
+		IssuerURL:    provider.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/auth/v1/oidc/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+	require.NoError(t, err)
+	return service, provider
+}
+
+func TestOIDCService_Exchange(t *testing.T) {
+	service, provider := newOIDCService(t)
+
+	req, err := authentication.NewOIDCAuthRequest()
+	require.NoError(t, err)
+
+	authURL := service.AuthCodeURL(req)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+	assert.Equal(t, req.Nonce, u.Query().Get("nonce"))
+
+	code, state, err := provider.Authorize(authURL, map[string]interface{}{
+		"sub":                "alice-1",
+		"email":              "Alice@example.com",
+		"email_verified":     true,
+		"name":               "Alice",
+		"preferred_username": "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, req.State, state)
+
+	identity, err := service.Exchange(context.Background(), code, req)
+	require.NoError(t, err)
+	assert.Equal(t, &authentication.OIDCIdentity{
+		Issuer:        provider.Issuer(),
+		Subject:       "alice-1",
+		Email:         "Alice@example.com",
+		EmailVerified: true,
+		Name:          "Alice",
+		Username:      "alice",
+	}, identity)
+
+	// a code is used once
+	_, err = service.Exchange(context.Background(), code, req)
+	assert.Error(t, err)
+}
+
+func TestOIDCService_ExchangeRejected(t *testing.T) {
+	service, provider := newOIDCService(t)
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		req, err := authentication.NewOIDCAuthRequest()
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(service.AuthCodeURL(req), map[string]interface{}{"sub": "alice-1"})
+		require.NoError(t, err)
+
+		other, err := authentication.NewOIDCAuthRequest()
+		require.NoError(t, err)
+		req.Verifier = other.Verifier
+		_, err = service.Exchange(context.Background(), code, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		req, err := authentication.NewOIDCAuthRequest()
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(service.AuthCodeURL(req), map[string]interface{}{"sub": "alice-1"})
+		require.NoError(t, err)
+
+		req.Nonce = "other"
+		_, err = service.Exchange(context.Background(), code, req)
+		assert.ErrorIs(t, err, authentication.ErrInvalidIDToken)
+	})
+
+	t.Run("other audience", func(t *testing.T) {
+		req, err := authentication.NewOIDCAuthRequest()
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(service.AuthCodeURL(req), map[string]interface{}{"sub": "alice-1", "aud": "other"})
+		require.NoError(t, err)
+
+		_, err = service.Exchange(context.Background(), code, req)
+		assert.ErrorIs(t, err, authentication.ErrInvalidIDToken)
+	})
+}
@@ -0,0 +1,218 @@
+// Package oidctest runs a local OpenID Connect provider for tests, supporting discovery, the
+// authorization code flow with PKCE and the JWKS of its ID tokens.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// KeyID is the kid of the signing key of the provider.
+const KeyID = "oidctest"
+
+type authorization struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	nonce       string
+	claims      jwt.MapClaims
+}
+
+// Provider is a local OpenID Connect provider, its issuer is the URL of its server.
+type Provider struct {
+	Server *httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+// NewProvider starts a provider accepting the client.
+//
+// Parameters:
+//   - clientID: client ID
+//   - clientSecret: client secret
+//
+// Returns:
+//   - *Provider: provider, to close once done
+//   - error: error
+func NewProvider(clientID string, clientSecret string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("POST /token", p.token)
+	p.Server = httptest.NewServer(mux)
+	return p, nil
+}
+
+// Issuer returns the issuer of the provider.
+func (p *Provider) Issuer() string {
+	return p.Server.URL
+}
+
+func (p *Provider) Close() {
+	p.Server.Close()
+}
+
+// Authorize plays the user signing in at the provider. It reads the authorization URL a client
+// redirected to and returns the code and state the provider redirects back with. The ID token of
+// the code carries the claims, such as sub and email.
+//
+// Parameters:
+//   - authURL: authorization URL
+//   - claims: claims of the ID token
+//
+// Returns:
+//   - string: authorization code
+//   - string: state
+//   - error: error when the authorization request is invalid
+func (p *Provider) Authorize(authURL string, claims map[string]interface{}) (string, string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	q := u.Query()
+	if q.Get("response_type") != "code" || q.Get("client_id") != p.ClientID {
+		return "", "", errors.New("invalid authorization request")
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", "", errors.New("PKCE is required")
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authorization{
+		clientID:    q.Get("client_id"),
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		claims:      jwt.MapClaims(claims),
+	}
+	p.mu.Unlock()
+	return code, q.Get("state"), nil
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.ClientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.ClientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// a code is used once
+	p.mu.Lock()
+	auth, found := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !found || auth.clientID != clientID || auth.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": p.Issuer(),
+		"aud": p.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	for key, value := range auth.claims {
+		claims[key] = value
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = KeyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
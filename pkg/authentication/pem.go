@@ -0,0 +1,172 @@
+package authentication
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Algorithms supported to sign the tokens.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmRS384 = "RS384"
+	AlgorithmRS512 = "RS512"
+	AlgorithmPS256 = "PS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmES384 = "ES384"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var signingMethods = map[string]jwt.SigningMethod{
+	AlgorithmRS256: jwt.SigningMethodRS256,
+	AlgorithmRS384: jwt.SigningMethodRS384,
+	AlgorithmRS512: jwt.SigningMethodRS512,
+	AlgorithmPS256: jwt.SigningMethodPS256,
+	AlgorithmES256: jwt.SigningMethodES256,
+	AlgorithmES384: jwt.SigningMethodES384,
+	AlgorithmEdDSA: jwt.SigningMethodEdDSA,
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded private key, in the PKCS#1 (RSA PRIVATE KEY), SEC 1
+// (EC PRIVATE KEY) or PKCS#8 (PRIVATE KEY) format.
+//
+// Parameters:
+//   - data: PEM encoded private key
+//
+// Returns:
+//   - crypto.Signer: RSA, ECDSA or Ed25519 private key
+//   - error: error when the key is not PEM encoded, its format or its type is not supported
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported private key PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", block.Type, err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+// ParsePublicKeyPEM parses a PEM encoded public key, in the PKIX (PUBLIC KEY) or PKCS#1 (RSA
+// PUBLIC KEY) format.
+//
+// Parameters:
+//   - data: PEM encoded public key
+//
+// Returns:
+//   - crypto.PublicKey: RSA, ECDSA or Ed25519 public key
+//   - error: error when the key is not PEM encoded, its format or its type is not supported
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported public key PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", block.Type, err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return key, nil
+	case *ecdsa.PublicKey:
+		return key, nil
+	case ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// CheckKeyAlgorithm checks the algorithm is supported and can be used with the key: RSA keys for
+// RS256, RS384, RS512 and PS256, P-256 and P-384 keys for ES256 and ES384 and Ed25519 keys for
+// EdDSA.
+//
+// Parameters:
+//   - algorithm: algorithm
+//   - key: public key
+//
+// Returns:
+//   - error: error when the algorithm is not supported or does not match the key
+func CheckKeyAlgorithm(algorithm string, key crypto.PublicKey) error {
+	method, ok := signingMethods[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	var valid bool
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			valid = true
+		}
+	case *ecdsa.PublicKey:
+		if method, ok := method.(*jwt.SigningMethodECDSA); ok {
+			valid = key.Curve == ecdsaCurves[method.Alg()]
+		}
+	case ed25519.PublicKey:
+		valid = algorithm == AlgorithmEdDSA
+	}
+	if !valid {
+		return fmt.Errorf("algorithm %s cannot be used with a %s key", algorithm, keyType(key))
+	}
+	return nil
+}
+
+var ecdsaCurves = map[string]elliptic.Curve{
+	AlgorithmES256: elliptic.P256(),
+	AlgorithmES384: elliptic.P384(),
+}
+
+// keyType describes the key in errors.
+func keyType(key crypto.PublicKey) string {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return "RSA"
+	case *ecdsa.PublicKey:
+		return "ECDSA " + key.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	}
+	return fmt.Sprintf("%T", key)
+}
@@ -0,0 +1,141 @@
+package authentication_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePEM(blockType string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+func encodePKCS8(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return encodePEM("PRIVATE KEY", der)
+}
+
+func encodePKIX(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return encodePEM("PUBLIC KEY", der)
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		private string
+		public  string
+		key     crypto.Signer
+	}{
+		"PKCS#1":         {encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), encodePEM("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), rsaKey},
+		"PKCS#8 RSA":     {encodePKCS8(t, rsaKey), encodePKIX(t, &rsaKey.PublicKey), rsaKey},
+		"SEC 1":          {encodePEM("EC PRIVATE KEY", sec1), encodePKIX(t, &ecKey.PublicKey), ecKey},
+		"PKCS#8 ECDSA":   {encodePKCS8(t, ecKey), encodePKIX(t, &ecKey.PublicKey), ecKey},
+		"PKCS#8 Ed25519": {encodePKCS8(t, edKey), encodePKIX(t, edKey.Public()), edKey},
+	} {
+		private, err := authentication.ParsePrivateKeyPEM([]byte(tc.private))
+		require.NoError(t, err, name)
+		assert.True(t, tc.key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(private.Public()), name)
+
+		public, err := authentication.ParsePublicKeyPEM([]byte(tc.public))
+		require.NoError(t, err, name)
+		assert.True(t, tc.key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(public), name)
+	}
+
+	_, err = authentication.ParsePrivateKeyPEM([]byte("not a key"))
+	assert.Error(t, err)
+	_, err = authentication.ParsePrivateKeyPEM([]byte(encodePKIX(t, &rsaKey.PublicKey)))
+	assert.Error(t, err)
+}
+
+func TestCheckKeyAlgorithm(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, algorithm := range []string{"RS256", "RS384", "RS512", "PS256"} {
+		assert.NoError(t, authentication.CheckKeyAlgorithm(algorithm, &rsaKey.PublicKey), algorithm)
+	}
+	assert.NoError(t, authentication.CheckKeyAlgorithm("ES256", &p256.PublicKey))
+	assert.NoError(t, authentication.CheckKeyAlgorithm("ES384", &p384.PublicKey))
+	assert.NoError(t, authentication.CheckKeyAlgorithm("EdDSA", edPublic))
+
+	assert.Error(t, authentication.CheckKeyAlgorithm("ES256", &rsaKey.PublicKey))
+	assert.Error(t, authentication.CheckKeyAlgorithm("ES256", &p384.PublicKey))
+	assert.Error(t, authentication.CheckKeyAlgorithm("RS256", &p256.PublicKey))
+	assert.Error(t, authentication.CheckKeyAlgorithm("RS256", edPublic))
+	assert.Error(t, authentication.CheckKeyAlgorithm("HS256", &rsaKey.PublicKey))
+	assert.Error(t, authentication.CheckKeyAlgorithm("none", &rsaKey.PublicKey))
+}
+
+func TestJWTAlgorithms(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for algorithm, key := range map[string]crypto.Signer{"ES256": p256, "EdDSA": edKey, "PS256": rsaKey} {
+		keys, err := authentication.NewStaticKeyManager(encodePKCS8(t, key), encodePKIX(t, key.Public()), algorithm)
+		require.NoError(t, err, algorithm)
+		jwtService := authentication.NewJWTService(&authentication.JWTConfig{Keys: keys, ExpirationTime: 5})
+
+		issued := time.Now().Truncate(time.Millisecond)
+		token, err := jwtService.GenerateToken(authentication.JWTClaims{"sub": "user-1"})
+		require.NoError(t, err, algorithm)
+		claims, err := jwtService.ParseToken(token)
+		require.NoError(t, err, algorithm)
+		assert.Equal(t, "user-1", claims.String("sub"))
+		assert.WithinDuration(t, issued, claims.Time(authentication.ClaimIssuedAt), time.Second)
+		assert.False(t, claims.Time(authentication.ClaimIssuedAt).Before(issued), algorithm)
+
+		jwks := keys.JWKS()
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, algorithm, jwks.Keys[0].Alg)
+	}
+
+	// a key is rejected with another algorithm than the configured one
+	keys, err := authentication.NewStaticKeyManager(encodePKCS8(t, rsaKey), "", "RS384")
+	require.NoError(t, err)
+	signing, err := keys.SigningKey()
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user-1"})
+	token.Header["kid"] = signing.ID
+	signed, err := token.SignedString(rsaKey)
+	require.NoError(t, err)
+	assert.Error(t, authentication.NewJWTService(&authentication.JWTConfig{Keys: keys}).ValidateToken(signed))
+
+	// a key that does not match the algorithm is reported
+	_, err = authentication.NewStaticKeyManager(encodePKCS8(t, rsaKey), "", "ES256")
+	assert.Error(t, err)
+	_, err = authentication.NewStaticKeyManager(encodePKCS8(t, rsaKey), encodePKIX(t, p256.Public()), "RS256")
+	assert.Error(t, err)
+}
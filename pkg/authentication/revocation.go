@@ -0,0 +1,155 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/redis"
+)
+
+type IRevocationService interface {
+	// RevokeToken denies a token until it expires.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - tokenID: jti of the token
+	//   - expiresAt: expiry of the token
+	//
+	// Returns:
+	//   - error: error
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeUser denies every token of the user issued up to now, tokens issued later are
+	// accepted.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - userID: user ID
+	//
+	// Returns:
+	//   - error: error
+	RevokeUser(ctx context.Context, userID string) error
+
+	// RevokeFamily denies every token of the token family, the tokens of a login and of its
+	// refreshes.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - family: token family
+	//
+	// Returns:
+	//   - error: error
+	RevokeFamily(ctx context.Context, family string) error
+
+	// IsRevoked checks the jti of the token, the revocation of its family and of its user in one
+	// round trip.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - claims: JWT claims
+	//
+	// Returns:
+	//   - bool: true if the token is revoked
+	//   - error: error if the revocations could not be read
+	IsRevoked(ctx context.Context, claims JWTClaims) (bool, error)
+}
+
+type RevocationConfig struct {
+	// Redis storing the revocations
+	Redis redis.IRedisService
+
+	// TokenLifetime is the lifetime of the longest lived token, the revocation of a user is kept
+	// as long
+	TokenLifetime time.Duration
+}
+
+type revocation struct {
+	redis         redis.IRedisService
+	tokenLifetime time.Duration
+}
+
+func NewRevocationService(config *RevocationConfig) IRevocationService {
+	return &revocation{
+		redis:         config.Redis,
+		tokenLifetime: config.TokenLifetime,
+	}
+}
+
+func revokedTokenKey(tokenID string) string {
+	return "auth:revoked:token:" + tokenID
+}
+
+func revokedFamilyKey(family string) string {
+	return "auth:revoked:family:" + family
+}
+
+// revokedUserKey holds the unix time in milliseconds up to which the tokens of the user are revoked.
+func revokedUserKey(userID string) string {
+	return "auth:revoked:user:" + userID
+}
+
+// secondsBefore is a unix time in milliseconds no revocation in seconds reaches, year 2001 in
+// milliseconds is year 33658 in seconds.
+const secondsBefore = 1_000_000_000_000
+
+func (r *revocation) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return errors.New("token has no jti")
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// expired tokens are rejected anyway
+		return nil
+	}
+	return r.redis.Set(ctx, revokedTokenKey(tokenID), 1, ttl)
+}
+
+func (r *revocation) RevokeUser(ctx context.Context, userID string) error {
+	return r.redis.Set(ctx, revokedUserKey(userID), time.Now().UnixMilli(), r.tokenLifetime)
+}
+
+func (r *revocation) RevokeFamily(ctx context.Context, family string) error {
+	if family == "" {
+		return errors.New("token has no family")
+	}
+	return r.redis.Set(ctx, revokedFamilyKey(family), 1, r.tokenLifetime)
+}
+
+func (r *revocation) IsRevoked(ctx context.Context, claims JWTClaims) (bool, error) {
+	keys := []string{
+		revokedTokenKey(claims.String(ClaimTokenID)),
+		revokedUserKey(claims.String("userId")),
+	}
+	family := claims.String(ClaimFamily)
+	if family != "" {
+		keys = append(keys, revokedFamilyKey(family))
+	}
+
+	values, err := r.redis.MGet(ctx, keys)
+	if err != nil {
+		return false, err
+	}
+
+	if values[0] != nil && claims.String(ClaimTokenID) != "" {
+		return true, nil
+	}
+	if family != "" && values[2] != nil {
+		return true, nil
+	}
+
+	if notBefore, ok := values[1].(string); ok {
+		revokedAt, err := strconv.ParseInt(notBefore, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		// revocations stored before they kept the milliseconds hold seconds
+		if revokedAt < secondsBefore {
+			revokedAt *= 1000
+		}
+		return claims.Time(ClaimIssuedAt).UnixMilli() <= revokedAt, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package authentication_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var revocationKeys = []string{"auth:revoked:token:token-1", "auth:revoked:user:user-1"}
+
+func newRevocation(t *testing.T) (authentication.IRevocationService, *mocks.MockIRedisService) {
+	redis := mocks.NewMockIRedisService(t)
+	return authentication.NewRevocationService(&authentication.RevocationConfig{
+		Redis:         redis,
+		TokenLifetime: time.Hour,
+	}), redis
+}
+
+func claimsIssuedAt(iat time.Time) authentication.JWTClaims {
+	return authentication.JWTClaims{
+		authentication.ClaimTokenID:  "token-1",
+		authentication.ClaimIssuedAt: float64(iat.UnixMilli()) / 1000,
+		"userId":                     "user-1",
+	}
+}
+
+func TestRevocation_IsRevoked(t *testing.T) {
+	now := time.Now()
+	revokedAt := strconv.FormatInt(now.UnixMilli(), 10)
+	revokedAtSeconds := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name    string
+		values  []interface{}
+		iat     time.Time
+		revoked bool
+	}{
+		{name: "not revoked", values: []interface{}{nil, nil}, iat: now, revoked: false},
+		{name: "token revoked", values: []interface{}{"1", nil}, iat: now, revoked: true},
+		{name: "issued before user revocation", values: []interface{}{nil, revokedAt}, iat: now.Add(-time.Minute), revoked: true},
+		{name: "issued at user revocation", values: []interface{}{nil, revokedAt}, iat: now, revoked: true},
+		{name: "issued right after user revocation", values: []interface{}{nil, revokedAt}, iat: now.Add(5 * time.Millisecond), revoked: false},
+		{name: "issued after user revocation", values: []interface{}{nil, revokedAt}, iat: now.Add(time.Minute), revoked: false},
+		{name: "issued before user revocation in seconds", values: []interface{}{nil, revokedAtSeconds}, iat: now.Add(-time.Minute), revoked: true},
+		{name: "issued after user revocation in seconds", values: []interface{}{nil, revokedAtSeconds}, iat: now.Add(time.Minute), revoked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revocation, redis := newRevocation(t)
+			redis.EXPECT().MGet(mock.Anything, revocationKeys).Return(tt.values, nil)
+
+			revoked, err := revocation.IsRevoked(context.Background(), claimsIssuedAt(tt.iat))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.revoked, revoked)
+		})
+	}
+}
+
+func TestRevocation_IsRevokedRedisDown(t *testing.T) {
+	revocation, redis := newRevocation(t)
+	redis.EXPECT().MGet(mock.Anything, revocationKeys).Return(nil, errors.New("connection refused"))
+
+	_, err := revocation.IsRevoked(context.Background(), claimsIssuedAt(time.Now()))
+
+	assert.Error(t, err)
+}
+
+func TestRevocation_RevokeToken(t *testing.T) {
+	revocation, redis := newRevocation(t)
+	redis.EXPECT().
+		Set(mock.Anything, "auth:revoked:token:token-1", 1, mock.MatchedBy(func(ttl time.Duration) bool {
+			return ttl > 9*time.Minute && ttl <= 10*time.Minute
+		})).
+		Return(nil)
+
+	err := revocation.RevokeToken(context.Background(), "token-1", time.Now().Add(10*time.Minute))
+	assert.NoError(t, err)
+
+	// expired tokens need no entry
+	err = revocation.RevokeToken(context.Background(), "token-1", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+}
+
+func TestRevocation_IsRevokedFamily(t *testing.T) {
+	claims := claimsIssuedAt(time.Now())
+	claims[authentication.ClaimFamily] = "family-1"
+	keys := append(revocationKeys[:2:2], "auth:revoked:family:family-1")
+
+	revocation, redis := newRevocation(t)
+	redis.EXPECT().MGet(mock.Anything, keys).Return([]interface{}{nil, nil, nil}, nil).Once()
+	revoked, err := revocation.IsRevoked(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	redis.EXPECT().Set(mock.Anything, "auth:revoked:family:family-1", 1, time.Hour).Return(nil)
+	assert.NoError(t, revocation.RevokeFamily(context.Background(), "family-1"))
+
+	redis.EXPECT().MGet(mock.Anything, keys).Return([]interface{}{nil, nil, "1"}, nil).Once()
+	revoked, err = revocation.IsRevoked(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevocation_RevokeUserKeepsMilliseconds(t *testing.T) {
+	revocation, redis := newRevocation(t)
+	before := time.Now().UnixMilli()
+	redis.EXPECT().
+		Set(mock.Anything, "auth:revoked:user:user-1", mock.MatchedBy(func(revokedAt int64) bool {
+			return revokedAt >= before && revokedAt <= time.Now().UnixMilli()
+		}), time.Hour).
+		Return(nil)
+
+	assert.NoError(t, revocation.RevokeUser(context.Background(), "user-1"))
+}
@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/redis"
+)
+
+type ISessionService interface {
+	// Touch records the use of a session, at most once per interval, and reports whether the
+	// session is active. A session is read when its use is recorded, one recorded in the interval
+	// is reported as active unless verify is set. Revoked sessions are left as they are.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - family: token family of the session
+	//   - verify: read the session even if its use was recorded in the interval
+	//
+	// Returns:
+	//   - bool: false when the session is revoked or missing
+	//   - error: error
+	Touch(ctx context.Context, family string, verify bool) (bool, error)
+}
+
+type SessionConfig struct {
+	// DB storing the sessions
+	DB database.IDBService
+
+	// Redis remembering the sessions touched in the interval
+	Redis redis.IRedisService
+
+	// LastSeenInterval is the resolution of the last use of a session, it is written at most once
+	// per interval so a busy client does not write on every request
+	LastSeenInterval time.Duration
+}
+
+type sessions struct {
+	db               database.IDBService
+	redis            redis.IRedisService
+	lastSeenInterval time.Duration
+}
+
+func NewSessionService(config *SessionConfig) ISessionService {
+	return &sessions{
+		db:               config.DB,
+		redis:            config.Redis,
+		lastSeenInterval: config.LastSeenInterval,
+	}
+}
+
+// sessionSeenKey is set while the last use of the session is recent enough.
+func sessionSeenKey(family string) string {
+	return "auth:session:seen:" + family
+}
+
+func (s *sessions) Touch(ctx context.Context, family string, verify bool) (bool, error) {
+	if family == "" {
+		return !verify, nil
+	}
+	if !verify {
+		if s.lastSeenInterval <= 0 {
+			return true, nil
+		}
+		// when Redis cannot tell whether the use was recorded in the interval it is recorded again
+		first, err := s.redis.SetNX(ctx, sessionSeenKey(family), 1, s.lastSeenInterval)
+		if err == nil && !first {
+			return true, nil
+		}
+	}
+
+	result := s.db.GetTransaction(ctx).Model(&model.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", family).
+		UpdateColumn("last_seen_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
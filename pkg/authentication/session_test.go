@@ -0,0 +1,74 @@
+package authentication_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var touchSession = regexp.QuoteMeta(`UPDATE "user_sessions" SET "last_seen_at"=$1 WHERE id = $2 AND revoked_at IS NULL`)
+
+func newSessions(t *testing.T) (authentication.ISessionService, *mocks.MockIRedisService, sqlmock.Sqlmock) {
+	conn, db, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+
+	redis := mocks.NewMockIRedisService(t)
+	return authentication.NewSessionService(&authentication.SessionConfig{
+		DB:               &database.DBService{Gorm: gormDB},
+		Redis:            redis,
+		LastSeenInterval: time.Minute,
+	}), redis, db
+}
+
+func TestSessions_Touch(t *testing.T) {
+	tests := []struct {
+		name   string
+		verify bool
+		seen   bool
+		redis  error
+		rows   int64
+		read   bool
+		active bool
+	}{
+		{name: "seen in the interval", seen: true, active: true},
+		{name: "recorded", rows: 1, read: true, active: true},
+		{name: "revoked", rows: 0, read: true, active: false},
+		{name: "redis down reads the session", redis: errors.New("connection refused"), rows: 0, read: true, active: false},
+		{name: "verify reads a seen session", verify: true, rows: 0, read: true, active: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions, redis, db := newSessions(t)
+			if !tt.verify {
+				redis.EXPECT().SetNX(context.Background(), "auth:session:seen:family-1", 1, time.Minute).Return(!tt.seen, tt.redis)
+			}
+			if tt.read {
+				db.ExpectBegin()
+				db.ExpectExec(touchSession).WithArgs(sqlmock.AnyArg(), "family-1").WillReturnResult(sqlmock.NewResult(0, tt.rows))
+				db.ExpectCommit()
+			}
+
+			active, err := sessions.Touch(context.Background(), "family-1", tt.verify)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.active, active)
+			assert.NoError(t, db.ExpectationsWereMet())
+		})
+	}
+}
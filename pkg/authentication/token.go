@@ -0,0 +1,101 @@
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateOpaqueToken generates a random token to hand to a user, such as a password reset token.
+// Only the hash is meant to be stored.
+//
+// Returns:
+//   - string: token
+//   - string: hash of the token
+//   - error: error
+func GenerateOpaqueToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken returns the hash a token from GenerateOpaqueToken is stored as.
+//
+// Parameters:
+//   - token: token
+//
+// Returns:
+//   - string: hex encoded SHA-256 of the token
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrInvalidSignedToken is returned by VerifySignedToken for forged, malformed and expired tokens.
+var ErrInvalidSignedToken = errors.New("invalid or expired token")
+
+// SignToken returns a URL safe token carrying the payload until it expires, authenticated with
+// the HMAC-SHA256 of the secret. The payload is readable by the holder of the token.
+//
+// Parameters:
+//   - secret: signing secret
+//   - payload: payload
+//   - expiresAt: expiry of the token
+//
+// Returns:
+//   - string: token in the form <payload>.<expiry>.<signature>
+func SignToken(secret string, payload string, expiresAt time.Time) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return unsigned + "." + signToken(secret, unsigned)
+}
+
+// VerifySignedToken returns the payload of a token from SignToken.
+//
+// Parameters:
+//   - secret: signing secret
+//   - token: token
+//   - now: time the expiry is checked against
+//
+// Returns:
+//   - string: payload
+//   - error: ErrInvalidSignedToken if the token is forged, malformed or expired
+func VerifySignedToken(secret string, token string, now time.Time) (string, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", ErrInvalidSignedToken
+	}
+	unsigned, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(signToken(secret, unsigned))) {
+		return "", ErrInvalidSignedToken
+	}
+
+	encoded, expiry, ok := strings.Cut(unsigned, ".")
+	if !ok {
+		return "", ErrInvalidSignedToken
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return "", ErrInvalidSignedToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidSignedToken
+	}
+	return string(payload), nil
+}
+
+func signToken(secret string, unsigned string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,54 @@
+package authentication_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedToken(t *testing.T) {
+	now := time.Now()
+	token := authentication.SignToken("secret", "user-1\nuser@example.com", now.Add(time.Hour))
+
+	payload, err := authentication.VerifySignedToken("secret", token, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1\nuser@example.com", payload)
+
+	_, err = authentication.VerifySignedToken("other", token, now)
+	assert.ErrorIs(t, err, authentication.ErrInvalidSignedToken)
+
+	_, err = authentication.VerifySignedToken("secret", token, now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, authentication.ErrInvalidSignedToken)
+
+	// a longer expiry invalidates the signature
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + "9999999999" + "." + parts[2]
+	_, err = authentication.VerifySignedToken("secret", forged, now)
+	assert.ErrorIs(t, err, authentication.ErrInvalidSignedToken)
+
+	_, err = authentication.VerifySignedToken("secret", "garbage", now)
+	assert.ErrorIs(t, err, authentication.ErrInvalidSignedToken)
+}
+
+func TestOpaqueToken(t *testing.T) {
+	token, hash, err := authentication.GenerateOpaqueToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, hash)
+	assert.Equal(t, hash, authentication.HashOpaqueToken(token))
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := authentication.GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.True(t, strings.HasPrefix(prefix, authentication.APIKeyPrefix))
+	assert.LessOrEqual(t, len(prefix), 16)
+	assert.Equal(t, hash, authentication.HashOpaqueToken(key))
+
+	other, _, _, err := authentication.GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
@@ -0,0 +1,145 @@
+package authentication
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// TOTPPeriod is the lifetime of a TOTP code, the default of authenticator apps.
+	TOTPPeriod = 30 * time.Second
+
+	// TOTPSkew is the number of periods a code is accepted before and after the current one, to
+	// allow for clock drift.
+	TOTPSkew = 1
+
+	totpQRCodeSize = 256
+)
+
+// TOTPKey is a TOTP secret generated for enrollment.
+type TOTPKey struct {
+	// Secret is the base32 encoded secret
+	Secret string
+
+	// URI is the otpauth URI of the secret
+	URI string
+
+	// QRCode is a PNG image of the URI, to scan with an authenticator app
+	QRCode []byte
+}
+
+// GenerateTOTPKey generates a TOTP secret for an account.
+//
+// Parameters:
+//   - issuer: issuer shown by the authenticator app
+//   - account: account shown by the authenticator app
+//
+// Returns:
+//   - *TOTPKey: TOTP secret, its URI and QR code
+//   - error: error
+func GenerateTOTPKey(issuer string, account string) (*TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: account,
+		Period:      uint(TOTPPeriod / time.Second),
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return &TOTPKey{Secret: key.Secret(), URI: key.URL(), QRCode: buf.Bytes()}, nil
+}
+
+// ValidateTOTP checks a TOTP code at a time, within TOTPSkew periods. The counter of the matching
+// period is returned so the caller can refuse a code that was already used.
+//
+// Parameters:
+//   - code: TOTP code
+//   - secret: base32 encoded secret
+//   - t: time
+//
+// Returns:
+//   - int64: counter of the period of the code
+//   - bool: whether the code is valid
+func ValidateTOTP(code string, secret string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	opts := totp.ValidateOpts{
+		Period:    uint(TOTPPeriod / time.Second),
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+
+	for skew := -TOTPSkew; skew <= TOTPSkew; skew++ {
+		at := t.Add(time.Duration(skew) * TOTPPeriod)
+		expected, err := totp.GenerateCodeCustom(secret, at, opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / int64(TOTPPeriod/time.Second), true
+		}
+	}
+	return 0, false
+}
+
+// recoveryCodeEncoding writes recovery codes without padding and in lower case, the characters are
+// easy to tell apart when typed.
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateRecoveryCodes generates single-use recovery codes. Only the hashes are meant to be
+// stored.
+//
+// Parameters:
+//   - n: number of codes
+//
+// Returns:
+//   - []string: recovery codes in the form xxxxx-xxxxx
+//   - []string: hashes of the codes
+//   - error: error
+func GenerateRecoveryCodes(n int) ([]string, []string, error) {
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b)[:10])
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored as. The case and separators of the
+// code do not matter.
+//
+// Parameters:
+//   - code: recovery code
+//
+// Returns:
+//   - string: hash of the code
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashOpaqueToken(code)
+}
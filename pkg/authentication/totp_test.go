@@ -0,0 +1,59 @@
+package authentication_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTOTPKey(t *testing.T) {
+	key, err := authentication.GenerateTOTPKey("go-codebase", "alice")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key.Secret)
+	assert.True(t, strings.HasPrefix(key.URI, "otpauth://totp/go-codebase:alice?"))
+
+	_, err = png.Decode(bytes.NewReader(key.QRCode))
+	assert.NoError(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	key, err := authentication.GenerateTOTPKey("go-codebase", "alice")
+	assert.NoError(t, err)
+
+	now := time.Now()
+	code, err := totp.GenerateCode(key.Secret, now)
+	assert.NoError(t, err)
+
+	counter, valid := authentication.ValidateTOTP(code, key.Secret, now)
+	assert.True(t, valid)
+	assert.Equal(t, now.Unix()/30, counter)
+
+	// a code of the previous period is accepted within the skew
+	_, valid = authentication.ValidateTOTP(code, key.Secret, now.Add(authentication.TOTPPeriod))
+	assert.True(t, valid)
+
+	_, valid = authentication.ValidateTOTP(code, key.Secret, now.Add(3*authentication.TOTPPeriod))
+	assert.False(t, valid)
+
+	_, valid = authentication.ValidateTOTP("000000x", key.Secret, now)
+	assert.False(t, valid)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := authentication.GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Len(t, hashes, 10)
+
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, hashes[i], authentication.HashRecoveryCode(code))
+		assert.Equal(t, hashes[i], authentication.HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+}
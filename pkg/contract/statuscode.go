@@ -5,6 +5,7 @@ const (
 	ErrorValidatePayload       = "Failed to validate payload"
 	ErrorMutatePayload         = "Failed to mutate payload"
 	ErrorInsufficientPrivilege = "User does not have privilege to perform this action"
+	ErrorEmailNotVerified      = "Email address of the user is not verified"
 
 	// Common error message database
 	ErrorFailedToFindRecord   = "Failed to find record"
@@ -25,6 +26,11 @@ const (
 	StatusCodeUserOrPasswordInvalid = StatusCode("000012")
 	StatusCodeInternalServerError   = StatusCode("000013")
 	StatusCodeSequenceError         = StatusCode("000014")
+	StatusCodeForbidden             = StatusCode("000015")
+	StatusCodeConflict              = StatusCode("000016")
+	StatusCodeInvalidToken          = StatusCode("000017")
+	StatusCodeTooManyRequests       = StatusCode("000018")
+	StatusCodeEmailNotVerified      = StatusCode("000019")
 )
 
 func CreateStatusCode(code string) StatusCode {
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"math"
+	"strings"
 	"time"
 
 	"github.com/Alwanly/go-codebase/model"
@@ -116,6 +117,12 @@ func (db *DBService) BeginTransaction(ctx context.Context) (context.Context, *go
 	return ctx, tx.(*gorm.DB)
 }
 
+func (db *DBService) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.GetTransaction(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, TransactionContextKey, tx))
+	})
+}
+
 func (db *DBService) SetUpdateLockType(ctx context.Context) context.Context {
 	return context.WithValue(ctx, TransactionLockTypeContextKey, PostgresLockTypeForUpdate)
 }
@@ -172,9 +179,51 @@ func (db *DBService) Close() error {
 
 func MigrateIfNeed(db *gorm.DB) error {
 	log.Println("Running database migration if necessary...")
-	err := db.AutoMigrate(&model.Book{})
-	if err != nil {
+
+	// pg_trgm powers duplicate detection on books
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
 		return err
 	}
-	return nil
+
+	// the schema changes and the backfill they need are applied together, so a failed backfill
+	// runs again at the next start
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.AutoMigrate(&model.Book{}, &model.BookMerge{}, &model.BookTransition{}, &model.ReadingProgress{},
+			&model.WebhookSubscription{}, &model.WebhookDelivery{}, &model.OutboxEvent{})
+		if err != nil {
+			return err
+		}
+
+		for _, column := range []string{"normalized_title", "normalized_author"} {
+			index := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_books_%s_trgm ON books USING gin (%s gin_trgm_ops)", column, column)
+			if err := tx.Exec(index).Error; err != nil {
+				return err
+			}
+		}
+
+		return backfillNormalizedBooks(tx)
+	})
+}
+
+// backfillNormalizedBooksBatchSize is the number of books normalized per statement.
+const backfillNormalizedBooksBatchSize = 500
+
+// backfillNormalizedBooks fills the normalized columns of the books created before they existed.
+// The columns are added by the SQL migrations or by AutoMigrate, so the books still missing them
+// are looked up rather than the columns.
+func backfillNormalizedBooks(tx *gorm.DB) error {
+	var books []model.Book
+	missing := tx.Select("id", "title", "author").Where("normalized_title = '' AND normalized_author = ''")
+	return missing.FindInBatches(&books, backfillNormalizedBooksBatchSize, func(_ *gorm.DB, _ int) error {
+		values := make([]string, len(books))
+		args := make([]interface{}, 0, len(books)*3)
+		for i, book := range books {
+			values[i] = "(?, ?, ?)"
+			args = append(args, book.ID, utils.NormalizeTitle(book.Title), utils.NormalizeAuthor(book.Author))
+		}
+
+		return tx.Exec(`UPDATE books SET normalized_title = v.title, normalized_author = v.author
+			FROM (VALUES `+strings.Join(values, ", ")+`) AS v (id, title, author)
+			WHERE books.id = v.id`, args...).Error
+	}).Error
 }
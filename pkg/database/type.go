@@ -89,6 +89,18 @@ type IDBService interface {
 	//   - *gorm.DB: transaction
 	CommitTransaction(c context.Context) *gorm.DB
 
+	// RunInTransaction runs fn in a transaction attached to the context given to fn, every
+	// GetTransaction call with that context joins it. It commits when fn returns nil and rolls back
+	// otherwise, a transaction already attached to c is joined with a savepoint.
+	//
+	// Parameters:
+	//   - c: context
+	//   - fn: function to run
+	//
+	// Returns:
+	//   - error: error returned by fn or by the commit
+	RunInTransaction(c context.Context, fn func(ctx context.Context) error) error
+
 	// SetUpdateLockType sets the lock type to UPDATE.
 	//
 	// Parameters:
@@ -0,0 +1,91 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc fetches the values of many keys at once, keys without a value are left out of the map.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+// Loader batches and caches the loads of a single request. Load only queues the key and returns a
+// thunk, the first thunk called fetches every queued key in one batch. Resolvers returning the
+// thunks, as graphql-go resolves them after the whole level, get one fetch per level instead of one
+// per item.
+type Loader[K comparable, V any] struct {
+	fetch   BatchFunc[K, V]
+	maxSize int
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]result[V]
+}
+
+// New creates a loader fetching at most maxSize keys per batch, zero means no limit.
+func New[K comparable, V any](fetch BatchFunc[K, V], maxSize int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		maxSize: maxSize,
+		results: map[K]result[V]{},
+	}
+}
+
+// Load queues the key and returns a thunk resolving its value, found is false when the batch did
+// not return the key.
+//
+// Parameters:
+//   - ctx: context used by the batch fetch
+//   - key: key
+//
+// Returns:
+//   - func() (V, bool, error): thunk
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, bool, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok && !l.isPending(key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.results[key]; !ok {
+			l.dispatch(ctx)
+		}
+		r := l.results[key]
+		return r.value, r.found, r.err
+	}
+}
+
+// dispatch fetches every pending key, the caller holds the lock.
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	for len(l.pending) > 0 {
+		batch := l.pending
+		if l.maxSize > 0 && len(batch) > l.maxSize {
+			batch = batch[:l.maxSize]
+		}
+		l.pending = l.pending[len(batch):]
+
+		values, err := l.fetch(ctx, batch)
+		for _, key := range batch {
+			value, found := values[key]
+			l.results[key] = result[V]{value: value, found: found, err: err}
+		}
+	}
+}
+
+func (l *Loader[K, V]) isPending(key K) bool {
+	for _, k := range l.pending {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
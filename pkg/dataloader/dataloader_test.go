@@ -0,0 +1,79 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/dataloader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader_BatchesQueuedKeys(t *testing.T) {
+	batches := [][]string{}
+	loader := dataloader.New(func(_ context.Context, keys []string) (map[string]int, error) {
+		batches = append(batches, keys)
+		values := map[string]int{}
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = len(key)
+			}
+		}
+		return values, nil
+	}, 0)
+
+	ctx := context.Background()
+	a := loader.Load(ctx, "a")
+	bb := loader.Load(ctx, "bb")
+	again := loader.Load(ctx, "a")
+	missing := loader.Load(ctx, "missing")
+
+	value, found, err := bb()
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, value)
+
+	value, found, _ = a()
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	value, _, _ = again()
+	assert.Equal(t, 1, value)
+
+	_, found, err = missing()
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.Equal(t, [][]string{{"a", "bb", "missing"}}, batches)
+
+	// cached keys are not fetched again
+	value, _, _ = loader.Load(ctx, "a")()
+	assert.Equal(t, 1, value)
+	assert.Len(t, batches, 1)
+}
+
+func TestLoader_MaxBatchSize(t *testing.T) {
+	sizes := []int{}
+	loader := dataloader.New(func(_ context.Context, keys []int) (map[int]int, error) {
+		sizes = append(sizes, len(keys))
+		return map[int]int{}, nil
+	}, 2)
+
+	thunks := []func() (int, bool, error){}
+	for i := 0; i < 5; i++ {
+		thunks = append(thunks, loader.Load(context.Background(), i))
+	}
+	thunks[0]()
+
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+}
+
+func TestLoader_Error(t *testing.T) {
+	loader := dataloader.New(func(context.Context, []string) (map[string]int, error) {
+		return nil, errors.New("boom")
+	}, 0)
+
+	_, found, err := loader.Load(context.Background(), "a")()
+	assert.EqualError(t, err, "boom")
+	assert.False(t, found)
+}
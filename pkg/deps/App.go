@@ -1,9 +1,16 @@
 package deps
 
 import (
+	"context"
+
 	"github.com/Alwanly/go-codebase/config"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
 	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/grpcserver"
 	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/Alwanly/go-codebase/pkg/outbox"
 	"github.com/Alwanly/go-codebase/pkg/redis"
 	"github.com/Alwanly/go-codebase/pkg/validator"
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +25,22 @@ type App struct {
 	Auth      *middleware.AuthMiddleware
 	Validator validator.IValidatorService
 
+	// Outbox stores domain events in the transaction of the change
+	Outbox outbox.IStore
+
+	// Webhooks dispatches events to the webhook subscriptions
+	Webhooks event.IDispatcher
+
+	// Notifier delivers messages to users, such as password reset tokens
+	Notifier notifier.INotifier
+
+	// OIDC signs users in at the external identity provider, nil when none is configured
+	OIDC authentication.IOIDCService
+
+	// Workers run next to the servers until the context is done
+	Workers []func(ctx context.Context) error
+
 	// APIs
 	Fiber *fiber.App
+	Grpc  *grpcserver.Server
 }
@@ -0,0 +1,39 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+)
+
+// Event is something that happened in the domain, published to downstream systems. The ID is kept
+// across redeliveries so consumers can drop duplicates.
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+type IDispatcher interface {
+	// Dispatch hands the event over for delivery, it returns once the event is safely accepted.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - evt: event
+	//
+	// Returns:
+	//   - error: error if the event was not accepted and must be dispatched again
+	Dispatch(ctx context.Context, evt Event) error
+}
+
+// New creates an event of the type with a new ID.
+func New(eventType string, data interface{}) Event {
+	return Event{
+		ID:         utils.GenerateUUID(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
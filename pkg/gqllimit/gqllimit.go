@@ -0,0 +1,185 @@
+package gqllimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	ErrOperationNotFound  = errors.New("operation not found")
+	ErrDepthExceeded      = errors.New("query depth exceeded")
+	ErrComplexityExceeded = errors.New("query complexity exceeded")
+)
+
+// SizeArguments are the arguments read as the number of items a list field returns.
+var SizeArguments = []string{"pageSize", "limit", "first"}
+
+type (
+	Opts struct {
+		// MaxDepth is the deepest nesting of fields allowed, zero means no limit.
+		MaxDepth int
+		// MaxComplexity is the highest cost allowed, zero means no limit.
+		MaxComplexity int
+		// ListFields maps the name of the fields returning a list to the size assumed when the
+		// query does not set one of the SizeArguments.
+		ListFields map[string]int
+	}
+
+	// Result is the depth and cost of an operation. Every field costs one, the cost of the fields
+	// selected under a list is multiplied by its size.
+	Result struct {
+		Depth      int
+		Complexity int
+	}
+)
+
+type analyzer struct {
+	opts      Opts
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// Analyze computes the depth and complexity of the operation. The document must have been
+// validated, as fragment cycles are only skipped and not reported.
+//
+// Parameters:
+//   - doc: parsed query
+//   - operationName: operation to analyze, may be empty when the document has one operation
+//   - variables: variables of the request
+//   - opts: list fields and their default size
+//
+// Returns:
+//   - Result: depth and complexity
+//   - error: error if the operation is not found
+func Analyze(doc *ast.Document, operationName string, variables map[string]interface{}, opts Opts) (Result, error) {
+	a := &analyzer{
+		opts:      opts,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+	}
+
+	var operation *ast.OperationDefinition
+	operations := 0
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			operations++
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operation = d
+			}
+		}
+	}
+	if operation == nil || (operationName == "" && operations > 1) {
+		return Result{}, ErrOperationNotFound
+	}
+
+	depth, complexity := a.selectionSet(operation.SelectionSet, map[string]bool{})
+	return Result{Depth: depth, Complexity: complexity}, nil
+}
+
+// Check analyzes the operation and returns an error if it goes over the limits of the options.
+//
+// Parameters:
+//   - doc: parsed and validated query
+//   - operationName: operation to check
+//   - variables: variables of the request
+//   - opts: limits
+//
+// Returns:
+//   - error: error if the operation is not found or is over a limit
+func Check(doc *ast.Document, operationName string, variables map[string]interface{}, opts Opts) error {
+	result, err := Analyze(doc, operationName, variables, opts)
+	if err != nil {
+		return err
+	}
+	if opts.MaxDepth > 0 && result.Depth > opts.MaxDepth {
+		return fmt.Errorf("%w: %d, max %d", ErrDepthExceeded, result.Depth, opts.MaxDepth)
+	}
+	if opts.MaxComplexity > 0 && result.Complexity > opts.MaxComplexity {
+		return fmt.Errorf("%w: %d, max %d", ErrComplexityExceeded, result.Complexity, opts.MaxComplexity)
+	}
+	return nil
+}
+
+// selectionSet returns the depth and cost of the selections, visiting holds the fragments being
+// expanded to stop at cycles.
+func (a *analyzer) selectionSet(set *ast.SelectionSet, visiting map[string]bool) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	depth, complexity := 0, 0
+	for _, selection := range set.Selections {
+		d, c := 0, 0
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = a.field(s, visiting)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			d, c = a.selectionSet(fragment.SelectionSet, visiting)
+			delete(visiting, name)
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (a *analyzer) field(field *ast.Field, visiting map[string]bool) (int, int) {
+	// introspection is not charged, its shape is bounded by the schema
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	depth, complexity := a.selectionSet(field.SelectionSet, visiting)
+	return depth + 1, 1 + complexity*a.size(field)
+}
+
+// size returns how many items the field is expected to return.
+func (a *analyzer) size(field *ast.Field) int {
+	defaultSize, ok := a.opts.ListFields[field.Name.Value]
+	if !ok {
+		return 1
+	}
+
+	for _, argument := range field.Arguments {
+		for _, name := range SizeArguments {
+			if argument.Name.Value != name {
+				continue
+			}
+			if size, ok := a.intValue(argument.Value); ok && size > 0 {
+				return size
+			}
+		}
+	}
+	return max(defaultSize, 1)
+}
+
+func (a *analyzer) intValue(value ast.Value) (int, bool) {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		i, err := strconv.Atoi(v.Value)
+		return i, err == nil
+	case *ast.Variable:
+		switch i := a.variables[v.Name.Value].(type) {
+		case int:
+			return i, true
+		case float64:
+			return int(i), true
+		}
+	}
+	return 0, false
+}
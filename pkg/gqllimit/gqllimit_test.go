@@ -0,0 +1,95 @@
+package gqllimit_test
+
+import (
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/gqllimit"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var opts = gqllimit.Opts{ListFields: map[string]int{"books": 10}}
+
+func parse(t *testing.T, query string) *ast.Document {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestAnalyze_Nested(t *testing.T) {
+	doc := parse(t, `{ book(id: "1") { id title creator { id username } } }`)
+
+	result, err := gqllimit.Analyze(doc, "", nil, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Depth)
+	assert.Equal(t, 6, result.Complexity)
+}
+
+func TestAnalyze_ListSize(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		expected  int
+	}{
+		{"default size", `{ books { id } }`, nil, 11},
+		{"argument", `{ books(pageSize: 3) { id } }`, nil, 4},
+		{"variable", `query ($size: Int) { books(pageSize: $size) { id } }`, map[string]interface{}{"size": float64(5)}, 6},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := gqllimit.Analyze(parse(t, c.query), "", c.variables, opts)
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, result.Complexity)
+		})
+	}
+}
+
+func TestAnalyze_Fragments(t *testing.T) {
+	doc := parse(t, `
+		query Book { book(id: "1") { ...BookFields ... on Book { creator { id } } } }
+		fragment BookFields on Book { id title }
+	`)
+
+	result, err := gqllimit.Analyze(doc, "Book", nil, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Depth)
+	assert.Equal(t, 5, result.Complexity)
+}
+
+func TestAnalyze_SkipsIntrospection(t *testing.T) {
+	doc := parse(t, `{ __schema { types { name fields { name } } } me { id } }`)
+
+	result, err := gqllimit.Analyze(doc, "", nil, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Depth)
+	assert.Equal(t, 2, result.Complexity)
+}
+
+func TestAnalyze_OperationNotFound(t *testing.T) {
+	doc := parse(t, `query A { me { id } } query B { me { id } }`)
+
+	_, err := gqllimit.Analyze(doc, "", nil, opts)
+	assert.ErrorIs(t, err, gqllimit.ErrOperationNotFound)
+
+	_, err = gqllimit.Analyze(doc, "C", nil, opts)
+	assert.ErrorIs(t, err, gqllimit.ErrOperationNotFound)
+}
+
+func TestCheck(t *testing.T) {
+	doc := parse(t, `{ books(pageSize: 100) { id title creator { id } } }`)
+
+	err := gqllimit.Check(doc, "", nil, gqllimit.Opts{MaxDepth: 2, ListFields: opts.ListFields})
+	assert.ErrorIs(t, err, gqllimit.ErrDepthExceeded)
+
+	err = gqllimit.Check(doc, "", nil, gqllimit.Opts{MaxComplexity: 100, ListFields: opts.ListFields})
+	assert.ErrorIs(t, err, gqllimit.ErrComplexityExceeded)
+
+	err = gqllimit.Check(doc, "", nil, gqllimit.Opts{MaxDepth: 3, MaxComplexity: 401, ListFields: opts.ListFields})
+	assert.NoError(t, err)
+}
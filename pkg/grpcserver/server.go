@@ -0,0 +1,117 @@
+package grpcserver
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	Opts struct {
+		Logger *zap.Logger
+		Auth   *middleware.AuthMiddleware
+	}
+
+	// Server is a gRPC server with the health and reflection services registered.
+	Server struct {
+		*grpc.Server
+		Health *health.Server
+
+		// AuthRules and Permissions are read by the auth interceptors on every call, handlers add
+		// the rules of their methods when they register their service.
+		AuthRules   middleware.GrpcAuthRules
+		Permissions middleware.GrpcPermissionRules
+	}
+)
+
+// New creates the server, recovering from panics and authenticating every call. The health and
+// reflection services are open, every other method requires a bearer token unless a rule says
+// otherwise.
+//
+// Parameters:
+//   - opts: options
+//
+// Returns:
+//   - *Server: server
+func New(opts Opts) *Server {
+	s := &Server{
+		Health: health.NewServer(),
+		AuthRules: middleware.GrpcAuthRules{
+			"/" + healthpb.Health_ServiceDesc.ServiceName + "/": middleware.GrpcAuthNone,
+			"/grpc.reflection.v1.ServerReflection/":             middleware.GrpcAuthNone,
+			"/grpc.reflection.v1alpha.ServerReflection/":        middleware.GrpcAuthNone,
+		},
+		Permissions: middleware.GrpcPermissionRules{},
+	}
+
+	s.Server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			unaryRecover(opts.Logger),
+			opts.Auth.UnaryGrpcAuth(s.AuthRules, s.Permissions),
+		),
+		grpc.ChainStreamInterceptor(
+			streamRecover(opts.Logger),
+			opts.Auth.StreamGrpcAuth(s.AuthRules, s.Permissions),
+		),
+	)
+	healthpb.RegisterHealthServer(s.Server, s.Health)
+	reflection.Register(s.Server)
+	return s
+}
+
+// Shutdown reports the server as not serving to health checks and stops it gracefully, calls
+// still running after the timeout are cancelled.
+//
+// Parameters:
+//   - timeout: time given to the running calls
+func (s *Server) Shutdown(timeout time.Duration) {
+	s.Health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		s.Stop()
+	}
+}
+
+func unaryRecover(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(l, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func streamRecover(l *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(l, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(l *zap.Logger, method string, p interface{}) error {
+	l.Error("Unexpected error", zap.Any("panic", p), zap.String("method", method), zap.ByteString("stack", debug.Stack()))
+	return status.Error(codes.Internal, "Internal server error")
+}
@@ -0,0 +1,106 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	bookv1 "github.com/Alwanly/go-codebase/api/proto/book/v1"
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/grpcserver"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type bookServer struct {
+	bookv1.UnimplementedBookServiceServer
+}
+
+func (bookServer) GetBook(ctx context.Context, req *bookv1.GetBookRequest) (*bookv1.Book, error) {
+	if req.GetId() == "panic" {
+		panic("boom")
+	}
+	user, _ := middleware.AuthUserFromContext(ctx)
+	return &bookv1.Book{Id: req.GetId(), CreatedBy: user.UserID}, nil
+}
+
+func newClient(t *testing.T, jwt authentication.IJwtService) *grpc.ClientConn {
+	server := grpcserver.New(grpcserver.Opts{
+		Logger: zap.NewNop(),
+		Auth:   &middleware.AuthMiddleware{Jwt: jwt},
+	})
+	bookv1.RegisterBookServiceServer(server, bookServer{})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { server.Shutdown(time.Second) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestServer_HealthIsOpen(t *testing.T) {
+	conn := newClient(t, mocks.NewMockIJwtService(t))
+
+	response, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+}
+
+func TestServer_RequiresBearerToken(t *testing.T) {
+	jwt := mocks.NewMockIJwtService(t)
+	jwt.EXPECT().ParseToken("valid").Return(&authentication.JWTClaims{"userId": "user-1", authentication.ClaimRoles: []any{"admin"}, authentication.ClaimPermissions: []any{}, authentication.ClaimType: authentication.TokenTypeAccess}, nil)
+	jwt.EXPECT().ParseToken("refresh").Return(&authentication.JWTClaims{"userId": "user-1", authentication.ClaimRoles: []any{"admin"}, authentication.ClaimPermissions: []any{}, authentication.ClaimType: authentication.TokenTypeRefresh}, nil)
+	jwt.EXPECT().ParseToken("invalid").Return(nil, errors.New("invalid"))
+	client := bookv1.NewBookServiceClient(newClient(t, jwt))
+
+	_, err := client.GetBook(context.Background(), &bookv1.GetBookRequest{Id: "1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetBook(ctx, &bookv1.GetBookRequest{Id: "1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer refresh")
+	_, err = client.GetBook(ctx, &bookv1.GetBookRequest{Id: "1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid")
+	book, err := client.GetBook(ctx, &bookv1.GetBookRequest{Id: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", book.GetCreatedBy())
+
+	_, err = client.GetBook(ctx, &bookv1.GetBookRequest{Id: "panic"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestError(t *testing.T) {
+	assert.NoError(t, grpcserver.Error(wrapper.ResponseSuccess(http.StatusNoContent, nil)))
+
+	err := grpcserver.Error(wrapper.ResponseFailed(http.StatusNotFound, contract.CreateStatusCode("0004"), "Book not found", nil))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Book not found", status.Convert(err).Message())
+
+	err = grpcserver.Error(wrapper.ResponseFailed(http.StatusTeapot, contract.StatusCodeInternalServerError, "teapot", nil))
+	assert.Equal(t, codes.Unknown, status.Code(err))
+}
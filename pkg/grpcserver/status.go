@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"net/http"
+
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ContextName = "GrpcServer"
+
+// httpCodes maps the HTTP status of the use case results to gRPC codes, any other error status
+// is mapped to Unknown.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// CodeFromHTTP returns the gRPC code of an HTTP status.
+//
+// Parameters:
+//   - code: HTTP status
+//
+// Returns:
+//   - codes.Code: gRPC code
+func CodeFromHTTP(code int) codes.Code {
+	if code < http.StatusBadRequest {
+		return codes.OK
+	}
+	if c, ok := httpCodes[code]; ok {
+		return c
+	}
+	return codes.Unknown
+}
+
+// Error turns a failed use case result into a gRPC status error, the status code of the result is
+// kept in the details.
+//
+// Parameters:
+//   - result: use case result
+//
+// Returns:
+//   - error: nil if the result is a success
+func Error(result wrapper.JSONResult) error {
+	code := CodeFromHTTP(result.Code)
+	if code == codes.OK {
+		return nil
+	}
+
+	st := status.New(code, result.Message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(result.StatusCode)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// ValidateModel validates the model as validator.ValidateModel does, failures are returned as an
+// InvalidArgument error with a violation per field.
+//
+// Parameters:
+//   - log: logger
+//   - v: validator
+//   - m: model
+//
+// Returns:
+//   - error: error if the model is invalid
+func ValidateModel(log *zap.Logger, v validator.IValidatorService, m interface{}) error {
+	l := logger.WithID(log, ContextName, "ValidateModel")
+
+	err := v.ValidateStruct(m)
+	if err == nil {
+		return nil
+	}
+	l.Error(contract.ErrorValidatePayload, zap.Error(err))
+
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	for _, e := range v.TranslateError(err) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.Field,
+			Description: e.Message,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, contract.ErrorValidatePayload)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
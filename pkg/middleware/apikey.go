@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/validator"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderAPIKey carries the API key of APIKeyAuth.
+const HeaderAPIKey = "X-API-Key"
+
+const LocalAPIKeyKey = "apiKey"
+
+// Scopes granted to API keys for the routes that accept them.
+const (
+	ScopeUserRegister = "user:register"
+)
+
+// APIKeyData is the client authenticated by APIKeyAuth.
+type APIKeyData struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the key was granted the scope.
+func (k *APIKeyData) HasScope(scope string) bool {
+	return utils.AnyInSlice(k.Scopes, scope)
+}
+
+// APIKeyAuth authenticates machine-to-machine clients with the API key of the X-API-Key header.
+// It can replace BasicAuth on a route, the key must be granted all the scopes.
+//
+// Parameters:
+//   - scopes: scopes the key requires
+//
+// Returns:
+//   - fiber.Handler: handler
+func (a *AuthMiddleware) APIKeyAuth(scopes ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := ctx.Get(HeaderAPIKey)
+		if key == "" {
+			return responseUnauthorized(ctx, "ApiKey", "Invalid API key")
+		}
+
+		apiKey, err := a.authenticateAPIKey(ctx.UserContext(), key)
+		if errors.Is(err, authentication.ErrInvalidAPIKey) {
+			return responseUnauthorized(ctx, "ApiKey", "Invalid API key")
+		}
+		if err != nil {
+			return ctx.Status(http.StatusServiceUnavailable).JSON(wrapper.ResponseFailed(
+				http.StatusServiceUnavailable, contract.StatusCodeInternalServerError, "API key check unavailable", nil))
+		}
+
+		for _, scope := range scopes {
+			if !apiKey.HasScope(scope) {
+				return responseForbidden(ctx)
+			}
+		}
+
+		// the key is the client of the request, as the client of BasicAuth
+		ctx.Locals(LocalAPIKeyKey, apiKey)
+		ctx.Locals(LocalClientKey, apiKey.ID)
+		ctx.SetUserContext(context.WithValue(ctx.UserContext(), ClientContextKey, apiKey.ID))
+		return ctx.Next()
+	}
+}
+
+// ClientAuth authenticates the client with APIKeyAuth when the request has an X-API-Key header
+// and with BasicAuth otherwise, for routes open to both kinds of clients.
+//
+// Parameters:
+//   - scopes: scopes the API key requires
+//
+// Returns:
+//   - fiber.Handler: handler
+func (a *AuthMiddleware) ClientAuth(scopes ...string) fiber.Handler {
+	apiKeyAuth := a.APIKeyAuth(scopes...)
+	basicAuth := a.BasicAuth()
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Get(HeaderAPIKey) != "" {
+			return apiKeyAuth(ctx)
+		}
+		return basicAuth(ctx)
+	}
+}
+
+func (a *AuthMiddleware) authenticateAPIKey(ctx context.Context, key string) (*APIKeyData, error) {
+	if a.APIKeys == nil {
+		return nil, authentication.ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.APIKeys.Authenticate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeyData{
+		ID:     apiKey.ID,
+		Name:   apiKey.Name,
+		Scopes: validator.SplitCSV(apiKey.Scopes),
+	}, nil
+}
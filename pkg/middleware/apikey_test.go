@@ -0,0 +1,155 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/authentication"
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type apiKeys map[string]*model.APIKey
+
+func (k apiKeys) Authenticate(_ context.Context, key string) (*model.APIKey, error) {
+	if key == "ak_down" {
+		return nil, errors.New("connection refused")
+	}
+	if apiKey, ok := k[key]; ok {
+		return apiKey, nil
+	}
+	return nil, authentication.ErrInvalidAPIKey
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	auth := &middleware.AuthMiddleware{APIKeys: apiKeys{
+		"ak_reader": {ID: "key-1", Name: "reader", Scopes: "book:read"},
+		"ak_writer": {ID: "key-2", Name: "writer", Scopes: "book:read,book:create"},
+	}}
+
+	tests := []struct {
+		name   string
+		key    string
+		status int
+	}{
+		{name: "missing", key: "", status: http.StatusUnauthorized},
+		{name: "unknown", key: "ak_unknown", status: http.StatusUnauthorized},
+		{name: "store down", key: "ak_down", status: http.StatusServiceUnavailable},
+		{name: "missing scope", key: "ak_reader", status: http.StatusForbidden},
+		{name: "all scopes", key: "ak_writer", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/books", auth.APIKeyAuth("book:create"), func(c *fiber.Ctx) error {
+				apiKey := c.Locals(middleware.LocalAPIKeyKey).(*middleware.APIKeyData)
+				return c.SendString(apiKey.Name)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/books", nil)
+			if tt.key != "" {
+				req.Header.Set(middleware.HeaderAPIKey, tt.key)
+			}
+			res, err := app.Test(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
+
+func TestClientAuth(t *testing.T) {
+	basic := mocks.NewMockIBasicAuthService(t)
+	basic.EXPECT().DecodeFromHeader("Basic YXBwOnNlY3JldA==").Return("app", "secret")
+	basic.EXPECT().Authenticate("app", "secret").Return("app", true)
+	auth := &middleware.AuthMiddleware{
+		Basic:   basic,
+		APIKeys: apiKeys{"ak_register": {ID: "key-1", Name: "signup", Scopes: middleware.ScopeUserRegister}},
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+		client string
+	}{
+		{name: "api key", header: middleware.HeaderAPIKey, value: "ak_register", status: http.StatusOK, client: "key-1"},
+		{name: "unknown api key", header: middleware.HeaderAPIKey, value: "ak_unknown", status: http.StatusUnauthorized},
+		{name: "basic", header: fiber.HeaderAuthorization, value: "Basic YXBwOnNlY3JldA==", status: http.StatusOK, client: "app"},
+		{name: "none", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/register", auth.ClientAuth(middleware.ScopeUserRegister), func(c *fiber.Ctx) error {
+				assert.Equal(t, c.Locals(middleware.LocalClientKey), middleware.ClientFromContext(c.UserContext()))
+				return c.SendString(middleware.ClientFromContext(c.UserContext()))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/register", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			res, err := app.Test(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			if tt.status == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				assert.Equal(t, tt.client, string(body))
+			}
+		})
+	}
+}
+
+func TestUnaryGrpcAuth_Client(t *testing.T) {
+	const method = "/auth.v1.AuthService/Register"
+	auth := &middleware.AuthMiddleware{APIKeys: apiKeys{
+		"ak_register": {ID: "key-1", Name: "signup", Scopes: middleware.ScopeUserRegister},
+		"ak_reader":   {ID: "key-2", Name: "reader", Scopes: "book:read"},
+	}}
+	interceptor := auth.UnaryGrpcAuth(
+		middleware.GrpcAuthRules{method: middleware.GrpcAuthClient},
+		middleware.GrpcPermissionRules{method: {middleware.ScopeUserRegister}},
+	)
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return middleware.ClientFromContext(ctx), nil
+	}
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		code   codes.Code
+		client string
+	}{
+		{name: "api key", md: metadata.Pairs("x-api-key", "ak_register"), code: codes.OK, client: "key-1"},
+		{name: "missing scope", md: metadata.Pairs("x-api-key", "ak_reader"), code: codes.PermissionDenied},
+		{name: "no basic auth", md: metadata.MD{}, code: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			client, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			if err == nil {
+				assert.Equal(t, tt.client, client)
+			}
+		})
+	}
+}
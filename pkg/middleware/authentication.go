@@ -1,10 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/Alwanly/go-codebase/pkg/wrapper"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -14,11 +19,33 @@ type IAuthMiddleware interface {
 
 	// Basic Auth
 	BasicAuth() fiber.Handler
+
+	// API key
+	APIKeyAuth(scopes ...string) fiber.Handler
 }
 
 type AuthMiddleware struct {
 	Jwt   authentication.IJwtService
 	Basic authentication.IBasicAuthService
+
+	// Keys sign and verify the tokens of Jwt, nil when it uses the key pair of its config
+	Keys authentication.IKeyManager
+
+	// Revocation is checked for every bearer token when set
+	Revocation authentication.IRevocationService
+	// RevocationFailOpen accepts tokens when the revocations cannot be read, instead of
+	// rejecting them, as long as their session is active
+	RevocationFailOpen bool
+
+	// Verification lists the routes that require a verified email address
+	Verification VerificationPolicy
+
+	// APIKeys authenticates the API keys of APIKeyAuth
+	APIKeys authentication.IAPIKeyService
+
+	// Sessions records the last use of the session of every bearer token when set, tokens of
+	// revoked or missing sessions are rejected
+	Sessions authentication.ISessionService
 }
 
 // mockery:ignore
@@ -26,15 +53,77 @@ type AuthConfig func(*AuthOpts)
 
 type AuthUserData struct {
 	UserID string `json:"userId"`
+	// Role is the most privileged role of the user, Roles all of them
+	Role          string   `json:"role"`
+	Roles         []string `json:"-"`
+	Permissions   []string `json:"-"`
+	EmailVerified bool     `json:"-"`
+
+	// TokenID, Family and ExpiresAt identify the token the user authenticated with
+	TokenID   string    `json:"-"`
+	Family    string    `json:"-"`
+	ExpiresAt time.Time `json:"-"`
 }
 
 type AuthOpts struct {
 	*authentication.JWTConfig
 	*authentication.BasicAuthTConfig
+	*authentication.RevocationConfig
+	RevocationFailOpen bool
+	VerificationRoutes []string
+	*authentication.APIKeyConfig
+	*authentication.SessionConfig
 }
 
 const LocalTokenKey = "user"
 
+// LocalClientKey holds the ID of the client authenticated by BasicAuth, or of the key
+// authenticated by APIKeyAuth.
+const LocalClientKey = "client"
+
+const (
+	RoleAdmin     = "admin"
+	RoleLibrarian = "librarian"
+	RoleMember    = "member"
+)
+
+// Permissions seeded with the roles, the roles grant them in the role_permissions table.
+const (
+	PermissionBookCreate    = "book:create"
+	PermissionBookUpdate    = "book:update"
+	PermissionBookDelete    = "book:delete"
+	PermissionBookMerge     = "book:merge"
+	PermissionWebhookManage = "webhook:manage"
+	PermissionUserManage    = "user:manage"
+	PermissionAPIKeyManage  = "apikey:manage"
+)
+
+// HasRole reports whether the user has the role.
+func (u *AuthUserData) HasRole(role string) bool {
+	return utils.AnyInSlice(u.Roles, role)
+}
+
+// rolePrecedence orders the seeded roles from the most privileged.
+var rolePrecedence = []string{RoleAdmin, RoleLibrarian, RoleMember}
+
+// PrimaryRole returns the most privileged of the roles, empty when there is none.
+func PrimaryRole(roles []string) string {
+	for _, role := range rolePrecedence {
+		if utils.AnyInSlice(roles, role) {
+			return role
+		}
+	}
+	if len(roles) > 0 {
+		return roles[0]
+	}
+	return ""
+}
+
+// HasPermission reports whether one of the roles of the user grants the permission.
+func (u *AuthUserData) HasPermission(permission string) bool {
+	return utils.AnyInSlice(u.Permissions, permission)
+}
+
 func SetJwtAuth(jwtConfig *authentication.JWTConfig) AuthConfig {
 	return func(o *AuthOpts) {
 		o.JWTConfig = jwtConfig
@@ -47,6 +136,62 @@ func SetBasicAuth(basicAuthConfig *authentication.BasicAuthTConfig) AuthConfig {
 	}
 }
 
+// SetRevocation makes the middleware reject revoked tokens.
+//
+// Parameters:
+//   - revocationConfig: revocation config
+//   - failOpen: accept tokens when the revocations cannot be read
+//
+// Returns:
+//   - AuthConfig: option
+func SetRevocation(revocationConfig *authentication.RevocationConfig, failOpen bool) AuthConfig {
+	return func(o *AuthOpts) {
+		o.RevocationConfig = revocationConfig
+		o.RevocationFailOpen = failOpen
+	}
+}
+
+// SetVerificationPolicy makes the middleware reject users without a verified email address on
+// the routes, see NewVerificationPolicy for their format.
+//
+// Parameters:
+//   - routes: routes
+//
+// Returns:
+//   - AuthConfig: option
+func SetVerificationPolicy(routes []string) AuthConfig {
+	return func(o *AuthOpts) {
+		o.VerificationRoutes = routes
+	}
+}
+
+// SetAPIKeyAuth enables APIKeyAuth.
+//
+// Parameters:
+//   - apiKeyConfig: API key config
+//
+// Returns:
+//   - AuthConfig: option
+func SetAPIKeyAuth(apiKeyConfig *authentication.APIKeyConfig) AuthConfig {
+	return func(o *AuthOpts) {
+		o.APIKeyConfig = apiKeyConfig
+	}
+}
+
+// SetSessions makes the middleware record the last use of the session of the tokens and reject
+// the tokens of revoked sessions.
+//
+// Parameters:
+//   - sessionConfig: session config
+//
+// Returns:
+//   - AuthConfig: option
+func SetSessions(sessionConfig *authentication.SessionConfig) AuthConfig {
+	return func(o *AuthOpts) {
+		o.SessionConfig = sessionConfig
+	}
+}
+
 func NewAuthMiddleware(opts ...AuthConfig) *AuthMiddleware {
 	var o AuthOpts
 	for _, opt := range opts {
@@ -56,9 +201,30 @@ func NewAuthMiddleware(opts ...AuthConfig) *AuthMiddleware {
 	jwtAuth := authentication.NewJWTService(o.JWTConfig)
 
 	basicAuth := authentication.NewBasicAuthService(o.BasicAuthTConfig)
+
+	var revocation authentication.IRevocationService
+	if o.RevocationConfig != nil {
+		revocation = authentication.NewRevocationService(o.RevocationConfig)
+	}
+
+	var apiKeys authentication.IAPIKeyService
+	if o.APIKeyConfig != nil {
+		apiKeys = authentication.NewAPIKeyService(o.APIKeyConfig)
+	}
+
+	var sessions authentication.ISessionService
+	if o.SessionConfig != nil {
+		sessions = authentication.NewSessionService(o.SessionConfig)
+	}
 	return &AuthMiddleware{
-		Jwt:   jwtAuth,
-		Basic: basicAuth,
+		Jwt:                jwtAuth,
+		Basic:              basicAuth,
+		Keys:               o.JWTConfig.Keys,
+		Revocation:         revocation,
+		RevocationFailOpen: o.RevocationFailOpen,
+		Verification:       NewVerificationPolicy(o.VerificationRoutes),
+		APIKeys:            apiKeys,
+		Sessions:           sessions,
 	}
 }
 
@@ -76,19 +242,89 @@ func (a *AuthMiddleware) JwtAuth() fiber.Handler {
 			return responseUnauthorized(ctx, "Bearer", "Invalid token")
 		}
 
-		// parse token
+		// parse token, refresh tokens are only accepted by the refresh endpoint
 		auth, err := a.Jwt.ParseToken(token)
-		if err != nil {
+		if err != nil || auth.String(authentication.ClaimType) != authentication.TokenTypeAccess {
 			return responseUnauthorized(ctx, "Bearer", "Invalid token")
 		}
 
+		// check revocation
+		revoked, failedOpen, err := a.checkRevocation(ctx.UserContext(), *auth)
+		if err != nil {
+			return ctx.Status(http.StatusServiceUnavailable).JSON(wrapper.ResponseFailed(
+				http.StatusServiceUnavailable, contract.StatusCodeInternalServerError, "Token revocation check unavailable", nil))
+		}
+		if revoked {
+			return responseUnauthorized(ctx, "Bearer", "Token revoked")
+		}
+		if outdatedToken(*auth) {
+			return responseUnauthorized(ctx, "Bearer", "Token outdated")
+		}
+		if !a.touchSession(ctx.UserContext(), *auth, failedOpen) {
+			return responseUnauthorized(ctx, "Bearer", "Session revoked")
+		}
+
+		// check email verification
+		user := decodeAuthToken(*auth)
+		if !user.EmailVerified && a.Verification.Requires(ctx.Method(), ctx.Path()) {
+			return ctx.Status(http.StatusForbidden).JSON(wrapper.ResponseFailed(
+				http.StatusForbidden, contract.StatusCodeEmailNotVerified, contract.ErrorEmailNotVerified, nil))
+		}
+
 		// set claims to context
-		ctx.Locals(LocalTokenKey, decodeAuthToken(*auth))
+		ctx.Locals(LocalTokenKey, user)
 
 		return ctx.Next()
 	}
 }
 
+// IsRevoked checks the revocation of the token, an error is only returned when the revocations
+// cannot be read and the middleware fails closed.
+//
+// Parameters:
+//   - ctx: context
+//   - claims: JWT claims
+//
+// Returns:
+//   - bool: true if the token is revoked
+//   - error: error
+func (a *AuthMiddleware) IsRevoked(ctx context.Context, claims authentication.JWTClaims) (bool, error) {
+	revoked, _, err := a.checkRevocation(ctx, claims)
+	return revoked, err
+}
+
+// checkRevocation checks the revocation of the token as IsRevoked does, failedOpen tells whether
+// the token is accepted only because the revocations cannot be read.
+func (a *AuthMiddleware) checkRevocation(ctx context.Context, claims authentication.JWTClaims) (bool, bool, error) {
+	if a.Revocation == nil {
+		return false, false, nil
+	}
+
+	revoked, err := a.Revocation.IsRevoked(ctx, claims)
+	if err != nil {
+		if a.RevocationFailOpen {
+			return false, true, nil
+		}
+		return false, false, err
+	}
+	return revoked, false, nil
+}
+
+// touchSession records the use of the session of the token and reports whether the token may be
+// used. A revoked or missing session refuses the token, a failure to read the session only does
+// when the revocation check failed open, the session is then always read as it is the only check
+// left.
+func (a *AuthMiddleware) touchSession(ctx context.Context, claims authentication.JWTClaims, failedOpen bool) bool {
+	if a.Sessions == nil {
+		return true
+	}
+	active, err := a.Sessions.Touch(ctx, claims.String(authentication.ClaimFamily), failedOpen)
+	if err != nil {
+		return !failedOpen
+	}
+	return active
+}
+
 func (a *AuthMiddleware) BasicAuth() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		// get auth from header
@@ -99,16 +335,61 @@ func (a *AuthMiddleware) BasicAuth() fiber.Handler {
 
 		// decode auth
 		username, password := a.Basic.DecodeFromHeader(auth)
-		if !a.Basic.Validate(username, password) {
+		clientID, ok := a.Basic.Authenticate(username, password)
+		if !ok {
 			return responseUnauthorized(ctx, "Basic", "Invalid auth")
 		}
+
+		// set client to locals and context
+		ctx.Locals(LocalClientKey, clientID)
+		ctx.SetUserContext(context.WithValue(ctx.UserContext(), ClientContextKey, clientID))
+		return ctx.Next()
+	}
+}
+
+// RequirePermission allows the request only when the roles of the authenticated user grant all
+// the permissions. It must be registered after JwtAuth, on a route or a group.
+func RequirePermission(permissions ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user, ok := ctx.Locals(LocalTokenKey).(*AuthUserData)
+		if !ok {
+			return responseUnauthorized(ctx, "Bearer", "Invalid token")
+		}
+
+		for _, permission := range permissions {
+			if !user.HasPermission(permission) {
+				return responseForbidden(ctx)
+			}
+		}
 		return ctx.Next()
 	}
 }
 
+func responseForbidden(c *fiber.Ctx) error {
+	return c.Status(http.StatusForbidden).JSON(wrapper.ResponseFailed(
+		http.StatusForbidden, contract.StatusCodeForbidden, contract.ErrorInsufficientPrivilege, nil))
+}
+
+// outdatedToken reports whether the access token was issued before tokens carried the permissions
+// of the user, its holder gets a 401 and refreshes it to get one that does.
+func outdatedToken(claims authentication.JWTClaims) bool {
+	_, ok := claims[authentication.ClaimPermissions]
+	return !ok
+}
+
 func decodeAuthToken(dataClaims authentication.JWTClaims) *AuthUserData {
+	roles := dataClaims.Strings(authentication.ClaimRoles)
+	emailVerified, _ := dataClaims[authentication.ClaimEmailVerified].(bool)
+
 	return &AuthUserData{
-		UserID: dataClaims["userId"].(string),
+		UserID:        dataClaims["userId"].(string),
+		Role:          PrimaryRole(roles),
+		Roles:         roles,
+		Permissions:   dataClaims.Strings(authentication.ClaimPermissions),
+		EmailVerified: emailVerified,
+		TokenID:       dataClaims.String(authentication.ClaimTokenID),
+		Family:        dataClaims.String(authentication.ClaimFamily),
+		ExpiresAt:     dataClaims.Time("exp"),
 	}
 }
 
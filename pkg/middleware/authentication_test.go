@@ -0,0 +1,144 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUserData_HasRole(t *testing.T) {
+	user := &middleware.AuthUserData{
+		Roles: []string{middleware.RoleLibrarian, middleware.RoleMember},
+	}
+
+	assert.True(t, user.HasRole(middleware.RoleLibrarian))
+	assert.True(t, user.HasRole(middleware.RoleMember))
+	assert.False(t, user.HasRole(middleware.RoleAdmin))
+}
+
+func TestPrimaryRole(t *testing.T) {
+	assert.Equal(t, middleware.RoleAdmin, middleware.PrimaryRole([]string{middleware.RoleMember, middleware.RoleAdmin}))
+	assert.Equal(t, middleware.RoleLibrarian, middleware.PrimaryRole([]string{middleware.RoleMember, middleware.RoleLibrarian}))
+	assert.Equal(t, "auditor", middleware.PrimaryRole([]string{"auditor"}))
+	assert.Equal(t, "", middleware.PrimaryRole(nil))
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *middleware.AuthUserData
+		status int
+	}{
+		{name: "unauthenticated", user: nil, status: http.StatusUnauthorized},
+		{name: "no permission", user: &middleware.AuthUserData{Permissions: []string{middleware.PermissionBookCreate}}, status: http.StatusForbidden},
+		{name: "some permissions", user: &middleware.AuthUserData{Permissions: []string{middleware.PermissionBookDelete}}, status: http.StatusForbidden},
+		{name: "all permissions", user: &middleware.AuthUserData{Permissions: []string{middleware.PermissionBookDelete, middleware.PermissionBookMerge}}, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if tt.user != nil {
+					c.Locals(middleware.LocalTokenKey, tt.user)
+				}
+				return c.Next()
+			})
+			app.Delete("/books/:id",
+				middleware.RequirePermission(middleware.PermissionBookDelete, middleware.PermissionBookMerge),
+				func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+			res, err := app.Test(httptest.NewRequest(http.MethodDelete, "/books/1", nil))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
+
+func TestJwtAuth_RejectsTokensWithoutPermissions(t *testing.T) {
+	jwt := mocks.NewMockIJwtService(t)
+	jwt.EXPECT().ParseToken("outdated").Return(&authentication.JWTClaims{
+		"userId":                 "user-1",
+		authentication.ClaimType: authentication.TokenTypeAccess,
+	}, nil)
+	jwt.EXPECT().ParseToken("current").Return(&authentication.JWTClaims{
+		"userId":                        "user-1",
+		authentication.ClaimType:        authentication.TokenTypeAccess,
+		authentication.ClaimRoles:       []interface{}{middleware.RoleMember},
+		authentication.ClaimPermissions: []interface{}{},
+	}, nil)
+
+	auth := &middleware.AuthMiddleware{Jwt: jwt}
+	app := fiber.New()
+	app.Get("/", auth.JwtAuth(), func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	for token, status := range map[string]int{"outdated": http.StatusUnauthorized, "current": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		res, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, status, res.StatusCode, token)
+	}
+}
+
+func TestJwtAuth_RejectsRevokedSessions(t *testing.T) {
+	redisDown := errors.New("connection refused")
+	tests := []struct {
+		name       string
+		revocation error
+		failOpen   bool
+		active     bool
+		sessionErr error
+		status     int
+	}{
+		{name: "active session", active: true, status: http.StatusOK},
+		{name: "revoked session", active: false, status: http.StatusUnauthorized},
+		{name: "session unreadable", sessionErr: redisDown, status: http.StatusOK},
+		{name: "fails closed", revocation: redisDown, status: http.StatusServiceUnavailable},
+		{name: "fails open on an active session", revocation: redisDown, failOpen: true, active: true, status: http.StatusOK},
+		{name: "fails open on a revoked session", revocation: redisDown, failOpen: true, active: false, status: http.StatusUnauthorized},
+		{name: "fails open on an unreadable session", revocation: redisDown, failOpen: true, sessionErr: redisDown, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &authentication.JWTClaims{
+				"userId":                        "user-1",
+				authentication.ClaimType:        authentication.TokenTypeAccess,
+				authentication.ClaimFamily:      "family-1",
+				authentication.ClaimRoles:       []interface{}{middleware.RoleMember},
+				authentication.ClaimPermissions: []interface{}{},
+			}
+			jwt := mocks.NewMockIJwtService(t)
+			jwt.EXPECT().ParseToken("token").Return(claims, nil)
+			revocation := mocks.NewMockIRevocationService(t)
+			revocation.EXPECT().IsRevoked(mock.Anything, *claims).Return(false, tt.revocation)
+			sessions := mocks.NewMockISessionService(t)
+			if tt.status != http.StatusServiceUnavailable {
+				// the session is always read when the revocation check failed open
+				sessions.EXPECT().Touch(mock.Anything, "family-1", tt.failOpen).Return(tt.active, tt.sessionErr)
+			}
+
+			auth := &middleware.AuthMiddleware{Jwt: jwt, Revocation: revocation, RevocationFailOpen: tt.failOpen, Sessions: sessions}
+			app := fiber.New()
+			app.Get("/", auth.JwtAuth(), func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer token")
+			res, err := app.Test(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
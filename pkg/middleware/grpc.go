@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Alwanly/go-codebase/pkg/authentication"
+	"github.com/Alwanly/go-codebase/pkg/contract"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type ContextAuth string
+
+const AuthUserContextKey ContextAuth = "middleware:auth_user"
+
+// Schemes of GrpcAuthRules.
+const (
+	GrpcAuthNone   = "none"
+	GrpcAuthBearer = "Bearer"
+	GrpcAuthBasic  = "Basic"
+	GrpcAuthAPIKey = "ApiKey"
+	// GrpcAuthClient accepts the x-api-key metadata when it is set and basic auth otherwise.
+	GrpcAuthClient = "Client"
+)
+
+// ClientContextKey holds the ID of the client authenticated with basic auth, or of the API key,
+// over HTTP and gRPC.
+const ClientContextKey ContextAuth = "middleware:client"
+
+// APIKeyContextKey holds the client authenticated with an API key.
+const APIKeyContextKey ContextAuth = "middleware:api_key"
+
+// GrpcAuthRules maps a full method name, such as "/auth.v1.AuthService/Login", or a service
+// prefix, such as "/grpc.health.v1.Health/", to the scheme it requires. Methods without a rule
+// require a bearer token.
+type GrpcAuthRules map[string]string
+
+func (r GrpcAuthRules) schemeOf(fullMethod string) string {
+	if scheme, ok := r[fullMethod]; ok {
+		return scheme
+	}
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		if scheme, ok := r[fullMethod[:i+1]]; ok {
+			return scheme
+		}
+	}
+	return GrpcAuthBearer
+}
+
+// GrpcPermissionRules maps a full method name to the permissions it requires, as
+// RequirePermission does for HTTP routes. Methods of the API key scheme require them as scopes.
+type GrpcPermissionRules map[string][]string
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryGrpcAuth authenticates unary calls as JwtAuth and BasicAuth do for HTTP, reading the
+// authorization metadata. The user of a bearer token is put in the context of the call.
+//
+// Parameters:
+//   - rules: scheme of the methods
+//   - permissions: permissions of the methods
+//
+// Returns:
+//   - grpc.UnaryServerInterceptor: interceptor
+func (a *AuthMiddleware) UnaryGrpcAuth(rules GrpcAuthRules, permissions GrpcPermissionRules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticateGrpc(ctx, info.FullMethod, rules.schemeOf(info.FullMethod), permissions[info.FullMethod])
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamGrpcAuth is UnaryGrpcAuth for streaming calls.
+//
+// Parameters:
+//   - rules: scheme of the methods
+//   - permissions: permissions of the methods
+//
+// Returns:
+//   - grpc.StreamServerInterceptor: interceptor
+func (a *AuthMiddleware) StreamGrpcAuth(rules GrpcAuthRules, permissions GrpcPermissionRules) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticateGrpc(ss.Context(), info.FullMethod, rules.schemeOf(info.FullMethod), permissions[info.FullMethod])
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *AuthMiddleware) authenticateGrpc(ctx context.Context, fullMethod string, scheme string, permissions []string) (context.Context, error) {
+	if scheme == GrpcAuthNone {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if scheme == GrpcAuthClient {
+		scheme = GrpcAuthBasic
+		if len(md.Get(strings.ToLower(HeaderAPIKey))) > 0 {
+			scheme = GrpcAuthAPIKey
+		}
+	}
+	if scheme == GrpcAuthAPIKey {
+		return a.authenticateGrpcAPIKey(ctx, md, permissions)
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], scheme+" ") {
+		if scheme == GrpcAuthBasic {
+			return nil, status.Error(codes.Unauthenticated, "Invalid auth")
+		}
+		return nil, status.Error(codes.Unauthenticated, "Invalid token")
+	}
+	auth := values[0]
+
+	if scheme == GrpcAuthBasic {
+		username, password := a.Basic.DecodeFromHeader(auth)
+		clientID, ok := a.Basic.Authenticate(username, password)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "Invalid auth")
+		}
+		return context.WithValue(ctx, ClientContextKey, clientID), nil
+	}
+
+	token := strings.TrimPrefix(auth, "Bearer ")
+	claims, err := a.Jwt.ParseToken(token)
+	if token == "" || err != nil || claims.String(authentication.ClaimType) != authentication.TokenTypeAccess {
+		return nil, status.Error(codes.Unauthenticated, "Invalid token")
+	}
+
+	revoked, failedOpen, err := a.checkRevocation(ctx, *claims)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "Token revocation check unavailable")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "Token revoked")
+	}
+	if outdatedToken(*claims) {
+		return nil, status.Error(codes.Unauthenticated, "Token outdated")
+	}
+	if !a.touchSession(ctx, *claims, failedOpen) {
+		return nil, status.Error(codes.Unauthenticated, "Session revoked")
+	}
+
+	user := decodeAuthToken(*claims)
+	if !user.EmailVerified && a.Verification.Requires(PolicyMethodGrpc, fullMethod) {
+		return nil, status.Error(codes.PermissionDenied, contract.ErrorEmailNotVerified)
+	}
+	for _, permission := range permissions {
+		if !user.HasPermission(permission) {
+			return nil, status.Error(codes.PermissionDenied, contract.ErrorInsufficientPrivilege)
+		}
+	}
+	return context.WithValue(ctx, AuthUserContextKey, user), nil
+}
+
+// authenticateGrpcAPIKey authenticates the x-api-key metadata as APIKeyAuth does.
+func (a *AuthMiddleware) authenticateGrpcAPIKey(ctx context.Context, md metadata.MD, scopes []string) (context.Context, error) {
+	values := md.Get(strings.ToLower(HeaderAPIKey))
+	if len(values) == 0 || values[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	apiKey, err := a.authenticateAPIKey(ctx, values[0])
+	if errors.Is(err, authentication.ErrInvalidAPIKey) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "API key check unavailable")
+	}
+
+	for _, scope := range scopes {
+		if !apiKey.HasScope(scope) {
+			return nil, status.Error(codes.PermissionDenied, contract.ErrorInsufficientPrivilege)
+		}
+	}
+	ctx = context.WithValue(ctx, ClientContextKey, apiKey.ID)
+	return context.WithValue(ctx, APIKeyContextKey, apiKey), nil
+}
+
+// APIKeyFromContext returns the client authenticated with an API key by UnaryGrpcAuth or
+// StreamGrpcAuth.
+func APIKeyFromContext(ctx context.Context) (*APIKeyData, bool) {
+	apiKey, ok := ctx.Value(APIKeyContextKey).(*APIKeyData)
+	return apiKey, ok
+}
+
+// ClientFromContext returns the ID of the client authenticated with basic auth or of the API key,
+// by BasicAuth, APIKeyAuth or the gRPC interceptors, empty when there is none.
+func ClientFromContext(ctx context.Context) string {
+	clientID, _ := ctx.Value(ClientContextKey).(string)
+	return clientID
+}
+
+// AuthUserFromContext returns the user authenticated by UnaryGrpcAuth or StreamGrpcAuth.
+func AuthUserFromContext(ctx context.Context) (*AuthUserData, bool) {
+	user, ok := ctx.Value(AuthUserContextKey).(*AuthUserData)
+	return user, ok
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"strings"
+)
+
+// PolicyMethodGrpc is the method of the gRPC rules of VerificationPolicy.
+const PolicyMethodGrpc = "GRPC"
+
+// VerificationPolicy lists the routes that require a verified email address. JwtAuth and the gRPC
+// interceptors reject the users without one on these routes.
+type VerificationPolicy struct {
+	rules []policyRule
+}
+
+type policyRule struct {
+	method   string
+	segments []string
+}
+
+// NewVerificationPolicy parses the routes of the policy. HTTP routes are given as
+// "POST /books/v1/:id", where a ":name" segment matches any segment, a trailing "*" matches the
+// rest of the path and a "*" method matches any method. HTTP paths match regardless of case, as
+// the router routes them. gRPC methods are given as "GRPC /book.v1.BookService/CreateBook".
+//
+// Parameters:
+//   - routes: routes
+//
+// Returns:
+//   - VerificationPolicy: policy
+func NewVerificationPolicy(routes []string) VerificationPolicy {
+	var p VerificationPolicy
+	for _, route := range routes {
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			continue
+		}
+		p.rules = append(p.rules, policyRule{
+			method:   strings.ToUpper(method),
+			segments: splitPath(path),
+		})
+	}
+	return p
+}
+
+// Requires reports whether the route requires a verified email address.
+//
+// Parameters:
+//   - method: HTTP method, or PolicyMethodGrpc
+//   - path: request path, or full gRPC method
+//
+// Returns:
+//   - bool: true if a verified email address is required
+func (p VerificationPolicy) Requires(method string, path string) bool {
+	segments := splitPath(path)
+	for _, rule := range p.rules {
+		if (rule.method == "*" || rule.method == method) && rule.matches(segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r policyRule) matches(segments []string) bool {
+	for i, pattern := range r.segments {
+		if pattern == "*" && i == len(r.segments)-1 {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(pattern, ":") {
+			continue
+		}
+		if r.method == PolicyMethodGrpc && pattern != segments[i] {
+			return false
+		}
+		if r.method != PolicyMethodGrpc && !strings.EqualFold(pattern, segments[i]) {
+			return false
+		}
+	}
+	return len(segments) == len(r.segments)
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
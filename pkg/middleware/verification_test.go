@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationPolicy_Requires(t *testing.T) {
+	policy := middleware.NewVerificationPolicy([]string{
+		"POST /books/v1",
+		"post /books/v1/:id/transitions",
+		"* /admin/*",
+		"GRPC /book.v1.BookService/CreateBook",
+		"invalid",
+	})
+
+	tests := []struct {
+		method   string
+		path     string
+		requires bool
+	}{
+		{method: "POST", path: "/books/v1", requires: true},
+		{method: "POST", path: "/books/v1/", requires: true},
+		{method: "POST", path: "/Books/V1", requires: true},
+		{method: "POST", path: "/BOOKS/v1/book-1/Transitions", requires: true},
+		{method: "PUT", path: "/Admin/users/1", requires: true},
+		{method: "GET", path: "/books/v1", requires: false},
+		{method: "POST", path: "/books/v1/batch", requires: false},
+		{method: "POST", path: "/books/v1/book-1/transitions", requires: true},
+		{method: "DELETE", path: "/admin/users/1", requires: true},
+		{method: "GET", path: "/administration", requires: false},
+		{method: middleware.PolicyMethodGrpc, path: "/book.v1.BookService/CreateBook", requires: true},
+		{method: middleware.PolicyMethodGrpc, path: "/book.v1.BookService/GetBook", requires: false},
+		{method: middleware.PolicyMethodGrpc, path: "/book.v1.bookservice/createbook", requires: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.requires, policy.Requires(tt.method, tt.path))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const ContextName = "Components.Notifier"
+
+const (
+	SinkLog  = "log"
+	SinkFile = "file"
+)
+
+// Kinds of Message.
+const (
+	KindPasswordReset     = "password_reset"
+	KindEmailVerification = "email_verification"
+)
+
+// Message is a notification to a user. To is the email address of the user, empty when the user
+// has none, sinks that cannot reach the user without it return an error.
+type Message struct {
+	Kind    string    `json:"kind"`
+	UserID  string    `json:"userId"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+type INotifier interface {
+	// Notify delivers the message.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - msg: message
+	//
+	// Returns:
+	//   - error: error
+	Notify(ctx context.Context, msg Message) error
+}
+
+type Opts struct {
+	Logger *zap.Logger
+
+	// Sink is one of SinkLog and SinkFile
+	Sink string
+
+	// FilePath is the file SinkFile appends to
+	FilePath string
+}
+
+// New creates the notifier of the sink. The log and file sinks write the whole message, secrets
+// included, they are meant for development and tests.
+//
+// Parameters:
+//   - opts: options
+//
+// Returns:
+//   - INotifier: notifier
+//   - error: error if the sink is unknown
+func New(opts Opts) (INotifier, error) {
+	switch opts.Sink {
+	case SinkLog:
+		return &LogNotifier{Logger: opts.Logger}, nil
+	case SinkFile:
+		if opts.FilePath == "" {
+			return nil, fmt.Errorf("notifier: file sink requires a file path")
+		}
+		return &FileNotifier{Path: opts.FilePath}, nil
+	default:
+		return nil, fmt.Errorf("notifier: unknown sink %q", opts.Sink)
+	}
+}
+
+// LogNotifier writes messages to the log.
+type LogNotifier struct {
+	Logger *zap.Logger
+}
+
+func (n *LogNotifier) Notify(_ context.Context, msg Message) error {
+	logger.WithID(n.Logger, ContextName, "LogNotifier").Info("notification",
+		zap.String("kind", msg.Kind),
+		zap.String("userId", msg.UserID),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body))
+	return nil
+}
+
+// FileNotifier appends messages to a file, one JSON object per line.
+type FileNotifier struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (n *FileNotifier) Notify(_ context.Context, msg Message) error {
+	if msg.SentAt.IsZero() {
+		msg.SentAt = time.Now()
+	}
+	line, err := utils.JSONMarshal(msg)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,59 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNew(t *testing.T) {
+	n, err := notifier.New(notifier.Opts{Logger: zap.NewNop(), Sink: notifier.SinkLog})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), notifier.Message{Kind: notifier.KindPasswordReset}))
+
+	_, err = notifier.New(notifier.Opts{Sink: notifier.SinkFile})
+	assert.Error(t, err)
+
+	_, err = notifier.New(notifier.Opts{Sink: "smtp"})
+	assert.Error(t, err)
+}
+
+func TestFileNotifier_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n, err := notifier.New(notifier.Opts{Sink: notifier.SinkFile, FilePath: path})
+	assert.NoError(t, err)
+
+	for _, userID := range []string{"user-1", "user-2"} {
+		err := n.Notify(context.Background(), notifier.Message{
+			Kind:    notifier.KindPasswordReset,
+			UserID:  userID,
+			Subject: "Reset your password",
+			Body:    "token",
+		})
+		assert.NoError(t, err)
+	}
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var messages []notifier.Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg notifier.Message
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		messages = append(messages, msg)
+	}
+
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "user-2", messages[1].UserID)
+	assert.Equal(t, notifier.KindPasswordReset, messages[1].Kind)
+	assert.False(t, messages[0].SentAt.IsZero())
+}
@@ -0,0 +1,163 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+// pruneInterval is how often published events past the retention are deleted.
+const pruneInterval = time.Hour
+
+// RelayOpts represents the options for configuring the outbox relay.
+type RelayOpts struct {
+	// Logger is the logger.
+	Logger *zap.Logger
+	// DB is the database holding the outbox.
+	DB database.IDBService
+	// Sinks receive every event, in order.
+	Sinks []ISink
+	// PollInterval is the delay between polls when the outbox is drained. Default is 1 second.
+	PollInterval time.Duration
+	// BatchSize is the number of events claimed per poll. Default is 100.
+	BatchSize int
+	// MaxRetryDelay caps the delay before a failed event is retried. Default is 5 minutes.
+	MaxRetryDelay time.Duration
+	// Retention is how long published events are kept, zero keeps them forever.
+	Retention time.Duration
+}
+
+// Relay publishes outbox events to the sinks. Several relays may run at once, rows are claimed with
+// FOR UPDATE SKIP LOCKED so each batch is handled by one of them. An event is marked published only
+// after every sink accepted it, so a crash in between sends it again: delivery is at-least-once.
+type Relay struct {
+	opts      RelayOpts
+	lastPrune time.Time
+}
+
+func NewRelay(opts RelayOpts) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxRetryDelay <= 0 {
+		opts.MaxRetryDelay = 5 * time.Minute
+	}
+	return &Relay{opts: opts}
+}
+
+// Run relays events until the context is done.
+func (r *Relay) Run(ctx context.Context) error {
+	l := logger.WithID(r.opts.Logger, ContextName, "Relay")
+
+	for {
+		relayed, err := r.RelayBatch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			l.Error("failed to relay outbox", zap.Error(err))
+		}
+		r.prune(ctx)
+
+		// poll again right away while there is a backlog
+		if relayed == r.opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
+
+// RelayBatch claims due events and sends them to the sinks.
+//
+// Parameters:
+//   - ctx: context
+//
+// Returns:
+//   - int: number of claimed events
+//   - error: error
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	l := logger.WithID(r.opts.Logger, ContextName, "RelayBatch")
+
+	claimed := 0
+	err := r.opts.DB.RunInTransaction(ctx, func(ctx context.Context) error {
+		var events []model.OutboxEvent
+		err := r.opts.DB.GetTransaction(ctx).
+			Clauses(clause.Locking{Strength: database.PostgresLockTypeForUpdate, Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND next_attempt_at <= ?", time.Now()).
+			Order("created_at").
+			Limit(r.opts.BatchSize).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+		claimed = len(events)
+
+		for i := range events {
+			e := &events[i]
+			if err := r.send(ctx, e); err != nil {
+				e.Attempts++
+				e.LastError = err.Error()
+				e.NextAttemptAt = time.Now().Add(utils.ExponentialBackoff(e.Attempts, r.opts.PollInterval, r.opts.MaxRetryDelay))
+				l.Warn("failed to relay event",
+					zap.String("id", e.ID), zap.Int("attempts", e.Attempts), zap.Error(err))
+			} else {
+				e.PublishedAt = utils.ToPointer(time.Now())
+				e.LastError = ""
+			}
+
+			err := r.opts.DB.GetTransaction(ctx).Model(e).
+				Select("attempts", "last_error", "next_attempt_at", "published_at").
+				Updates(e).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *Relay) send(ctx context.Context, e *model.OutboxEvent) error {
+	msg := Message{
+		ID:            e.ID,
+		Type:          e.EventType,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Payload:       []byte(e.Payload),
+		CreatedAt:     e.CreatedAt,
+	}
+	for _, sink := range r.opts.Sinks {
+		if err := sink.Send(ctx, msg); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+// prune deletes published events older than the retention, at most once per prune interval.
+func (r *Relay) prune(ctx context.Context) {
+	if r.opts.Retention <= 0 || time.Since(r.lastPrune) < pruneInterval {
+		return
+	}
+	r.lastPrune = time.Now()
+
+	err := r.opts.DB.GetTransaction(ctx).
+		Where("published_at < ?", time.Now().Add(-r.opts.Retention)).
+		Delete(&model.OutboxEvent{}).Error
+	if err != nil {
+		logger.WithID(r.opts.Logger, ContextName, "Prune").Warn("failed to prune outbox", zap.Error(err))
+	}
+}
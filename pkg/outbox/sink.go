@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/logger"
+	"github.com/Alwanly/go-codebase/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	SinkLog         = "log"
+	SinkRedisStream = "redis_stream"
+	SinkRedisPubSub = "redis_pubsub"
+	SinkWebhook     = "webhook"
+)
+
+// Message is an outbox event handed to a sink. ID is the event ID, consumers use it to drop the
+// duplicates at-least-once delivery can produce.
+type Message struct {
+	ID            string
+	Type          string
+	AggregateType string
+	AggregateID   string
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+type ISink interface {
+	// Name identifies the sink in logs.
+	Name() string
+
+	// Send publishes the message, an error makes the relay retry it later on every sink.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - msg: message
+	//
+	// Returns:
+	//   - error: error
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSink writes messages to the log, useful in development.
+type LogSink struct {
+	Logger *zap.Logger
+}
+
+func (s *LogSink) Name() string { return SinkLog }
+
+func (s *LogSink) Send(_ context.Context, msg Message) error {
+	logger.WithID(s.Logger, ContextName, "LogSink").Info("outbox event",
+		zap.String("id", msg.ID),
+		zap.String("type", msg.Type),
+		zap.String("aggregate", msg.AggregateType+"/"+msg.AggregateID),
+		zap.ByteString("payload", msg.Payload))
+	return nil
+}
+
+// RedisStreamSink appends messages to a Redis stream, the entry carries the event ID for dedupe.
+type RedisStreamSink struct {
+	Redis  redis.IRedisService
+	Stream string
+	MaxLen int64
+}
+
+func (s *RedisStreamSink) Name() string { return SinkRedisStream }
+
+func (s *RedisStreamSink) Send(ctx context.Context, msg Message) error {
+	_, err := s.Redis.XAdd(ctx, s.Stream, s.MaxLen, map[string]interface{}{
+		"id":            msg.ID,
+		"type":          msg.Type,
+		"aggregateType": msg.AggregateType,
+		"aggregateId":   msg.AggregateID,
+		"payload":       string(msg.Payload),
+	})
+	return err
+}
+
+// RedisPubSubSink publishes the stored event payload to a Redis channel, every instance listening
+// on it gets the event. Pub/sub keeps nothing for absent listeners, use it for live updates only.
+type RedisPubSubSink struct {
+	Redis   redis.IRedisService
+	Channel string
+}
+
+func (s *RedisPubSubSink) Name() string { return SinkRedisPubSub }
+
+func (s *RedisPubSubSink) Send(ctx context.Context, msg Message) error {
+	return s.Redis.Publish(ctx, s.Channel, string(msg.Payload))
+}
+
+// DispatcherSink hands messages to an event dispatcher such as the webhook dispatcher.
+type DispatcherSink struct {
+	SinkName   string
+	Dispatcher event.IDispatcher
+}
+
+func (s *DispatcherSink) Name() string { return s.SinkName }
+
+func (s *DispatcherSink) Send(ctx context.Context, msg Message) error {
+	// keep the data as stored so it is forwarded byte for byte
+	var stored struct {
+		ID         string          `json:"id"`
+		Type       string          `json:"type"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := utils.JSONUnMarshal(msg.Payload, &stored); err != nil {
+		return err
+	}
+
+	return s.Dispatcher.Dispatch(ctx, event.Event{
+		ID:         stored.ID,
+		Type:       stored.Type,
+		OccurredAt: stored.OccurredAt,
+		Data:       stored.Data,
+	})
+}
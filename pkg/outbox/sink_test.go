@@ -0,0 +1,72 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	mocks "github.com/Alwanly/go-codebase/mocks/pkg/redis"
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dispatcherFunc func(ctx context.Context, evt event.Event) error
+
+func (f dispatcherFunc) Dispatch(ctx context.Context, evt event.Event) error {
+	return f(ctx, evt)
+}
+
+func TestRedisStreamSink_Send(t *testing.T) {
+	redis := mocks.NewMockIRedisService(t)
+	redis.EXPECT().
+		XAdd(mock.Anything, "books:events", int64(100), mock.MatchedBy(func(values map[string]interface{}) bool {
+			return values["id"] == "event-1" && values["type"] == "book.created" && values["aggregateId"] == "book-1"
+		})).
+		Return("1-0", nil)
+
+	sink := &outbox.RedisStreamSink{Redis: redis, Stream: "books:events", MaxLen: 100}
+	err := sink.Send(context.Background(), outbox.Message{
+		ID:            "event-1",
+		Type:          "book.created",
+		AggregateType: "book",
+		AggregateID:   "book-1",
+		Payload:       []byte(`{}`),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestDispatcherSink_KeepsEventIDAndData(t *testing.T) {
+	var dispatched event.Event
+	sink := &outbox.DispatcherSink{
+		SinkName: outbox.SinkWebhook,
+		Dispatcher: dispatcherFunc(func(_ context.Context, evt event.Event) error {
+			dispatched = evt
+			return nil
+		}),
+	}
+
+	payload := []byte(`{"id":"event-1","type":"book.created","occurredAt":"2026-10-19T10:00:00Z","data":{"id":"book-1","title":"Dune"}}`)
+	err := sink.Send(context.Background(), outbox.Message{ID: "event-1", Type: "book.created", Payload: payload})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "event-1", dispatched.ID)
+	assert.Equal(t, "book.created", dispatched.Type)
+	data, _ := json.Marshal(dispatched.Data)
+	assert.JSONEq(t, `{"id":"book-1","title":"Dune"}`, string(data))
+}
+
+func TestDispatcherSink_ReturnsDispatchError(t *testing.T) {
+	sink := &outbox.DispatcherSink{
+		SinkName: outbox.SinkWebhook,
+		Dispatcher: dispatcherFunc(func(context.Context, event.Event) error {
+			return errors.New("queue closed")
+		}),
+	}
+
+	err := sink.Send(context.Background(), outbox.Message{Payload: []byte(`{"id":"event-1","data":{}}`)})
+	assert.EqualError(t, err, "queue closed")
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/Alwanly/go-codebase/model"
+	"github.com/Alwanly/go-codebase/pkg/database"
+	"github.com/Alwanly/go-codebase/pkg/event"
+	"github.com/Alwanly/go-codebase/pkg/utils"
+)
+
+const ContextName = "Components.Outbox"
+
+type IStore interface {
+	// Add writes the event to the outbox in the transaction attached to the context, so the event is
+	// stored if and only if the change it describes is committed.
+	//
+	// Parameters:
+	//   - ctx: context carrying the transaction of the change
+	//   - aggregateType: type of the changed entity, e.g. book
+	//   - aggregateID: ID of the changed entity
+	//   - evt: event
+	//
+	// Returns:
+	//   - error: error
+	Add(ctx context.Context, aggregateType, aggregateID string, evt event.Event) error
+}
+
+type Store struct {
+	DB database.IDBService
+}
+
+func NewStore(db database.IDBService) IStore {
+	return &Store{DB: db}
+}
+
+func (s *Store) Add(ctx context.Context, aggregateType, aggregateID string, evt event.Event) error {
+	payload, err := utils.JSONMarshal(evt)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.GetTransaction(ctx).Create(&model.OutboxEvent{
+		ID:            evt.ID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     evt.Type,
+		Payload:       string(payload),
+		CreatedAt:     evt.OccurredAt,
+		NextAttemptAt: evt.OccurredAt,
+	}).Error
+}
@@ -2,6 +2,10 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
 
 	"github.com/Alwanly/go-codebase/pkg/logger"
 	"github.com/go-redis/redis/v9"
@@ -62,3 +66,137 @@ func (db *Service) CloseRedis() error {
 func (db *Service) GetTransaction() (redis.Pipeliner, error) {
 	return db.Redis.TxPipeline(), nil
 }
+
+func (db *Service) Get(ctx context.Context, key string) (string, error) {
+	return db.Redis.Get(ctx, key).Result()
+}
+
+func (db *Service) GetDel(ctx context.Context, key string) (string, error) {
+	return db.Redis.GetDel(ctx, key).Result()
+}
+
+func (db *Service) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return db.Redis.Set(ctx, key, value, expiration).Err()
+}
+
+func (db *Service) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return db.Redis.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (db *Service) Del(ctx context.Context, key string) error {
+	return db.Redis.Del(ctx, key).Err()
+}
+
+func (db *Service) MGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return []interface{}{}, nil
+	}
+	return db.Redis.MGet(ctx, keys...).Result()
+}
+
+func (db *Service) MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	// MSET takes no expiration, so SET every key in a single pipeline
+	_, err := db.Redis.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for key, value := range values {
+			p.Set(ctx, key, value, expiration)
+		}
+		return nil
+	})
+	return err
+}
+
+func (db *Service) DelMany(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return db.Redis.Del(ctx, keys...).Err()
+}
+
+// compareAndSet sets KEYS[1] to ARGV[2] with a ttl of ARGV[3] milliseconds when it holds ARGV[1].
+var compareAndSet = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+func (db *Service) CompareAndSet(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error) {
+	return compareAndSet.Run(ctx, db.Redis, []string{key}, expected, value, expiration.Milliseconds()).Bool()
+}
+
+func (db *Service) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := db.Redis.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// PTTL is negative for missing keys and keys without expiration
+	return max(ttl, 0), nil
+}
+
+// windowAdd drops the members of the sorted set KEYS[1] scored before ARGV[1] - ARGV[2]
+// milliseconds, adds ARGV[3] scored ARGV[1] and returns the size of the set.
+var windowAdd = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", tonumber(ARGV[1]) - tonumber(ARGV[2]))
+redis.call("ZADD", KEYS[1], ARGV[1], ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return redis.call("ZCARD", KEYS[1])
+`)
+
+// windowCount drops the members of the sorted set KEYS[1] scored before ARGV[1] - ARGV[2]
+// milliseconds and returns the size of the set with the score of its oldest member.
+var windowCount = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", tonumber(ARGV[1]) - tonumber(ARGV[2]))
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {redis.call("ZCARD", KEYS[1]), oldest[2] or "0"}
+`)
+
+func (db *Service) WindowAdd(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := time.Now()
+	// the member only needs to be unique, two events of the same millisecond are both counted
+	member := strconv.FormatInt(now.UnixNano(), 36) + ":" + strconv.FormatUint(rand.Uint64(), 36)
+	return windowAdd.Run(ctx, db.Redis, []string{key}, now.UnixMilli(), window.Milliseconds(), member).Int64()
+}
+
+func (db *Service) WindowCount(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	now := time.Now()
+	result, err := windowCount.Run(ctx, db.Redis, []string{key}, now.UnixMilli(), window.Milliseconds()).Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count, _ := result[0].(int64)
+	if count == 0 {
+		return 0, 0, nil
+	}
+	oldest, err := strconv.ParseFloat(fmt.Sprint(result[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, max(time.UnixMilli(int64(oldest)).Add(window).Sub(now), 0), nil
+}
+
+func (db *Service) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	return db.Redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+}
+
+func (db *Service) Publish(ctx context.Context, channel string, message interface{}) error {
+	return db.Redis.Publish(ctx, channel, message).Err()
+}
+
+func (db *Service) Subscribe(ctx context.Context, channel string) *redis.PubSub {
+	return db.Redis.Subscribe(ctx, channel)
+}
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-redis/redis/v9"
@@ -13,6 +14,9 @@ const (
 	PingTimeout = 10 * time.Second
 )
 
+// ErrNil is returned by Get when the key does not exist.
+var ErrNil = redis.Nil
+
 // DBServiceOpts represents the options for configuring the database service.
 type Opts struct {
 	// Debug enables debug mode.
@@ -52,4 +56,178 @@ type IRedisService interface {
 
 	// CloseRedis closes the Redis database connection.
 	CloseRedis() error
+
+	// Get returns the value stored at key.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//
+	// Returns:
+	//   - string: value
+	//   - error: ErrNil if the key does not exist
+	Get(ctx context.Context, key string) (string, error)
+
+	// GetDel returns the value stored at key and removes the key, atomically.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//
+	// Returns:
+	//   - string: value
+	//   - error: ErrNil if the key does not exist
+	GetDel(ctx context.Context, key string) (string, error)
+
+	// Set stores the value at key with an expiration, zero means no expiration.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//   - value: value
+	//   - expiration: time to live
+	//
+	// Returns:
+	//   - error: error
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+
+	// SetNX stores the value at key only if the key does not exist.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//   - value: value
+	//   - expiration: time to live
+	//
+	// Returns:
+	//   - bool: true if the value was stored
+	//   - error: error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
+	// Del removes the key.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//
+	// Returns:
+	//   - error: error
+	Del(ctx context.Context, key string) error
+
+	// MGet returns the values stored at the keys in one round trip.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - keys: keys
+	//
+	// Returns:
+	//   - []interface{}: value of every key in order, nil for the keys that do not exist
+	//   - error: error
+	MGet(ctx context.Context, keys []string) ([]interface{}, error)
+
+	// MSet stores the values in one pipeline, each with the same expiration.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - values: values by key
+	//   - expiration: time to live
+	//
+	// Returns:
+	//   - error: error
+	MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error
+
+	// DelMany removes the keys.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - keys: keys
+	//
+	// Returns:
+	//   - error: error
+	DelMany(ctx context.Context, keys []string) error
+
+	// CompareAndSet stores the value at key only if the key holds the expected value, atomically.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//   - expected: value the key must hold
+	//   - value: new value
+	//   - expiration: time to live, zero means no expiration
+	//
+	// Returns:
+	//   - bool: true if the value was stored
+	//   - error: error
+	CompareAndSet(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error)
+
+	// TTL returns the remaining time to live of the key.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//
+	// Returns:
+	//   - time.Duration: time to live, zero if the key does not exist or never expires
+	//   - error: error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// WindowAdd records an event in the sliding window stored at key and counts the events of the
+	// window, atomically. Events older than the window are dropped.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//   - window: length of the window
+	//
+	// Returns:
+	//   - int64: events in the window, including the new one
+	//   - error: error
+	WindowAdd(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// WindowCount counts the events of the sliding window stored at key.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - key: key
+	//   - window: length of the window
+	//
+	// Returns:
+	//   - int64: events in the window
+	//   - time.Duration: time until the oldest event leaves the window
+	//   - error: error
+	WindowCount(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+
+	// XAdd appends an entry to a stream, trimming it to about maxLen entries.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - stream: stream key
+	//   - maxLen: approximate maximum length, zero means no trimming
+	//   - values: entry fields
+	//
+	// Returns:
+	//   - string: entry ID
+	//   - error: error
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error)
+
+	// Publish posts a message to a pub/sub channel.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - channel: channel
+	//   - message: message
+	//
+	// Returns:
+	//   - error: error
+	Publish(ctx context.Context, channel string, message interface{}) error
+
+	// Subscribe subscribes to a pub/sub channel, the subscription reconnects on its own until closed.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - channel: channel
+	//
+	// Returns:
+	//   - *redis.PubSub: subscription
+	Subscribe(ctx context.Context, channel string) *redis.PubSub
 }
@@ -0,0 +1,181 @@
+package sse
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Event is a message sent to the stream. Attributes are not sent, subscribers filter on them.
+type Event struct {
+	ID         string
+	Type       string
+	Data       []byte
+	Attributes map[string]string
+}
+
+// Filter reports whether a subscriber wants the event.
+type Filter func(evt Event) bool
+
+// Subscription receives the events published after it was created. C is closed when the
+// subscriber falls too far behind or the broker is closed, the client should then reconnect with
+// the last event ID it received.
+type Subscription struct {
+	C <-chan Event
+
+	ch     chan Event
+	filter Filter
+}
+
+// Broker fans events out to subscribers and keeps the latest ones for replay.
+type Broker struct {
+	mu          sync.Mutex
+	buffer      []Event
+	next        int
+	full        bool
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewBroker creates a broker keeping the last replaySize events and buffering up to bufferSize
+// events per subscriber.
+func NewBroker(replaySize, bufferSize int) *Broker {
+	return &Broker{
+		buffer:      make([]Event, max(replaySize, 1)),
+		bufferSize:  max(bufferSize, 1),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish stores the event for replay and sends it to every interested subscriber. A subscriber
+// whose buffer is full is dropped instead of blocking the others.
+func (b *Broker) Publish(evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.buffer[b.next] = evt
+	b.next = (b.next + 1) % len(b.buffer)
+	if b.next == 0 {
+		b.full = true
+	}
+
+	for sub := range b.subscribers {
+		if sub.filter != nil && !sub.filter(evt) {
+			continue
+		}
+		select {
+		case sub.ch <- evt:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe registers a subscriber and returns the buffered events after lastEventID that pass the
+// filter. When lastEventID is empty nothing is replayed, when it is no longer buffered the whole
+// buffer is replayed.
+func (b *Broker) Subscribe(lastEventID string, filter Filter) (*Subscription, []Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, b.bufferSize)
+	sub := &Subscription{C: ch, ch: ch, filter: filter}
+	if b.closed {
+		close(ch)
+		return sub, nil
+	}
+	b.subscribers[sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil
+	}
+
+	buffered := b.buffered()
+	start := 0
+	for i := len(buffered) - 1; i >= 0; i-- {
+		if buffered[i].ID == lastEventID {
+			start = i + 1
+			break
+		}
+	}
+
+	replay := []Event{}
+	for _, evt := range buffered[start:] {
+		if filter == nil || filter(evt) {
+			replay = append(replay, evt)
+		}
+	}
+	return sub, replay
+}
+
+// Unsubscribe removes the subscriber, it is safe to call more than once.
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Close disconnects every subscriber, later subscriptions are closed right away.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// buffered returns the buffered events from oldest to newest.
+func (b *Broker) buffered() []Event {
+	if !b.full {
+		return append([]Event{}, b.buffer[:b.next]...)
+	}
+	return append(append([]Event{}, b.buffer[b.next:]...), b.buffer[:b.next]...)
+}
+
+// Write writes the event in the text/event-stream format and flushes it.
+//
+// Parameters:
+//   - w: stream writer
+//   - evt: event
+//
+// Returns:
+//   - error: error if the client is gone
+func Write(w *bufio.Writer, evt Event) error {
+	if evt.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", evt.ID)
+	}
+	if evt.Type != "" {
+		fmt.Fprintf(w, "event: %s\n", evt.Type)
+	}
+	for _, line := range strings.Split(string(evt.Data), "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	w.WriteString("\n")
+	return w.Flush()
+}
+
+// WriteHeartbeat writes a comment line, it keeps proxies from closing an idle connection.
+//
+// Parameters:
+//   - w: stream writer
+//
+// Returns:
+//   - error: error if the client is gone
+func WriteHeartbeat(w *bufio.Writer) error {
+	w.WriteString(": heartbeat\n\n")
+	return w.Flush()
+}
@@ -0,0 +1,97 @@
+package sse_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/sse"
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(events []sse.Event) []string {
+	result := []string{}
+	for _, evt := range events {
+		result = append(result, evt.ID)
+	}
+	return result
+}
+
+func TestBroker_PublishToSubscribers(t *testing.T) {
+	broker := sse.NewBroker(10, 10)
+	sub, replay := broker.Subscribe("", nil)
+	assert.Empty(t, replay)
+
+	broker.Publish(sse.Event{ID: "1"})
+	broker.Publish(sse.Event{ID: "2"})
+
+	assert.Equal(t, "1", (<-sub.C).ID)
+	assert.Equal(t, "2", (<-sub.C).ID)
+}
+
+func TestBroker_Filter(t *testing.T) {
+	broker := sse.NewBroker(10, 10)
+	sub, _ := broker.Subscribe("", func(evt sse.Event) bool { return evt.Attributes["author"] == "tolkien" })
+
+	broker.Publish(sse.Event{ID: "1", Attributes: map[string]string{"author": "herbert"}})
+	broker.Publish(sse.Event{ID: "2", Attributes: map[string]string{"author": "tolkien"}})
+
+	assert.Equal(t, "2", (<-sub.C).ID)
+	assert.Empty(t, sub.C)
+}
+
+func TestBroker_ReplayAfterLastEventID(t *testing.T) {
+	broker := sse.NewBroker(3, 10)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		broker.Publish(sse.Event{ID: id})
+	}
+
+	// the buffer holds 2, 3 and 4
+	_, replay := broker.Subscribe("2", nil)
+	assert.Equal(t, []string{"3", "4"}, ids(replay))
+
+	_, replay = broker.Subscribe("4", nil)
+	assert.Empty(t, replay)
+
+	// 1 was evicted, everything buffered is replayed
+	_, replay = broker.Subscribe("1", nil)
+	assert.Equal(t, []string{"2", "3", "4"}, ids(replay))
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	broker := sse.NewBroker(10, 1)
+	sub, _ := broker.Subscribe("", nil)
+
+	broker.Publish(sse.Event{ID: "1"})
+	broker.Publish(sse.Event{ID: "2"})
+
+	assert.Equal(t, "1", (<-sub.C).ID)
+	_, open := <-sub.C
+	assert.False(t, open)
+	broker.Unsubscribe(sub)
+}
+
+func TestBroker_Close(t *testing.T) {
+	broker := sse.NewBroker(10, 10)
+	sub, _ := broker.Subscribe("", nil)
+
+	broker.Close()
+	_, open := <-sub.C
+	assert.False(t, open)
+
+	late, _ := broker.Subscribe("", nil)
+	_, open = <-late.C
+	assert.False(t, open)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	assert.NoError(t, sse.Write(w, sse.Event{ID: "1", Type: "book.created", Data: []byte("a\nb")}))
+	assert.Equal(t, "id: 1\nevent: book.created\ndata: a\ndata: b\n\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, sse.WriteHeartbeat(w))
+	assert.Equal(t, ": heartbeat\n\n", buf.String())
+}
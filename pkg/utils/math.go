@@ -1,5 +1,7 @@
 package utils
 
+import "time"
+
 // CalculatePageSkip calculates the offset for pagination
 //
 // Parameters:
@@ -11,3 +13,24 @@ package utils
 func CalculatePageSkip(page int, limit int) int {
 	return (page - 1) * limit
 }
+
+// ExponentialBackoff calculates the delay after a failed attempt, base doubled for every previous
+// attempt and capped at max
+//
+// Parameters:
+//   - attempt: attempt that just failed, starting at 1
+//   - base: delay after the first attempt
+//   - max: maximum delay
+//
+// Returns:
+//   - delay: delay before the next attempt
+func ExponentialBackoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
@@ -0,0 +1,16 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, utils.ExponentialBackoff(1, time.Second, time.Minute))
+	assert.Equal(t, 2*time.Second, utils.ExponentialBackoff(2, time.Second, time.Minute))
+	assert.Equal(t, 8*time.Second, utils.ExponentialBackoff(4, time.Second, time.Minute))
+	assert.Equal(t, time.Minute, utils.ExponentialBackoff(10, time.Second, time.Minute))
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+var leadingArticles = []string{"the", "a", "an"}
+
+// NormalizeTitle normalizes a title for similarity matching
+//
+// Parameters:
+//   - title: title as entered, for example "Hobbit, The"
+//
+// Returns:
+//   - string: lower case words without punctuation and leading article, for example "hobbit"
+func NormalizeTitle(title string) string {
+	title = strings.ToLower(strings.TrimSpace(title))
+
+	// move a trailing article back to the front, "hobbit, the" becomes "the hobbit"
+	if i := strings.LastIndex(title, ","); i >= 0 {
+		if suffix := strings.TrimSpace(title[i+1:]); AnyInSlice(leadingArticles, suffix) {
+			title = suffix + " " + title[:i]
+		}
+	}
+
+	words := strings.Fields(stripPunctuation(title))
+	if len(words) > 1 && AnyInSlice(leadingArticles, words[0]) {
+		words = words[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// NormalizeAuthor normalizes an author name for similarity matching
+//
+// Parameters:
+//   - author: author as entered, for example "Tolkien, J.R.R."
+//
+// Returns:
+//   - string: lower case words without punctuation in "first last" order, for example "j r r tolkien"
+func NormalizeAuthor(author string) string {
+	author = strings.ToLower(strings.TrimSpace(author))
+
+	// "last, first" becomes "first last"
+	if parts := strings.Split(author, ","); len(parts) == 2 {
+		author = parts[1] + " " + parts[0]
+	}
+
+	return strings.Join(strings.Fields(stripPunctuation(author)), " ")
+}
+
+func stripPunctuation(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+}
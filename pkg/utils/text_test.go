@@ -0,0 +1,21 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, "hobbit", utils.NormalizeTitle("The Hobbit"))
+	assert.Equal(t, "hobbit", utils.NormalizeTitle("Hobbit, The"))
+	assert.Equal(t, "lord of the rings", utils.NormalizeTitle("  The Lord of the Rings! "))
+	assert.Equal(t, "a", utils.NormalizeTitle("A"))
+}
+
+func TestNormalizeAuthor(t *testing.T) {
+	assert.Equal(t, "j r r tolkien", utils.NormalizeAuthor("J.R.R. Tolkien"))
+	assert.Equal(t, "j r r tolkien", utils.NormalizeAuthor("Tolkien, J.R.R."))
+	assert.Equal(t, "tolkien", utils.NormalizeAuthor("Tolkien"))
+}
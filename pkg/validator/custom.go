@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// TagCSVOneOf validates that every item of a comma separated string is one of
+// the space separated values given as the tag parameter, for example
+// `validate:"omitempty,csvoneof=id title author"`.
+const TagCSVOneOf = "csvoneof"
+
+func registerCustomValidators(v *validator.Validate, trans ut.Translator) error {
+	if err := v.RegisterValidation(TagCSVOneOf, validateCSVOneOf); err != nil {
+		return err
+	}
+
+	return v.RegisterTranslation(TagCSVOneOf, trans,
+		func(ut ut.Translator) error {
+			return ut.Add(TagCSVOneOf, "each item of {0} must be one of [{1}]", false)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			t, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+			if err != nil {
+				return fe.(error).Error()
+			}
+			return t
+		},
+	)
+}
+
+func validateCSVOneOf(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+	for _, item := range SplitCSV(fl.Field().String()) {
+		found := false
+		for _, a := range allowed {
+			if item == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SplitCSV splits a comma separated string, trimming spaces and dropping empty items.
+//
+// Parameters:
+//   - s: comma separated string
+//
+// Returns:
+//   - []string: non-empty items
+func SplitCSV(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -11,8 +11,6 @@ func NewValidator() (IValidatorService, error) {
 	// create validator
 	v := validator.New()
 
-	// register custom validators
-
 	// register english translator
 	english := en.New()
 	uni := ut.New(english, english)
@@ -21,6 +19,11 @@ func NewValidator() (IValidatorService, error) {
 	// register english translator
 	_ = en_translations.RegisterDefaultTranslations(v, trans)
 
+	// register custom validators
+	if err := registerCustomValidators(v, trans); err != nil {
+		return nil, err
+	}
+
 	return &Service{
 		Validate:      v,
 		Translator:    trans,
@@ -66,3 +66,29 @@ func TestTranslateToLocale(t *testing.T) {
 	assert.Equal(t, "Name", translatedErrors[0].Field)
 	assert.Equal(t, "Email", translatedErrors[1].Field)
 }
+
+type TestFieldsStruct struct {
+	Fields string `validate:"omitempty,csvoneof=id title author"`
+}
+
+func TestValidateCSVOneOf(t *testing.T) {
+	v, _ := NewValidator()
+
+	err := v.ValidateStruct(TestFieldsStruct{Fields: "id, title"})
+	assert.NoError(t, err)
+
+	err = v.ValidateStruct(TestFieldsStruct{Fields: ""})
+	assert.NoError(t, err)
+
+	err = v.ValidateStruct(TestFieldsStruct{Fields: "id,password"})
+	assert.Error(t, err)
+
+	translatedErrors := v.TranslateError(err)
+	assert.Len(t, translatedErrors, 1)
+	assert.Equal(t, "each item of Fields must be one of [id title author]", translatedErrors[0].Message)
+}
+
+func TestSplitCSV(t *testing.T) {
+	assert.Equal(t, []string{"id", "title"}, SplitCSV(" id, ,title,"))
+	assert.Empty(t, SplitCSV(""))
+}
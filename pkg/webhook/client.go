@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/utils"
+)
+
+// maxResponseBody is the number of response bytes kept for the delivery log.
+const maxResponseBody = 1024
+
+// Opts represents the options for configuring the webhook client.
+type Opts struct {
+	// HTTPClient sends the requests. Default has a 10 seconds timeout.
+	HTTPClient *http.Client
+	// MaxAttempts is the number of attempts per delivery. Default is 5.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every retry. Default is 1 second.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries. Default is 1 minute.
+	MaxDelay time.Duration
+}
+
+// Request is one delivery to one endpoint. EventID is the same for every delivery of an event so
+// receivers can drop duplicates.
+type Request struct {
+	URL     string
+	Secret  string
+	ID      string
+	EventID string
+	Event   string
+	Payload []byte
+}
+
+// Result is the outcome of a delivery after all attempts.
+type Result struct {
+	Attempts     int
+	StatusCode   int
+	ResponseBody string
+	Duration     time.Duration
+	Err          error
+}
+
+// Succeeded reports whether the endpoint accepted the delivery.
+func (r Result) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type IClient interface {
+	// Deliver posts the signed payload to the endpoint, retrying with exponential backoff on network
+	// errors, 429 and 5xx responses. It stops early when the context is done.
+	//
+	// Parameters:
+	//   - ctx: context
+	//   - req: delivery request
+	//
+	// Returns:
+	//   - Result: outcome of the last attempt
+	Deliver(ctx context.Context, req Request) Result
+}
+
+type Client struct {
+	httpClient  *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func NewClient(opts *Opts) IClient {
+	c := &Client{
+		httpClient:  opts.HTTPClient,
+		maxAttempts: opts.MaxAttempts,
+		baseDelay:   opts.BaseDelay,
+		maxDelay:    opts.MaxDelay,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if c.maxAttempts <= 0 {
+		c.maxAttempts = 5
+	}
+	if c.baseDelay <= 0 {
+		c.baseDelay = time.Second
+	}
+	if c.maxDelay <= 0 {
+		c.maxDelay = time.Minute
+	}
+	return c
+}
+
+func (c *Client) Deliver(ctx context.Context, req Request) Result {
+	start := time.Now()
+	result := Result{}
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		result.Attempts = attempt
+		result.StatusCode, result.ResponseBody, result.Err = c.send(ctx, req)
+		if result.Succeeded() || !retryable(result) || attempt == c.maxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(utils.ExponentialBackoff(attempt, c.baseDelay, c.maxDelay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			result.Err = errors.Join(result.Err, ctx.Err())
+			result.Duration = time.Since(start)
+			return result
+		case <-timer.C:
+		}
+	}
+
+	if result.Err == nil && !result.Succeeded() {
+		result.Err = fmt.Errorf("endpoint responded with status %d", result.StatusCode)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (c *Client) send(ctx context.Context, req Request) (int, string, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderID, req.ID)
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderEventID, req.EventID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(body), nil
+}
+
+// retryable reports whether a failed attempt may succeed later, client errors other than 429 will not.
+func retryable(result Result) bool {
+	return result.Err != nil || result.StatusCode == http.StatusTooManyRequests || result.StatusCode >= 500
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Event-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of a delivery, the HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+//
+// Parameters:
+//   - secret: subscription secret
+//   - timestamp: unix seconds sent in the timestamp header
+//   - body: request body
+//
+// Returns:
+//   - string: signature in the form sha256=<hex>
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery and that its timestamp is within tolerance, receivers
+// can use it to authenticate requests.
+//
+// Parameters:
+//   - secret: subscription secret
+//   - timestamp: value of the timestamp header
+//   - signature: value of the signature header
+//   - body: request body
+//   - tolerance: maximum age of the delivery, zero disables the check
+//
+// Returns:
+//   - bool: true if the signature is valid
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	if tolerance > 0 && time.Since(time.Unix(ts, 0)).Abs() > tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
@@ -0,0 +1,108 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Alwanly/go-codebase/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"book.created"}`)
+	now := time.Now().Unix()
+	signature := webhook.Sign("secret", now, body)
+
+	assert.True(t, webhook.Verify("secret", strconv.FormatInt(now, 10), signature, body, time.Minute))
+	assert.False(t, webhook.Verify("other", strconv.FormatInt(now, 10), signature, body, time.Minute))
+	assert.False(t, webhook.Verify("secret", strconv.FormatInt(now, 10), signature, []byte(`{}`), time.Minute))
+	assert.False(t, webhook.Verify("secret", strconv.FormatInt(now-3600, 10), webhook.Sign("secret", now-3600, body), body, time.Minute))
+}
+
+func TestDeliver_SignedRequest(t *testing.T) {
+	payload := []byte(`{"id":"1"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, payload, body)
+		assert.Equal(t, "book.created", r.Header.Get(webhook.HeaderEvent))
+		assert.Equal(t, "delivery-1", r.Header.Get(webhook.HeaderID))
+		assert.Equal(t, "event-1", r.Header.Get(webhook.HeaderEventID))
+		assert.True(t, webhook.Verify("secret", r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, time.Minute))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(&webhook.Opts{})
+	result := client.Deliver(context.Background(), webhook.Request{
+		URL:     server.URL,
+		Secret:  "secret",
+		ID:      "delivery-1",
+		EventID: "event-1",
+		Event:   "book.created",
+		Payload: payload,
+	})
+
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, http.StatusNoContent, result.StatusCode)
+}
+
+func TestDeliver_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(&webhook.Opts{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+	result := client.Deliver(context.Background(), webhook.Request{URL: server.URL, Secret: "secret"})
+
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, 3, result.Attempts)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDeliver_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte("gone"))
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(&webhook.Opts{MaxAttempts: 5, BaseDelay: time.Millisecond})
+	result := client.Deliver(context.Background(), webhook.Request{URL: server.URL, Secret: "secret"})
+
+	assert.False(t, result.Succeeded())
+	assert.Error(t, result.Err)
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, http.StatusGone, result.StatusCode)
+	assert.Equal(t, "gone", result.ResponseBody)
+}
+
+func TestDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(&webhook.Opts{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	result := client.Deliver(context.Background(), webhook.Request{URL: server.URL, Secret: "secret"})
+
+	assert.False(t, result.Succeeded())
+	assert.Equal(t, 3, result.Attempts)
+	assert.Equal(t, int32(3), calls.Load())
+}